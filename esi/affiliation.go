@@ -0,0 +1,63 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+)
+
+// ESI limits the number of characters whose affiliations may be obtained at
+// once.
+const maxAffiliationsPerRequest = 1000
+
+// Affiliation is the corporation, alliance, and faction a character belongs
+// to. AllianceID and FactionID are zero if the character has none.
+type Affiliation struct {
+	CharacterID   int32
+	CorporationID int32
+	AllianceID    int32
+	FactionID     int32
+}
+
+// Affiliations obtains the affiliations of the characters in bulk.
+//
+// ESI fails the whole request if any one of the IDs is not a character.
+func (x *Client) Affiliations(ctx context.Context, ids []int32) ([]Affiliation, error) {
+	as := make([]Affiliation, 0, len(ids))
+	for start := 0; start < len(ids); start += maxAffiliationsPerRequest {
+		end := start + maxAffiliationsPerRequest
+		if end > len(ids) {
+			end = len(ids)
+		}
+		p, err := x.t.characterAffiliation(ctx, ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range p {
+			if a == nil || a.CharacterID == nil {
+				continue
+			}
+			as = append(as, Affiliation{
+				CharacterID:   *a.CharacterID,
+				CorporationID: deref32(a.CorporationID),
+				AllianceID:    a.AllianceID,
+				FactionID:     a.FactionID,
+			})
+		}
+	}
+	return as, nil
+}
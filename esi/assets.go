@@ -0,0 +1,114 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+)
+
+const (
+	// MaxAssetNamesPerRequest is the most items ESI names at once.
+	MaxAssetNamesPerRequest = 1000
+	// NHangarDivisions is the number of hangar divisions every corporation
+	// has, numbered from 1.
+	NHangarDivisions = 7
+)
+
+// The location types of an asset.
+const (
+	AssetLocationStation     = "station"
+	AssetLocationSolarSystem = "solar_system"
+	AssetLocationItem        = "item"
+	AssetLocationOther       = "other"
+)
+
+// Asset is an item owned by a corporation. Its LocationID is a station,
+// structure, or solar system, or the ItemID of the asset containing it.
+type Asset struct {
+	ItemID          int64
+	TypeID          int32
+	LocationID      int64
+	LocationFlag    string
+	LocationType    string
+	Quantity        int32
+	IsSingleton     bool
+	IsBlueprintCopy bool
+}
+
+// CorporationAssets obtains every asset the corporation owns, as seen by the
+// character.
+func (x *AuthClient) CorporationAssets(ctx context.Context, charID, corpID int32) ([]Asset, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationAssetsScope)
+	if err != nil {
+		return nil, err
+	}
+	var as []Asset
+	for page, pages := int32(1), int32(1); page <= pages; page++ {
+		p, n, err := x.t.corporationAssets(ctx, auth, corpID, page)
+		if err != nil {
+			return nil, err
+		}
+		pages = n
+		for _, a := range p {
+			if a == nil || a.ItemID == nil {
+				continue
+			}
+			ca := Asset{
+				ItemID:          *a.ItemID,
+				TypeID:          deref32(a.TypeID),
+				LocationID:      deref64(a.LocationID),
+				LocationFlag:    derefString(a.LocationFlag),
+				LocationType:    derefString(a.LocationType),
+				Quantity:        deref32(a.Quantity),
+				IsBlueprintCopy: a.IsBlueprintCopy,
+			}
+			if a.IsSingleton != nil {
+				ca.IsSingleton = *a.IsSingleton
+			}
+			as = append(as, ca)
+		}
+	}
+	return as, nil
+}
+
+// CorporationAssetNames obtains the names players gave to the corporation's
+// assembled containers and ships, as seen by the character. Items without a
+// name are absent.
+func (x *AuthClient) CorporationAssetNames(ctx context.Context, charID, corpID int32, itemIDs []int64) (map[int64]string, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationAssetsScope)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int64]string, len(itemIDs))
+	for start := 0; start < len(itemIDs); start += MaxAssetNamesPerRequest {
+		end := start + MaxAssetNamesPerRequest
+		if end > len(itemIDs) {
+			end = len(itemIDs)
+		}
+		p, err := x.t.corporationAssetNames(ctx, auth, corpID, itemIDs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range p {
+			if n == nil || n.ItemID == nil || n.Name == nil || len(*n.Name) == 0 || *n.Name == "None" {
+				continue
+			}
+			m[*n.ItemID] = *n.Name
+		}
+	}
+	return m, nil
+}
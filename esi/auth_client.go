@@ -0,0 +1,114 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+)
+
+// TokenSource provides a character's tokens, refreshed such that the access
+// token may be used right away.
+type TokenSource interface {
+	Token(c context.Context, charID int32) (*Tokens, error)
+}
+
+// MissingScopeError is returned when a character has not granted the scope
+// that an authenticated ESI call requires.
+type MissingScopeError struct {
+	CharacterID int32
+	Scope       Scope
+}
+
+func (m *MissingScopeError) Error() string {
+	return fmt.Sprintf("character %d has not granted the scope %s", m.CharacterID, m.Scope)
+}
+
+// AuthClient issues authenticated ESI calls on behalf of characters, using
+// their stored tokens.
+type AuthClient struct {
+	t  *ThinClient
+	ts TokenSource
+}
+
+// Authenticated returns a client for authenticated ESI calls, which shares
+// this Client's ThinClient.
+func (x *Client) Authenticated(ts TokenSource) *AuthClient {
+	return &AuthClient{
+		t:  x.t,
+		ts: ts,
+	}
+}
+
+// auth obtains the bearer token for the character, ensuring it was granted
+// the required scope.
+func (x *AuthClient) auth(ctx context.Context, charID int32, scope Scope) (runtime.ClientAuthInfoWriter, error) {
+	t, err := x.ts.Token(ctx, charID)
+	if err != nil {
+		return nil, err
+	}
+	if !t.HasScope(string(scope)) {
+		return nil, &MissingScopeError{
+			CharacterID: charID,
+			Scope:       scope,
+		}
+	}
+	return httptransport.BearerToken(t.Access), nil
+}
+
+type WalletBalance struct {
+	Division int32
+	Balance  float64
+}
+
+// CorporationWallets obtains the balance of each of the corporation's wallet
+// divisions, as seen by the character.
+func (x *AuthClient) CorporationWallets(ctx context.Context, charID, corpID int32) ([]WalletBalance, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationWalletsScope)
+	if err != nil {
+		return nil, err
+	}
+	p, err := x.t.corporationWallets(ctx, auth, corpID)
+	if err != nil {
+		return nil, err
+	}
+	wb := make([]WalletBalance, 0, len(p))
+	for _, w := range p {
+		var b WalletBalance
+		if w.Division != nil {
+			b.Division = *w.Division
+		}
+		if w.Balance != nil {
+			b.Balance = *w.Balance
+		}
+		wb = append(wb, b)
+	}
+	return wb, nil
+}
+
+// CorporationMembers obtains the IDs of the corporation's member characters,
+// as seen by the character.
+func (x *AuthClient) CorporationMembers(ctx context.Context, charID, corpID int32) ([]int32, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationMembershipScope)
+	if err != nil {
+		return nil, err
+	}
+	return x.t.corporationMembers(ctx, auth, corpID)
+}
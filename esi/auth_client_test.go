@@ -0,0 +1,316 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/esi/esitest"
+)
+
+// tokenSource keeps characters' tokens in memory and refreshes them through
+// the single sign on the way the ESI service does.
+type tokenSource struct {
+	o  *esi.OAuth2Client
+	mu sync.Mutex
+	m  map[int32]*esi.Tokens
+}
+
+func newTokenSource(s *esitest.Server) *tokenSource {
+	return &tokenSource{
+		o: s.OAuth2Client(""),
+		m: make(map[int32]*esi.Tokens),
+	}
+}
+
+func (ts *tokenSource) Token(c context.Context, charID int32) (*esi.Tokens, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	t, ok := ts.m[charID]
+	if !ok {
+		return nil, fmt.Errorf("no tokens for character %d", charID)
+	}
+	if time.Now().Add(time.Minute).Before(t.AccessExpires) {
+		return t, nil
+	}
+	t, err := ts.refresh(t.Refresh)
+	if err != nil {
+		return nil, err
+	}
+	ts.m[charID] = t
+	return t, nil
+}
+
+func (ts *tokenSource) refresh(refresh string) (*esi.Tokens, error) {
+	jr, err := ts.o.GetRefresh(refresh)
+	if err != nil {
+		return nil, err
+	}
+	ks, err := ts.o.FetchEveOnlineKeys()
+	if err != nil {
+		return nil, err
+	}
+	claims, err := esi.ValidateToken([]byte(jr.AccessToken), ks)
+	if err != nil {
+		return nil, err
+	}
+	if err := esi.ValidateEveClaims(claims); err != nil {
+		return nil, err
+	}
+	return esi.NewTokens(jr, claims)
+}
+
+func TestTokenRefresh(t *testing.T) {
+	s := newTestServer(t)
+	ts := newTokenSource(s)
+	tok, err := s.Tokens(testCEOID, esi.ReadCorporationMembershipScope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.CID != int(testCEOID) || tok.CName != "Test CEO" || !tok.HasScope(string(esi.ReadCorporationMembershipScope)) {
+		t.Fatalf("got tokens for %d %q with scopes %v", tok.CID, tok.CName, tok.Scopes)
+	}
+	// The access token is about to expire, so it is refreshed before use.
+	stale := *tok
+	stale.AccessExpires = time.Now()
+	ts.m[testCEOID] = &stale
+
+	x := esi.New(s.ThinClient(), 4).Authenticated(ts)
+	ids, err := x.CorporationMembers(context.Background(), testCEOID, testCorpID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 {
+		t.Errorf("got %d members, want 2", len(ids))
+	}
+	if n := s.Refreshes(); n != 1 {
+		t.Errorf("got %d refreshes, want 1", n)
+	}
+	fresh := ts.m[testCEOID]
+	if fresh.Access == tok.Access || fresh.Refresh == tok.Refresh {
+		t.Error("tokens were not replaced by the refresh")
+	}
+	if !fresh.HasScope(string(esi.ReadCorporationMembershipScope)) {
+		t.Errorf("refreshed tokens lost their scopes: %v", fresh.Scopes)
+	}
+	// The single sign on rotates refresh tokens, so the old one is spent.
+	if _, err := ts.refresh(tok.Refresh); err == nil {
+		t.Error("spent refresh token was accepted")
+	}
+	if _, err := x.CorporationMembers(context.Background(), testCEOID, testCorpID); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.Refreshes(); n != 1 {
+		t.Errorf("got %d refreshes, want the fresh token to be reused", n)
+	}
+}
+
+func TestMissingScope(t *testing.T) {
+	s := newTestServer(t)
+	ts := newTokenSource(s)
+	tok, err := s.Tokens(testCEOID, esi.ReadCorporationMembershipScope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.m[testCEOID] = tok
+	x := esi.New(s.ThinClient(), 4).Authenticated(ts)
+	_, err = x.CorporationAssets(context.Background(), testCEOID, testCorpID)
+	var mse *esi.MissingScopeError
+	if !errors.As(err, &mse) || mse.Scope != esi.ReadCorporationAssetsScope {
+		t.Fatalf("got %v, want a missing assets scope error", err)
+	}
+	if n := s.Requests(fmt.Sprintf("/corporations/%d/assets/", testCorpID)); n != 0 {
+		t.Errorf("got %d requests without the scope, want 0", n)
+	}
+}
+
+func TestPagedCorporationAssets(t *testing.T) {
+	s := newTestServer(t)
+	const n = 250
+	as := make([]esi.Asset, n)
+	for i := range as {
+		as[i] = esi.Asset{
+			ItemID:       int64(1000000 + i),
+			TypeID:       34,
+			LocationID:   int64(esitest.JitaStationID),
+			LocationFlag: "CorpSAG1",
+			LocationType: esi.AssetLocationStation,
+			Quantity:     int32(i + 1),
+		}
+	}
+	s.AddCorporationAssets(testCorpID, as...)
+	ts := newTokenSource(s)
+	tok, err := s.Tokens(testCEOID, esi.ReadCorporationAssetsScope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.m[testCEOID] = tok
+
+	x := esi.New(s.ThinClient(), 4).Authenticated(ts)
+	got, err := x.CorporationAssets(context.Background(), testCEOID, testCorpID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != n {
+		t.Fatalf("got %d assets, want %d", len(got), n)
+	}
+	for i, a := range got {
+		if a != as[i] {
+			t.Fatalf("asset %d: got %+v, want %+v", i, a, as[i])
+		}
+	}
+	if r := s.Requests(fmt.Sprintf("/corporations/%d/assets/", testCorpID)); r != 3 {
+		t.Errorf("got %d requests, want one for each of the 3 pages", r)
+	}
+}
+
+func TestCorporationTransactionsWalkBackToAfterID(t *testing.T) {
+	s := newTestServer(t)
+	const n = 120
+	ts := make([]esi.Transaction, n)
+	for i := range ts {
+		ts[i] = esi.Transaction{
+			ID:         int64(i + 1),
+			Date:       time.Date(2021, 1, 1, 0, i, 0, 0, time.UTC),
+			TypeID:     34,
+			Quantity:   1,
+			UnitPrice:  5,
+			ClientID:   testMemberID,
+			LocationID: int64(esitest.JitaStationID),
+		}
+	}
+	s.AddCorporationTransactions(testCorpID, 1, ts...)
+	src := newTokenSource(s)
+	tok, err := s.Tokens(testCEOID, esi.ReadCorporationWalletsScope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src.m[testCEOID] = tok
+
+	x := esi.New(s.ThinClient(), 4).Authenticated(src)
+	const afterID = 30
+	got, err := x.CorporationTransactions(context.Background(), testCEOID, testCorpID, 1, afterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != n-afterID {
+		t.Fatalf("got %d transactions, want %d", len(got), n-afterID)
+	}
+	seen := make(map[int64]bool, len(got))
+	for _, tr := range got {
+		if tr.ID <= afterID || seen[tr.ID] {
+			t.Fatalf("got transaction %d again or from before %d", tr.ID, afterID)
+		}
+		seen[tr.ID] = true
+	}
+	if r := s.Requests(fmt.Sprintf("/corporations/%d/wallets/1/transactions/", testCorpID)); r != 2 {
+		t.Errorf("got %d requests, want 2 pages walked back", r)
+	}
+}
+
+// TestCorporationSyncs covers the authenticated calls the periodic corporation
+// syncs make, so that each of the Server's routes is exercised.
+func TestCorporationSyncs(t *testing.T) {
+	s := newTestServer(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	s.AddCorporationIndustryJobs(testCorpID, esi.IndustryJob{
+		ID:          1,
+		ActivityID:  1,
+		Status:      "active",
+		InstallerID: testMemberID,
+		StartDate:   now,
+		EndDate:     now.Add(time.Hour),
+	})
+	s.AddCorporationContracts(testCorpID, esi.Contract{
+		ID:              2,
+		Type:            esi.ContractCourier,
+		Status:          esi.ContractOutstanding,
+		IssuerID:        testMemberID,
+		StartLocationID: int64(esitest.JitaStationID),
+		EndLocationID:   int64(esitest.JitaStationID),
+		DateIssued:      now,
+		DateExpired:     now.Add(24 * time.Hour),
+	})
+	s.AddCorporationContacts(testCorpID, esi.Contact{ID: 98000002, Type: "corporation", Standing: -10})
+	s.AddAllianceContacts(testAllianceID, esi.Contact{ID: 99000002, Type: "alliance", Standing: 5})
+	s.AddMiningObserver(testCorpID, esi.MiningObserver{ID: 1000000000001, Type: "structure", LastUpdated: now},
+		esi.MiningLedgerEntry{CharacterID: testMemberID, RecordedCorporationID: testCorpID, TypeID: 45490, Quantity: 1000, Day: now.Truncate(24 * time.Hour)})
+	src := newTokenSource(s)
+	tok, err := s.Tokens(testCEOID,
+		esi.ReadCorporationIndustryJobsScope,
+		esi.ReadCorporationContractsScope,
+		esi.ReadCorporationContactsScope,
+		esi.ReadAllianceContactsScope,
+		esi.ReadCorporationMiningScope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src.m[testCEOID] = tok
+	x := esi.New(s.ThinClient(), 4)
+	ax := x.Authenticated(src)
+	c := context.Background()
+
+	for _, test := range []struct {
+		name string
+		fn   func() (int, error)
+	}{
+		{"industry jobs", func() (int, error) {
+			js, err := ax.CorporationIndustryJobs(c, testCEOID, testCorpID)
+			return len(js), err
+		}},
+		{"contracts", func() (int, error) {
+			cs, err := ax.CorporationContracts(c, testCEOID, testCorpID)
+			return len(cs), err
+		}},
+		{"corporation contacts", func() (int, error) {
+			cs, err := ax.CorporationContacts(c, testCEOID, testCorpID)
+			return len(cs), err
+		}},
+		{"alliance contacts", func() (int, error) {
+			cs, err := ax.AllianceContacts(c, testCEOID, testAllianceID)
+			return len(cs), err
+		}},
+		{"mining observers", func() (int, error) {
+			os, err := ax.CorporationMiningObservers(c, testCEOID, testCorpID)
+			return len(os), err
+		}},
+		{"mining ledger", func() (int, error) {
+			es, err := ax.CorporationMiningLedger(c, testCEOID, testCorpID, 1000000000001)
+			return len(es), err
+		}},
+		{"affiliations", func() (int, error) {
+			as, err := x.Affiliations(c, []int32{testMemberID})
+			if err == nil && len(as) == 1 && (as[0].CorporationID != testCorpID || as[0].AllianceID != testAllianceID) {
+				err = fmt.Errorf("got affiliation %+v", as[0])
+			}
+			return len(as), err
+		}},
+	} {
+		n, err := test.fn()
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else if n != 1 {
+			t.Errorf("%s: got %d, want 1", test.name, n)
+		}
+	}
+}
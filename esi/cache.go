@@ -0,0 +1,294 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gregjones/httpcache"
+	"github.com/pascaldekloe/jwt"
+)
+
+// CacheStore persists ESI responses so that they outlive the process and may
+// be shared between processes.
+type CacheStore interface {
+	GetESIResponse(c context.Context, key string) (b []byte, found bool, err error)
+	SetESIResponse(c context.Context, key string, b []byte, expires time.Time) error
+	DeleteESIResponse(c context.Context, key string) error
+}
+
+var _ httpcache.Cache = &Cache{}
+
+// Cache stores ESI responses keyed by their route and parameters.
+//
+// It is meant to be used by a httpcache.Transport, which honors the Expires
+// and ETag headers that ESI provides: fresh responses are served from the
+// cache, and stale ones are revalidated with a conditional request.
+//
+// Until a CacheStore is set, responses are only cached in memory. Responses to
+// authenticated requests, and those ESI marks as private, are never given to
+// the CacheStore, as they hold a character's private data.
+type Cache struct {
+	ctx   context.Context
+	mem   *memoryCache
+	mu    sync.RWMutex
+	store CacheStore
+	onErr func(error)
+}
+
+func NewCache(ctx context.Context) *Cache {
+	return &Cache{
+		ctx: ctx,
+		mem: newMemoryCache(),
+	}
+}
+
+// SetStore begins persisting responses in the CacheStore. Errors from the
+// store are reported to onErr, and are otherwise treated as a cache miss.
+func (x *Cache) SetStore(s CacheStore, onErr func(error)) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.store = s
+	x.onErr = onErr
+}
+
+// Transport wraps the RoundTripper so that it is cached by this Cache.
+//
+// Requests carrying an Authorization header are only cached in memory, and
+// only for the character whose token it is. They are not cached at all if the
+// token does not name its character.
+func (x *Cache) Transport(t http.RoundTripper) http.RoundTripper {
+	shared := httpcache.NewTransport(x)
+	shared.Transport = t
+	return &cacheTransport{
+		shared: shared,
+		mem:    x.mem,
+		next:   t,
+	}
+}
+
+func (x *Cache) getStore() (CacheStore, func(error)) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return x.store, x.onErr
+}
+
+func (x *Cache) Get(key string) ([]byte, bool) {
+	if b, found := x.mem.Get(key); found {
+		return b, found
+	}
+	s, onErr := x.getStore()
+	if s == nil {
+		return nil, false
+	}
+	b, found, err := s.GetESIResponse(x.ctx, key)
+	if err != nil {
+		onErr(err)
+		return nil, false
+	}
+	return b, found
+}
+
+func (x *Cache) Set(key string, b []byte) {
+	h := dumpedHeader(b)
+	s, onErr := x.getStore()
+	if s == nil || isPrivate(h) {
+		x.mem.set(key, b, cachedExpiry(h))
+		return
+	}
+	if err := s.SetESIResponse(x.ctx, key, b, cachedExpiry(h)); err != nil {
+		onErr(err)
+	}
+}
+
+func (x *Cache) Delete(key string) {
+	x.mem.Delete(key)
+	s, onErr := x.getStore()
+	if s == nil {
+		return
+	}
+	if err := s.DeleteESIResponse(x.ctx, key); err != nil {
+		onErr(err)
+	}
+}
+
+// cacheTransport sends authenticated requests through a transport that only
+// caches in memory under the token's subject, and all others through the
+// shared Cache.
+type cacheTransport struct {
+	shared *httpcache.Transport
+	mem    *memoryCache
+	next   http.RoundTripper
+}
+
+func (t *cacheTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	auth := r.Header.Get("Authorization")
+	if auth == "" {
+		return t.shared.RoundTrip(r)
+	}
+	sub := tokenSubject(auth)
+	if sub == "" {
+		return t.next.RoundTrip(r)
+	}
+	private := httpcache.NewTransport(&subjectCache{sub, t.mem})
+	private.Transport = t.next
+	return private.RoundTrip(r)
+}
+
+// tokenSubject obtains the subject of the bearer token, which is empty if it
+// is not a JWT. The token is not verified, as it is only used to tell apart
+// whose responses are cached and ESI verifies it.
+func tokenSubject(auth string) string {
+	const bearer = "Bearer "
+	if !strings.HasPrefix(auth, bearer) {
+		return ""
+	}
+	c, err := jwt.ParseWithoutCheck([]byte(strings.TrimPrefix(auth, bearer)))
+	if err != nil {
+		return ""
+	}
+	return c.Subject
+}
+
+var _ httpcache.Cache = &subjectCache{}
+
+// subjectCache keys the responses for one token subject apart from all others
+// with the same URL.
+type subjectCache struct {
+	subject string
+	mem     *memoryCache
+}
+
+func (x *subjectCache) key(key string) string {
+	return x.subject + " " + key
+}
+
+func (x *subjectCache) Get(key string) ([]byte, bool) {
+	return x.mem.Get(x.key(key))
+}
+
+func (x *subjectCache) Set(key string, b []byte) {
+	x.mem.Set(x.key(key), b)
+}
+
+func (x *subjectCache) Delete(key string) {
+	x.mem.Delete(x.key(key))
+}
+
+// memoryRetention is how long a response is kept in memory after it expires,
+// so that its ETag may still be used to revalidate it.
+const memoryRetention = time.Hour
+
+var _ httpcache.Cache = &memoryCache{}
+
+// memoryCache keeps responses in memory, forgetting them a while after they
+// expire so that it does not grow without bound.
+type memoryCache struct {
+	mu    sync.Mutex
+	m     map[string]memoryEntry
+	swept time.Time
+}
+
+type memoryEntry struct {
+	b       []byte
+	expires time.Time
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{
+		m:     make(map[string]memoryEntry),
+		swept: time.Now(),
+	}
+}
+
+func (x *memoryCache) Get(key string) ([]byte, bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	e, ok := x.m[key]
+	return e.b, ok
+}
+
+func (x *memoryCache) Set(key string, b []byte) {
+	x.set(key, b, cachedExpiry(dumpedHeader(b)))
+}
+
+func (x *memoryCache) set(key string, b []byte, expires time.Time) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	now := time.Now()
+	if now.Sub(x.swept) > memoryRetention {
+		for k, e := range x.m {
+			if now.Sub(e.expires) > memoryRetention {
+				delete(x.m, k)
+			}
+		}
+		x.swept = now
+	}
+	x.m[key] = memoryEntry{
+		b:       b,
+		expires: expires,
+	}
+}
+
+func (x *memoryCache) Delete(key string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	delete(x.m, key)
+}
+
+// dumpedHeader obtains the header of a dumped response, or nil if it is
+// malformed.
+func dumpedHeader(b []byte) http.Header {
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), nil)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	return resp.Header
+}
+
+// isPrivate determines whether the response may only be cached for the one
+// who requested it.
+func isPrivate(h http.Header) bool {
+	for _, v := range h.Values("Cache-Control") {
+		for _, d := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(d), "private") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cachedExpiry determines when a dumped response is no longer fresh, so that
+// the store can eventually prune it.
+//
+// If the Expires header is missing or malformed, the response is considered
+// already expired. It is still kept, as its ETag may be used to revalidate it.
+func cachedExpiry(h http.Header) time.Time {
+	exp, err := http.ParseTime(h.Get("Expires"))
+	if err != nil {
+		return time.Now()
+	}
+	return exp
+}
@@ -0,0 +1,166 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeCacheStore struct {
+	mu sync.Mutex
+	m  map[string][]byte
+}
+
+func (f *fakeCacheStore) GetESIResponse(c context.Context, key string) ([]byte, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.m[key]
+	return b, ok, nil
+}
+
+func (f *fakeCacheStore) SetESIResponse(c context.Context, key string, b []byte, expires time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.m[key] = b
+	return nil
+}
+
+func (f *fakeCacheStore) DeleteESIResponse(c context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.m, key)
+	return nil
+}
+
+func TestCachePersistsOnlySharedResponses(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Expires", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		if r.URL.Path == "/private" {
+			w.Header().Set("Cache-Control", "private")
+		}
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	store := &fakeCacheStore{m: make(map[string][]byte)}
+	x := NewCache(context.Background())
+	x.SetStore(store, func(err error) { t.Fatal(err) })
+	h := &http.Client{Transport: x.Transport(http.DefaultTransport)}
+
+	get := func(path string, auth bool) string {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if auth {
+			req.Header.Set("Authorization", "Bearer "+testToken("CHARACTER:EVE:90000001"))
+		}
+		resp, err := h.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	for _, test := range []struct {
+		path      string
+		auth      bool
+		persisted bool
+	}{
+		{"/public", false, true},
+		{"/mail", true, false},
+		{"/private", false, false},
+	} {
+		for i := 0; i < 2; i++ {
+			if b := get(test.path, test.auth); b != test.path {
+				t.Fatalf("%s: got body %q", test.path, b)
+			}
+		}
+		if _, ok := store.m[srv.URL+test.path]; ok != test.persisted {
+			t.Errorf("%s: persisted=%v, want %v", test.path, ok, test.persisted)
+		}
+	}
+	if hits != 3 {
+		t.Errorf("got %d requests to the server, want 3 as repeats are cached", hits)
+	}
+}
+
+// testToken makes an unsigned JWT naming the subject, which is all the cache
+// reads of a token.
+func testToken(sub string) string {
+	enc := base64.RawURLEncoding.EncodeToString
+	return enc([]byte(`{"alg":"RS256","typ":"JWT"}`)) + "." + enc([]byte(`{"sub":"`+sub+`"}`)) + "." + enc([]byte("signature"))
+}
+
+func TestCacheKeepsEachCharactersResponses(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Expires", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer srv.Close()
+
+	x := NewCache(context.Background())
+	h := &http.Client{Transport: x.Transport(http.DefaultTransport)}
+	get := func(auth string) string {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/corporations/98000001/wallets", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", auth)
+		resp, err := h.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	a := "Bearer " + testToken("CHARACTER:EVE:90000001")
+	b := "Bearer " + testToken("CHARACTER:EVE:90000002")
+	for _, auth := range []string{a, a, b, b} {
+		if got := get(auth); got != auth {
+			t.Fatalf("got the response to %q for %q", got, auth)
+		}
+	}
+	if hits != 2 {
+		t.Errorf("got %d requests to the server, want 2 as each character's repeat is cached", hits)
+	}
+	if got := get("Bearer opaque"); got != "Bearer opaque" || hits != 3 {
+		t.Errorf("token without a subject got %q after %d requests", got, hits)
+	}
+	if get("Bearer opaque"); hits != 4 {
+		t.Errorf("token without a subject was cached")
+	}
+}
@@ -0,0 +1,149 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	CalendarAccepted     = "accepted"
+	CalendarDeclined     = "declined"
+	CalendarTentative    = "tentative"
+	CalendarNotResponded = "not_responded"
+)
+
+const (
+	CalendarOwnerEveServer   = "eve_server"
+	CalendarOwnerCorporation = "corporation"
+	CalendarOwnerFaction     = "faction"
+	CalendarOwnerCharacter   = "character"
+	CalendarOwnerAlliance    = "alliance"
+)
+
+// IsCalendarResponse determines whether the response may be given to a
+// calendar event.
+func IsCalendarResponse(r string) bool {
+	return r == CalendarAccepted || r == CalendarDeclined || r == CalendarTentative
+}
+
+// CalendarEvent is an event on a character's calendar. The Response is the
+// character's own response to it.
+type CalendarEvent struct {
+	ID         int32
+	Date       time.Time
+	Title      string
+	Importance int32
+	Response   string
+	// Only set when obtained in detail
+	Duration  time.Duration
+	OwnerID   int32
+	OwnerName string
+	OwnerType string
+	Text      string
+}
+
+// CalendarEvents obtains the character's upcoming calendar events, without
+// their details.
+func (x *AuthClient) CalendarEvents(ctx context.Context, charID int32) ([]CalendarEvent, error) {
+	auth, err := x.auth(ctx, charID, ReadCalendarEventsScope)
+	if err != nil {
+		return nil, err
+	}
+	var es []CalendarEvent
+	var from *int32
+	seen := make(map[int32]bool)
+	for {
+		p, err := x.t.characterCalendar(ctx, auth, charID, from)
+		if err != nil {
+			return nil, err
+		}
+		n := len(es)
+		for _, e := range p {
+			if e == nil || seen[e.EventID] {
+				continue
+			}
+			seen[e.EventID] = true
+			es = append(es, CalendarEvent{
+				ID:         e.EventID,
+				Date:       time.Time(e.EventDate),
+				Title:      e.Title,
+				Importance: e.Importance,
+				Response:   e.EventResponse,
+			})
+		}
+		if len(es) == n {
+			break
+		}
+		last := es[len(es)-1].ID
+		from = &last
+	}
+	return es, nil
+}
+
+// CalendarEvent obtains the details of an event on the character's calendar.
+func (x *AuthClient) CalendarEvent(ctx context.Context, charID, eventID int32) (*CalendarEvent, error) {
+	auth, err := x.auth(ctx, charID, ReadCalendarEventsScope)
+	if err != nil {
+		return nil, err
+	}
+	p, err := x.t.characterCalendarEvent(ctx, auth, charID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	e := &CalendarEvent{
+		ID:         eventID,
+		Importance: deref32(p.Importance),
+		OwnerID:    deref32(p.OwnerID),
+		Duration:   time.Duration(deref32(p.Duration)) * time.Minute,
+	}
+	if p.Date != nil {
+		e.Date = time.Time(*p.Date)
+	}
+	if p.Title != nil {
+		e.Title = *p.Title
+	}
+	if p.Response != nil {
+		e.Response = *p.Response
+	}
+	if p.OwnerName != nil {
+		e.OwnerName = *p.OwnerName
+	}
+	if p.OwnerType != nil {
+		e.OwnerType = *p.OwnerType
+	}
+	if p.Text != nil {
+		e.Text = *p.Text
+	}
+	return e, nil
+}
+
+// RespondToCalendarEvent sets the character's response to an event on their
+// calendar, which must be accepted, declined or tentative.
+func (x *AuthClient) RespondToCalendarEvent(ctx context.Context, charID, eventID int32, response string) error {
+	if !IsCalendarResponse(response) {
+		return errors.Errorf("invalid calendar event response: %s", response)
+	}
+	auth, err := x.auth(ctx, charID, RespondCalendarEventsScope)
+	if err != nil {
+		return err
+	}
+	return x.t.respondCalendarEvent(ctx, auth, charID, eventID, response)
+}
@@ -18,8 +18,8 @@ package esi
 
 import (
 	"context"
+	"fmt"
 	"net/url"
-	"sync"
 	"time"
 
 	"github.com/cjslep/dharma/internal/util"
@@ -29,11 +29,18 @@ import (
 
 type Client struct {
 	t *ThinClient
+	// maxConcurrent bounds the goroutines hydrating a list of entities.
+	maxConcurrent int
+	g             callGroup
+	u             *universeCache
 }
 
-func New(t *ThinClient) *Client {
+func New(t *ThinClient, maxConcurrent int) *Client {
 	return &Client{
-		t: t,
+		t:             t,
+		maxConcurrent: maxConcurrent,
+		g:             callGroup{timeout: t.Timeout},
+		u:             newUniverseCache(),
 	}
 }
 
@@ -89,23 +96,53 @@ type Character struct {
 }
 
 type Faction struct {
-	ID int32 `json:"id,omitempty"`
+	ID       int32 `json:"id,omitempty"`
+	Hydrated bool  `json:"-"`
+	// Only set if hydrated
+	Name               string       `json:"name,omitempty"`
+	Description        string       `json:"description,omitempty"`
+	Corporation        *Corporation `json:"corporation,omitempty"`
+	MilitiaCorporation *Corporation `json:"militia_corporation,omitempty"`
+	SolarSystemID      int32        `json:"solar_system_id,omitempty"`
 }
 
 type Station struct {
-	ID int32 `json:"id,omitempty"`
+	ID       int32 `json:"id,omitempty"`
+	Hydrated bool  `json:"-"`
+	// Only set if hydrated
+	Name     string       `json:"name,omitempty"`
+	SystemID int32        `json:"system_id,omitempty"`
+	Owner    *Corporation `json:"owner,omitempty"`
+	Race     *Race        `json:"race,omitempty"`
+	Services []string     `json:"services,omitempty"`
 }
 
 type Ancestry struct {
-	ID int32 `json:"id,omitempty"`
+	ID       int32 `json:"id,omitempty"`
+	Hydrated bool  `json:"-"`
+	// Only set if hydrated
+	Name             string     `json:"name,omitempty"`
+	Description      string     `json:"description,omitempty"`
+	ShortDescription string     `json:"short_description,omitempty"`
+	Bloodline        *Bloodline `json:"bloodline,omitempty"`
 }
 
 type Bloodline struct {
-	ID int32 `json:"id,omitempty"`
+	ID       int32 `json:"id,omitempty"`
+	Hydrated bool  `json:"-"`
+	// Only set if hydrated
+	Name        string       `json:"name,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Race        *Race        `json:"race,omitempty"`
+	Corporation *Corporation `json:"corporation,omitempty"`
 }
 
 type Race struct {
-	ID int32 `json:"id,omitempty"`
+	ID       int32 `json:"id,omitempty"`
+	Hydrated bool  `json:"-"`
+	// Only set if hydrated
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 type PortraitURLs struct {
@@ -191,7 +228,7 @@ func (x *Client) GetPortrait(ctx context.Context, id int32) (*PortraitURLs, erro
 
 // SearchCorp returns a slice of Corporations matching the search query
 //
-// Hydrates the Corporation, any associated alliance, the CEO, and Creator.
+// Hydrates each Corporation the same as Corporation does.
 func (x *Client) SearchCorp(ctx context.Context, q string, l language.Tag) ([]*Corporation, error) {
 	p, err := x.t.search(ctx, q, []string{"corporation"}, false, l)
 	if err != nil {
@@ -200,22 +237,16 @@ func (x *Client) SearchCorp(ctx context.Context, q string, l language.Tag) ([]*C
 	if len(p.Corporation) == 0 {
 		return nil, nil
 	}
-	var wg sync.WaitGroup
-	wg.Add(len(p.Corporation))
 	corps := make([]*Corporation, len(p.Corporation))
-	errs := make([]error, len(p.Corporation))
-	for idx, c := range p.Corporation {
-		go func(idx int, corpID int32) {
-			defer wg.Done()
-			corp, err := x.Corporation(ctx, corpID)
-			if err != nil {
-				errs[idx] = errors.Wrapf(err, "error fetching corporation id: %d", corpID)
-				return
-			}
-			corps[idx] = corp
-		}(idx, c)
-	}
-	wg.Wait()
+	errs := forEach(len(p.Corporation), x.maxConcurrent, func(idx int) error {
+		corpID := p.Corporation[idx]
+		corp, err := x.Corporation(ctx, corpID, l)
+		if err != nil {
+			return errors.Wrapf(err, "error fetching corporation id: %d", corpID)
+		}
+		corps[idx] = corp
+		return nil
+	})
 	err = util.ToErrors(errs)
 	if err != nil {
 		return nil, err
@@ -225,8 +256,9 @@ func (x *Client) SearchCorp(ctx context.Context, q string, l language.Tag) ([]*C
 
 // Character obtains information about the character.
 //
-// Hydrates the Corporation, and any associated alliance.
-func (x *Client) Character(ctx context.Context, id int32) (*Character, error) {
+// Hydrates the Corporation, any associated alliance and faction, and the race,
+// bloodline, and ancestry localized into the language.
+func (x *Client) Character(ctx context.Context, id int32, l language.Tag) (*Character, error) {
 	c, err := x.hydrateCharacter(ctx, id)
 	if err != nil {
 		return nil, err
@@ -245,27 +277,42 @@ func (x *Client) Character(ctx context.Context, id int32) (*Character, error) {
 			return nil, errors.Wrapf(err, "error fetching alliance id: %d", aID)
 		}
 	}
+	lang := esiLanguage(l)
+	if c.Faction != nil {
+		if c.Faction, err = x.hydrateFaction(ctx, c.Faction, lang); err != nil {
+			return nil, err
+		}
+	}
+	if c.Race != nil {
+		if c.Race, err = x.hydrateRace(ctx, c.Race, lang); err != nil {
+			return nil, err
+		}
+	}
+	if c.Bloodline != nil {
+		if c.Bloodline, err = x.hydrateBloodline(ctx, c.Bloodline, lang); err != nil {
+			return nil, err
+		}
+	}
+	if c.Ancestry != nil {
+		if c.Ancestry, err = x.hydrateAncestry(ctx, c.Ancestry, lang); err != nil {
+			return nil, err
+		}
+	}
 	return c, nil
 }
 
-// Characters is the same as Character but in parallel for multiple.
-func (x *Client) Characters(ctx context.Context, ids []int32) ([]*Character, error) {
-	var wg sync.WaitGroup
-	wg.Add(len(ids))
+// Characters is the same as Character but in parallel for multiple, bounded
+// by the maximum concurrency.
+func (x *Client) Characters(ctx context.Context, ids []int32, l language.Tag) ([]*Character, error) {
 	chars := make([]*Character, len(ids))
-	errs := make([]error, len(ids))
-	for idx, id := range ids {
-		go func(idx int, id int32) {
-			defer wg.Done()
-			ch, err := x.Character(ctx, id)
-			if err != nil {
-				errs[idx] = err
-			} else {
-				chars[idx] = ch
-			}
-		}(idx, id)
-	}
-	wg.Wait()
+	errs := forEach(len(ids), x.maxConcurrent, func(idx int) error {
+		ch, err := x.Character(ctx, ids[idx], l)
+		if err != nil {
+			return err
+		}
+		chars[idx] = ch
+		return nil
+	})
 	err := util.ToErrors(errs)
 	if err != nil {
 		return nil, err
@@ -275,8 +322,9 @@ func (x *Client) Characters(ctx context.Context, ids []int32) ([]*Character, err
 
 // Corporation obtains information about the Corporation.
 //
-// Hydrates the CEO, Creator, and any associated alliance.
-func (x *Client) Corporation(ctx context.Context, id int32) (*Corporation, error) {
+// Hydrates the CEO, Creator, home station, any associated alliance, and any
+// faction localized into the language.
+func (x *Client) Corporation(ctx context.Context, id int32, l language.Tag) (*Corporation, error) {
 	corp, err := x.hydrateCorporation(ctx, id)
 	if err != nil {
 		return nil, err
@@ -302,10 +350,36 @@ func (x *Client) Corporation(ctx context.Context, id int32) (*Corporation, error
 			return nil, errors.Wrapf(err, "error fetching creator id: %d", cID)
 		}
 	}
+	if corp.Faction != nil {
+		if corp.Faction, err = x.hydrateFaction(ctx, corp.Faction, esiLanguage(l)); err != nil {
+			return nil, err
+		}
+	}
+	if corp.Home != nil {
+		sID := corp.Home.ID
+		corp.Home, err = x.hydrateStation(ctx, sID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error fetching home station id: %d", sID)
+		}
+	}
 	return corp, nil
 }
 
+// hydrateCorporation fetches the corporation, sharing the call with any
+// concurrent hydration of the same corporation.
 func (x *Client) hydrateCorporation(ctx context.Context, id int32) (*Corporation, error) {
+	v, err := x.g.Do(ctx, fmt.Sprintf("corporation:%d", id), func(ctx context.Context) (interface{}, error) {
+		return x.fetchCorporation(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Callers replace the unhydrated fields, so each gets its own copy.
+	cp := *v.(*Corporation)
+	return &cp, nil
+}
+
+func (x *Client) fetchCorporation(ctx context.Context, id int32) (*Corporation, error) {
 	p, err := x.t.corporation(ctx, id)
 	if err != nil {
 		return nil, err
@@ -365,7 +439,21 @@ func (x *Client) hydrateCorporation(ctx context.Context, id int32) (*Corporation
 	return &c, nil
 }
 
+// hydrateAlliance fetches the alliance, sharing the call with any
+// concurrent hydration of the same alliance.
 func (x *Client) hydrateAlliance(ctx context.Context, id int32) (*Alliance, error) {
+	v, err := x.g.Do(ctx, fmt.Sprintf("alliance:%d", id), func(ctx context.Context) (interface{}, error) {
+		return x.fetchAlliance(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Callers replace the unhydrated fields, so each gets its own copy.
+	cp := *v.(*Alliance)
+	return &cp, nil
+}
+
+func (x *Client) fetchAlliance(ctx context.Context, id int32) (*Alliance, error) {
 	p, err := x.t.alliance(ctx, id)
 	if err != nil {
 		return nil, err
@@ -405,7 +493,21 @@ func (x *Client) hydrateAlliance(ctx context.Context, id int32) (*Alliance, erro
 	return &a, nil
 }
 
+// hydrateCharacter fetches the character, sharing the call with any
+// concurrent hydration of the same character.
 func (x *Client) hydrateCharacter(ctx context.Context, id int32) (*Character, error) {
+	v, err := x.g.Do(ctx, fmt.Sprintf("character:%d", id), func(ctx context.Context) (interface{}, error) {
+		return x.fetchCharacter(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Callers replace the unhydrated fields, so each gets its own copy.
+	cp := *v.(*Character)
+	return &cp, nil
+}
+
+func (x *Client) fetchCharacter(ctx context.Context, id int32) (*Character, error) {
 	p, err := x.t.character(ctx, id)
 	if err != nil {
 		return nil, err
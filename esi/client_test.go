@@ -0,0 +1,143 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/esi/esitest"
+	"golang.org/x/text/language"
+)
+
+const (
+	testCEOID      int32 = 90000001
+	testMemberID   int32 = 90000002
+	testCorpID     int32 = 98000001
+	testAllianceID int32 = 99000001
+)
+
+// newTestServer starts an esitest.Server with a corporation in an alliance, its
+// CEO, and one other member.
+func newTestServer(t *testing.T) *esitest.Server {
+	s, err := esitest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+	s.AddAlliance(testAllianceID, "Test Alliance Please Ignore", "TEST", testCorpID, testCorpID)
+	s.AddCorporation(testCorpID, "Dharma Industries", "DHRM", testCEOID, testAllianceID)
+	s.AddCharacter(testCEOID, "Test CEO", testCorpID, testAllianceID)
+	s.AddCharacter(testMemberID, "Test Member", testCorpID, testAllianceID)
+	return s
+}
+
+func TestCharacterIsHydrated(t *testing.T) {
+	s := newTestServer(t)
+	x := esi.New(s.ThinClient(), 4)
+	c, err := x.Character(context.Background(), testMemberID, language.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Hydrated || c.Name != "Test Member" {
+		t.Errorf("got character %q hydrated=%v", c.Name, c.Hydrated)
+	}
+	if c.Corporation == nil || !c.Corporation.Hydrated || c.Corporation.Ticker != "DHRM" {
+		t.Errorf("corporation not hydrated: %+v", c.Corporation)
+	}
+	if c.Alliance == nil || !c.Alliance.Hydrated || c.Alliance.Ticker != "TEST" {
+		t.Errorf("alliance not hydrated: %+v", c.Alliance)
+	}
+	if c.Race == nil || c.Race.Name != "Caldari" {
+		t.Errorf("race not hydrated: %+v", c.Race)
+	}
+	if c.Bloodline == nil || c.Bloodline.Name != "Deteis" {
+		t.Errorf("bloodline not hydrated: %+v", c.Bloodline)
+	}
+	if c.Ancestry == nil || c.Ancestry.Name != "Tube Child" {
+		t.Errorf("ancestry not hydrated: %+v", c.Ancestry)
+	}
+}
+
+func TestCharactersShareHydrations(t *testing.T) {
+	s := newTestServer(t)
+	x := esi.New(s.ThinClient(), 4)
+	cs, err := x.Characters(context.Background(), []int32{testCEOID, testMemberID}, language.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cs) != 2 || cs[0].ID != testCEOID || cs[1].ID != testMemberID {
+		t.Fatalf("got characters %+v", cs)
+	}
+	// The race, bloodline, and ancestry lists are fetched once and kept.
+	if _, err := x.Character(context.Background(), testCEOID, language.English); err != nil {
+		t.Fatal(err)
+	}
+	for _, route := range []string{"/universe/races/", "/universe/bloodlines/", "/universe/ancestries/"} {
+		if n := s.Requests(route); n != 1 {
+			t.Errorf("got %d requests to %s, want 1", n, route)
+		}
+	}
+}
+
+// TestCorporationSetup follows what choosing the corporation to manage needs:
+// finding it by name, and learning its CEO and alliance.
+func TestCorporationSetup(t *testing.T) {
+	s := newTestServer(t)
+	x := esi.New(s.ThinClient(), 4)
+	corps, err := x.SearchCorp(context.Background(), "dharma", language.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corps) != 1 {
+		t.Fatalf("got %d corporations, want 1", len(corps))
+	}
+	corp := corps[0]
+	if corp.ID != testCorpID || corp.Name != "Dharma Industries" {
+		t.Errorf("got corporation %d %q", corp.ID, corp.Name)
+	}
+	if corp.CEO == nil || corp.CEO.ID != testCEOID || corp.CEO.Name != "Test CEO" {
+		t.Errorf("ceo not hydrated: %+v", corp.CEO)
+	}
+	if corp.Alliance == nil || corp.Alliance.ID != testAllianceID || corp.Alliance.Executor == nil || corp.Alliance.Executor.ID != testCorpID {
+		t.Errorf("alliance not hydrated: %+v", corp.Alliance)
+	}
+	if corp.Home == nil || corp.Home.ID != esitest.JitaStationID || corp.Home.Name == "" {
+		t.Errorf("home station not hydrated: %+v", corp.Home)
+	}
+}
+
+func TestNamesLeavesOutInvalidIDs(t *testing.T) {
+	s := newTestServer(t)
+	x := esi.New(s.ThinClient(), 4)
+	const invalid int32 = 12345
+	es, err := x.Names(context.Background(), []int32{testCEOID, invalid, testCorpID, testMemberID, testAllianceID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[int32]string, len(es))
+	for _, e := range es {
+		got[e.ID] = e.Name
+	}
+	if len(got) != 4 || got[testCorpID] != "Dharma Industries" || got[testMemberID] != "Test Member" {
+		t.Errorf("got names %v", got)
+	}
+	if _, ok := got[invalid]; ok {
+		t.Errorf("invalid id %d was named", invalid)
+	}
+}
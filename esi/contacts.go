@@ -0,0 +1,93 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+)
+
+// The types of a contact.
+const (
+	ContactCharacter   = "character"
+	ContactCorporation = "corporation"
+	ContactAlliance    = "alliance"
+	ContactFaction     = "faction"
+)
+
+// Contact is a character, corporation, alliance, or faction that a corporation
+// or alliance has set a standing towards, from -10 to 10.
+type Contact struct {
+	ID       int32
+	Type     string
+	Standing float64
+}
+
+// CorporationContacts obtains the corporation's contacts, as seen by the
+// character.
+func (x *AuthClient) CorporationContacts(ctx context.Context, charID, corpID int32) ([]Contact, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationContactsScope)
+	if err != nil {
+		return nil, err
+	}
+	var cs []Contact
+	for page, pages := int32(1), int32(1); page <= pages; page++ {
+		p, n, err := x.t.corporationContacts(ctx, auth, corpID, page)
+		if err != nil {
+			return nil, err
+		}
+		pages = n
+		for _, c := range p {
+			if c == nil || c.ContactID == nil || c.Standing == nil {
+				continue
+			}
+			cs = append(cs, Contact{
+				ID:       *c.ContactID,
+				Type:     derefString(c.ContactType),
+				Standing: float64(*c.Standing),
+			})
+		}
+	}
+	return cs, nil
+}
+
+// AllianceContacts obtains the alliance's contacts, as seen by a character in
+// the alliance.
+func (x *AuthClient) AllianceContacts(ctx context.Context, charID, allianceID int32) ([]Contact, error) {
+	auth, err := x.auth(ctx, charID, ReadAllianceContactsScope)
+	if err != nil {
+		return nil, err
+	}
+	var cs []Contact
+	for page, pages := int32(1), int32(1); page <= pages; page++ {
+		p, n, err := x.t.allianceContacts(ctx, auth, allianceID, page)
+		if err != nil {
+			return nil, err
+		}
+		pages = n
+		for _, c := range p {
+			if c == nil || c.ContactID == nil || c.Standing == nil {
+				continue
+			}
+			cs = append(cs, Contact{
+				ID:       *c.ContactID,
+				Type:     derefString(c.ContactType),
+				Standing: float64(*c.Standing),
+			})
+		}
+	}
+	return cs, nil
+}
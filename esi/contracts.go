@@ -0,0 +1,122 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"time"
+)
+
+// The types of a contract.
+const (
+	ContractItemExchange = "item_exchange"
+	ContractAuction      = "auction"
+	ContractCourier      = "courier"
+	ContractLoan         = "loan"
+)
+
+// The statuses of a contract.
+const (
+	ContractOutstanding        = "outstanding"
+	ContractInProgress         = "in_progress"
+	ContractFinishedIssuer     = "finished_issuer"
+	ContractFinishedContractor = "finished_contractor"
+	ContractFinished           = "finished"
+	ContractCancelled          = "cancelled"
+	ContractRejected           = "rejected"
+	ContractFailed             = "failed"
+	ContractDeleted            = "deleted"
+	ContractReversed           = "reversed"
+)
+
+type Contract struct {
+	ID                  int32
+	Type                string
+	Status              string
+	Title               string
+	IssuerID            int32
+	IssuerCorporationID int32
+	AssigneeID          int32
+	// AcceptorID is zero until the contract is accepted.
+	AcceptorID      int32
+	ForCorporation  bool
+	Availability    string
+	StartLocationID int64
+	EndLocationID   int64
+	Volume          float64
+	Collateral      float64
+	Reward          float64
+	Price           float64
+	DaysToComplete  int32
+	DateIssued      time.Time
+	DateExpired     time.Time
+	DateAccepted    time.Time
+	DateCompleted   time.Time
+}
+
+// CorporationContracts obtains the contracts the corporation issued, accepted,
+// or was assigned in the last 30 days, as seen by the character.
+func (x *AuthClient) CorporationContracts(ctx context.Context, charID, corpID int32) ([]Contract, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationContractsScope)
+	if err != nil {
+		return nil, err
+	}
+	var cs []Contract
+	for page, pages := int32(1), int32(1); page <= pages; page++ {
+		p, n, err := x.t.corporationContracts(ctx, auth, corpID, page)
+		if err != nil {
+			return nil, err
+		}
+		pages = n
+		for _, c := range p {
+			if c == nil || c.ContractID == nil {
+				continue
+			}
+			ct := Contract{
+				ID:                  *c.ContractID,
+				Type:                derefString(c.Type),
+				Status:              derefString(c.Status),
+				Title:               c.Title,
+				IssuerID:            deref32(c.IssuerID),
+				IssuerCorporationID: deref32(c.IssuerCorporationID),
+				AssigneeID:          deref32(c.AssigneeID),
+				AcceptorID:          deref32(c.AcceptorID),
+				Availability:        derefString(c.Availability),
+				StartLocationID:     c.StartLocationID,
+				EndLocationID:       c.EndLocationID,
+				Volume:              c.Volume,
+				Collateral:          c.Collateral,
+				Reward:              c.Reward,
+				Price:               c.Price,
+				DaysToComplete:      c.DaysToComplete,
+				DateAccepted:        time.Time(c.DateAccepted),
+				DateCompleted:       time.Time(c.DateCompleted),
+			}
+			if c.ForCorporation != nil {
+				ct.ForCorporation = *c.ForCorporation
+			}
+			if c.DateIssued != nil {
+				ct.DateIssued = time.Time(*c.DateIssued)
+			}
+			if c.DateExpired != nil {
+				ct.DateExpired = time.Time(*c.DateExpired)
+			}
+			cs = append(cs, ct)
+		}
+	}
+	return cs, nil
+}
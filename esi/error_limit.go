@@ -0,0 +1,188 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	errorLimitRemainHeader = "X-Esi-Error-Limit-Remain"
+	errorLimitResetHeader  = "X-Esi-Error-Limit-Reset"
+	// statusErrorLimited is the status ESI responds with once the error
+	// budget has been exhausted.
+	statusErrorLimited = 420
+	// defaultErrorLimitWindow is used when ESI does not say when the error
+	// budget resets.
+	defaultErrorLimitWindow = time.Minute
+)
+
+// ErrorLimitedError is returned instead of issuing a request to ESI when the
+// error budget is nearly exhausted, and waiting for it to reset is not
+// permitted.
+type ErrorLimitedError struct {
+	Remain int
+	Reset  time.Time
+}
+
+func (e *ErrorLimitedError) Error() string {
+	return fmt.Sprintf("esi error limit nearly exhausted: %d errors remain until %s", e.Remain, e.Reset.Format(time.RFC3339))
+}
+
+// ErrorLimitStatus is a snapshot of the ESI error budget.
+type ErrorLimitStatus struct {
+	// Known is false until ESI has reported an error budget.
+	Known     bool
+	Remain    int
+	Reset     time.Time
+	Threshold int
+	// Paused is true when requests to ESI are currently being held back.
+	Paused bool
+	// NLimited counts the times ESI responded that the error limit was hit.
+	NLimited int
+}
+
+// ErrorLimiter is a http.RoundTripper that tracks the error budget ESI reports
+// in its X-ESI-Error-Limit-Remain and X-ESI-Error-Limit-Reset headers.
+//
+// It is meant to be shared by everything talking to ESI. When the remaining
+// budget drops to the Threshold, requests are paused until the budget resets.
+// If the reset is further away than MaxWait, or the request's context ends
+// first, the request fails fast with an *ErrorLimitedError instead.
+type ErrorLimiter struct {
+	Transport http.RoundTripper
+	Threshold int
+	MaxWait   time.Duration
+	L         *zerolog.Logger
+
+	mu       sync.Mutex
+	host     string
+	known    bool
+	remain   int
+	reset    time.Time
+	nLimited int
+}
+
+func (e *ErrorLimiter) RoundTrip(r *http.Request) (*http.Response, error) {
+	if err := e.wait(r); err != nil {
+		return nil, err
+	}
+	t := e.Transport
+	if t == nil {
+		t = http.DefaultTransport
+	}
+	resp, err := t.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	e.update(r, resp)
+	return resp, nil
+}
+
+// Status returns the current state of the error budget.
+func (e *ErrorLimiter) Status() ErrorLimitStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return ErrorLimitStatus{
+		Known:     e.known,
+		Remain:    e.remain,
+		Reset:     e.reset,
+		Threshold: e.Threshold,
+		Paused:    e.isPausedLocked(time.Now()),
+		NLimited:  e.nLimited,
+	}
+}
+
+func (e *ErrorLimiter) isPausedLocked(now time.Time) bool {
+	return e.known && e.remain <= e.Threshold && now.Before(e.reset)
+}
+
+// wait blocks until the request may be sent to ESI.
+//
+// Only requests to the host that reported an error budget are held back, so
+// that other services sharing the transport, such as SSO, are unaffected.
+func (e *ErrorLimiter) wait(r *http.Request) error {
+	e.mu.Lock()
+	now := time.Now()
+	if r.URL.Host != e.host || !e.isPausedLocked(now) {
+		e.mu.Unlock()
+		return nil
+	}
+	remain, reset := e.remain, e.reset
+	e.mu.Unlock()
+
+	d := reset.Sub(now)
+	if d > e.MaxWait {
+		return &ErrorLimitedError{Remain: remain, Reset: reset}
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-r.Context().Done():
+		return &ErrorLimitedError{Remain: remain, Reset: reset}
+	}
+}
+
+// update records the error budget reported in the response.
+func (e *ErrorLimiter) update(r *http.Request, resp *http.Response) {
+	sremain := resp.Header.Get(errorLimitRemainHeader)
+	sreset := resp.Header.Get(errorLimitResetHeader)
+	limited := resp.StatusCode == statusErrorLimited
+	if !limited && (len(sremain) == 0 || len(sreset) == 0) {
+		return
+	}
+	now := time.Now()
+	remain, errRemain := strconv.Atoi(sremain)
+	resetSec, errReset := strconv.Atoi(sreset)
+	if limited {
+		remain, errRemain = 0, nil
+	}
+	reset := now.Add(time.Duration(resetSec) * time.Second)
+	if errReset != nil {
+		reset = now.Add(defaultErrorLimitWindow)
+	}
+	if errRemain != nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	wasPaused := e.isPausedLocked(now)
+	e.host = r.URL.Host
+	e.known = true
+	e.remain = remain
+	e.reset = reset
+	if limited {
+		e.nLimited++
+	}
+	if e.L == nil {
+		return
+	}
+	if limited {
+		e.L.Error().Int("remain", remain).Time("reset", reset).Str("url", r.URL.String()).Msg("esi error limit reached")
+	} else if !wasPaused && e.isPausedLocked(now) {
+		e.L.Warn().Int("remain", remain).Time("reset", reset).Msg("esi error limit nearly exhausted, pausing esi requests")
+	}
+}
@@ -0,0 +1,1353 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/esi/client/alliance"
+	"github.com/cjslep/dharma/esi/client/assets"
+	"github.com/cjslep/dharma/esi/client/calendar"
+	"github.com/cjslep/dharma/esi/client/character"
+	"github.com/cjslep/dharma/esi/client/contacts"
+	"github.com/cjslep/dharma/esi/client/contracts"
+	"github.com/cjslep/dharma/esi/client/corporation"
+	"github.com/cjslep/dharma/esi/client/faction_warfare"
+	"github.com/cjslep/dharma/esi/client/industry"
+	"github.com/cjslep/dharma/esi/client/killmails"
+	"github.com/cjslep/dharma/esi/client/mail"
+	"github.com/cjslep/dharma/esi/client/market"
+	"github.com/cjslep/dharma/esi/client/search"
+	"github.com/cjslep/dharma/esi/client/universe"
+	"github.com/cjslep/dharma/esi/client/wallet"
+	"github.com/go-openapi/strfmt"
+)
+
+const (
+	statusErrorLimited = 420
+	// maxTransactionsPerPage is smaller than ESI's so that paging with
+	// from_id is exercised without adding thousands of transactions.
+	maxTransactionsPerPage = 50
+	// maxCalendarEventsPerPage matches ESI.
+	maxCalendarEventsPerPage = 50
+	// maxMailPerPage matches ESI.
+	maxMailPerPage = 50
+	// maxMarketOrdersPerPage is smaller than ESI's so that paging through
+	// an order book is exercised without adding thousands of orders.
+	maxMarketOrdersPerPage = 100
+	// maxAssetsPerPage is smaller than ESI's for the same reason.
+	maxAssetsPerPage = 100
+	// maxIndustryJobsPerPage is smaller than ESI's for the same reason.
+	maxIndustryJobsPerPage = 100
+	// The labels every character has, with their IDs in EVE.
+	inboxMailLabel = 1
+	sentMailLabel  = 2
+)
+
+// serveESI routes the ESI requests made by the esi package.
+func (s *Server) serveESI(w http.ResponseWriter, r *http.Request) {
+	route := strings.TrimPrefix(r.URL.Path, "/latest")
+	s.mu.Lock()
+	s.requests[route]++
+	remain, _ := s.errorLimitLocked(time.Now())
+	s.mu.Unlock()
+	if remain <= 0 {
+		s.writeError(w, r, statusErrorLimited, "This software has exceeded the error limit for ESI.")
+		return
+	}
+
+	seg := strings.Split(strings.Trim(route, "/"), "/")
+	get := r.Method == http.MethodGet
+	post := r.Method == http.MethodPost
+	put := r.Method == http.MethodPut
+	switch {
+	case get && len(seg) == 1 && seg[0] == "search":
+		s.serveSearch(w, r)
+	case get && len(seg) == 2 && seg[0] == "characters":
+		s.serveByID(w, r, seg[1], s.character)
+	case get && len(seg) == 3 && seg[0] == "characters" && seg[2] == "portrait":
+		s.serveByID(w, r, seg[1], s.characterPortrait)
+	case get && len(seg) == 2 && seg[0] == "corporations":
+		s.serveByID(w, r, seg[1], s.corporation)
+	case get && len(seg) == 3 && seg[0] == "corporations" && seg[2] == "icons":
+		s.serveByID(w, r, seg[1], s.corporationIcons)
+	case get && len(seg) == 3 && seg[0] == "corporations" && seg[2] == "members":
+		s.serveCorporationMembers(w, r, seg[1])
+	case get && len(seg) == 3 && seg[0] == "corporations" && seg[2] == "wallets":
+		s.serveCorporationWallets(w, r, seg[1])
+	case get && len(seg) == 4 && seg[0] == "corporations" && seg[2] == "killmails" && seg[3] == "recent":
+		s.serveCorporationKillmails(w, r, seg[1])
+	case get && len(seg) == 3 && seg[0] == "killmails":
+		s.serveKillmail(w, r, seg[1], seg[2])
+	case get && len(seg) == 5 && seg[0] == "corporations" && seg[2] == "wallets" && seg[4] == "journal":
+		s.serveCorporationJournal(w, r, seg[1], seg[3])
+	case get && len(seg) == 5 && seg[0] == "corporations" && seg[2] == "wallets" && seg[4] == "transactions":
+		s.serveCorporationTransactions(w, r, seg[1], seg[3])
+	case get && len(seg) == 3 && seg[0] == "corporations" && seg[2] == "assets":
+		s.serveCorporationAssets(w, r, seg[1])
+	case post && len(seg) == 4 && seg[0] == "corporations" && seg[2] == "assets" && seg[3] == "names":
+		s.serveCorporationAssetNames(w, r, seg[1])
+	case get && len(seg) == 4 && seg[0] == "corporations" && seg[2] == "industry" && seg[3] == "jobs":
+		s.serveCorporationIndustryJobs(w, r, seg[1])
+	case get && len(seg) == 4 && seg[0] == "corporation" && seg[2] == "mining" && seg[3] == "observers":
+		s.serveMiningObservers(w, r, seg[1])
+	case get && len(seg) == 5 && seg[0] == "corporation" && seg[2] == "mining" && seg[3] == "observers":
+		s.serveMiningLedger(w, r, seg[1], seg[4])
+	case get && len(seg) == 3 && seg[0] == "corporations" && seg[2] == "contracts":
+		s.serveCorporationContracts(w, r, seg[1])
+	case get && len(seg) == 3 && seg[0] == "corporations" && seg[2] == "contacts":
+		s.serveCorporationContacts(w, r, seg[1])
+	case get && len(seg) == 3 && seg[0] == "alliances" && seg[2] == "contacts":
+		s.serveAllianceContacts(w, r, seg[1])
+	case get && len(seg) == 4 && seg[0] == "corporations" && seg[2] == "fw" && seg[3] == "stats":
+		s.serveCorporationFactionWarfareStats(w, r, seg[1])
+	case get && len(seg) == 3 && seg[0] == "characters" && seg[2] == "calendar":
+		s.serveCalendar(w, r, seg[1])
+	case get && len(seg) == 4 && seg[0] == "characters" && seg[2] == "calendar":
+		s.serveCalendarEvent(w, r, seg[1], seg[3])
+	case put && len(seg) == 4 && seg[0] == "characters" && seg[2] == "calendar":
+		s.serveRespondCalendarEvent(w, r, seg[1], seg[3])
+	case get && len(seg) == 3 && seg[0] == "characters" && seg[2] == "roles":
+		s.serveCharacterRoles(w, r, seg[1])
+	case get && len(seg) == 3 && seg[0] == "characters" && seg[2] == "mail":
+		s.serveMailHeaders(w, r, seg[1])
+	case post && len(seg) == 3 && seg[0] == "characters" && seg[2] == "mail":
+		s.serveSendMail(w, r, seg[1])
+	case get && len(seg) == 4 && seg[0] == "characters" && seg[2] == "mail" && seg[3] == "labels":
+		s.serveMailLabels(w, r, seg[1])
+	case get && len(seg) == 4 && seg[0] == "characters" && seg[2] == "mail" && seg[3] == "lists":
+		s.serveMailingLists(w, r, seg[1])
+	case get && len(seg) == 4 && seg[0] == "characters" && seg[2] == "mail":
+		s.serveMail(w, r, seg[1], seg[3])
+	case put && len(seg) == 4 && seg[0] == "characters" && seg[2] == "mail":
+		s.serveUpdateMail(w, r, seg[1], seg[3])
+	case get && len(seg) == 2 && seg[0] == "alliances":
+		s.serveByID(w, r, seg[1], s.alliance)
+	case get && len(seg) == 3 && seg[0] == "alliances" && seg[2] == "icons":
+		s.serveByID(w, r, seg[1], s.allianceIcons)
+	case post && len(seg) == 2 && seg[0] == "characters" && seg[1] == "affiliation":
+		s.serveCharacterAffiliation(w, r)
+	case post && len(seg) == 2 && seg[0] == "universe" && seg[1] == "names":
+		s.serveUniverseNames(w, r)
+	case post && len(seg) == 2 && seg[0] == "universe" && seg[1] == "ids":
+		s.serveUniverseIDs(w, r)
+	case get && len(seg) == 2 && seg[0] == "universe" && seg[1] == "factions":
+		s.serveLocked(w, r, func() interface{} { return s.factions })
+	case get && len(seg) == 2 && seg[0] == "universe" && seg[1] == "races":
+		s.serveLocked(w, r, func() interface{} { return s.races })
+	case get && len(seg) == 2 && seg[0] == "universe" && seg[1] == "bloodlines":
+		s.serveLocked(w, r, func() interface{} { return s.bloodlines })
+	case get && len(seg) == 2 && seg[0] == "universe" && seg[1] == "ancestries":
+		s.serveLocked(w, r, func() interface{} { return s.ancestries })
+	case get && len(seg) == 3 && seg[0] == "universe" && seg[1] == "stations":
+		s.serveByID(w, r, seg[2], s.station)
+	case get && len(seg) == 2 && seg[0] == "markets" && seg[1] == "prices":
+		s.serveMarketPrices(w, r)
+	case get && len(seg) == 3 && seg[0] == "markets" && seg[2] == "orders":
+		s.serveRegionOrders(w, r, seg[1])
+	default:
+		s.writeError(w, r, http.StatusNotFound, "Requested page does not exist!")
+	}
+}
+
+// serveLocked writes the value obtained while holding the lock.
+func (s *Server) serveLocked(w http.ResponseWriter, r *http.Request, fn func() interface{}) {
+	s.mu.Lock()
+	b, err := json.Marshal(fn())
+	s.mu.Unlock()
+	if err != nil {
+		s.writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.writeJSON(w, r, http.StatusOK, json.RawMessage(b))
+}
+
+// serveByID writes the entity with the ID in the path, or a 404 if lookup
+// does not find it.
+func (s *Server) serveByID(w http.ResponseWriter, r *http.Request, sid string, lookup func(id int32) (interface{}, bool)) {
+	id, err := strconv.ParseInt(sid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", sid))
+		return
+	}
+	s.mu.Lock()
+	v, ok := lookup(int32(id))
+	var b []byte
+	if ok {
+		b, err = json.Marshal(v)
+	}
+	s.mu.Unlock()
+	if !ok {
+		s.writeError(w, r, http.StatusNotFound, fmt.Sprintf("not found: %d", id))
+		return
+	} else if err != nil {
+		s.writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.writeJSON(w, r, http.StatusOK, json.RawMessage(b))
+}
+
+func (s *Server) character(id int32) (interface{}, bool) {
+	v, ok := s.characters[id]
+	return v, ok
+}
+
+func (s *Server) corporation(id int32) (interface{}, bool) {
+	v, ok := s.corporations[id]
+	return v, ok
+}
+
+func (s *Server) alliance(id int32) (interface{}, bool) {
+	v, ok := s.alliances[id]
+	return v, ok
+}
+
+func (s *Server) station(id int32) (interface{}, bool) {
+	v, ok := s.stations[id]
+	return v, ok
+}
+
+func (s *Server) characterPortrait(id int32) (interface{}, bool) {
+	if _, ok := s.characters[id]; !ok {
+		return nil, false
+	}
+	return &character.GetCharactersCharacterIDPortraitOKBody{
+		Px64x64:   s.imageURL("characters", id, 64),
+		Px128x128: s.imageURL("characters", id, 128),
+		Px256x256: s.imageURL("characters", id, 256),
+		Px512x512: s.imageURL("characters", id, 512),
+	}, true
+}
+
+func (s *Server) corporationIcons(id int32) (interface{}, bool) {
+	if _, ok := s.corporations[id]; !ok {
+		return nil, false
+	}
+	return &corporation.GetCorporationsCorporationIDIconsOKBody{
+		Px64x64:   s.imageURL("corporations", id, 64),
+		Px128x128: s.imageURL("corporations", id, 128),
+		Px256x256: s.imageURL("corporations", id, 256),
+	}, true
+}
+
+func (s *Server) allianceIcons(id int32) (interface{}, bool) {
+	if _, ok := s.alliances[id]; !ok {
+		return nil, false
+	}
+	return &alliance.GetAlliancesAllianceIDIconsOKBody{
+		Px64x64:   s.imageURL("alliances", id, 64),
+		Px128x128: s.imageURL("alliances", id, 128),
+	}, true
+}
+
+// serveSearch matches corporation names, which is the only category the esi
+// package searches.
+func (s *Server) serveSearch(w http.ResponseWriter, r *http.Request) {
+	q := strings.ToLower(r.URL.Query().Get("search"))
+	strict := r.URL.Query().Get("strict") == "true"
+	cats := strings.Split(r.URL.Query().Get("categories"), ",")
+	resp := &search.GetSearchOKBody{}
+	for _, c := range cats {
+		if c != "corporation" {
+			continue
+		}
+		s.mu.Lock()
+		for id, corp := range s.corporations {
+			name := strings.ToLower(*corp.Name)
+			if (strict && name == q) || (!strict && strings.Contains(name, q)) {
+				resp.Corporation = append(resp.Corporation, id)
+			}
+		}
+		s.mu.Unlock()
+	}
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveCorporationMembers serves the IDs of the characters belonging to the
+// corporation.
+func (s *Server) serveCorporationMembers(w http.ResponseWriter, r *http.Request, sid string) {
+	if _, ok := s.authorize(w, r, esi.ReadCorporationMembershipScope); !ok {
+		return
+	}
+	s.serveByID(w, r, sid, func(id int32) (interface{}, bool) {
+		if _, ok := s.corporations[id]; !ok {
+			return nil, false
+		}
+		ids := []int32{}
+		for charID, c := range s.characters {
+			if c.CorporationID != nil && *c.CorporationID == id {
+				ids = append(ids, charID)
+			}
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		return ids, true
+	})
+}
+
+func (s *Server) serveCorporationWallets(w http.ResponseWriter, r *http.Request, sid string) {
+	if _, ok := s.authorize(w, r, esi.ReadCorporationWalletsScope); !ok {
+		return
+	}
+	s.serveByID(w, r, sid, func(id int32) (interface{}, bool) {
+		v, ok := s.wallets[id]
+		return v, ok
+	})
+}
+
+// walletKey parses the corporation and wallet division of a request.
+func (s *Server) walletKey(w http.ResponseWriter, r *http.Request, sid, sdiv string) (walletKey, bool) {
+	id, err := strconv.ParseInt(sid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", sid))
+		return walletKey{}, false
+	}
+	div, err := strconv.ParseInt(sdiv, 10, 32)
+	if err != nil || div < 1 || div > esi.NWalletDivisions {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid division: %s", sdiv))
+		return walletKey{}, false
+	}
+	return walletKey{corpID: int32(id), division: int32(div)}, true
+}
+
+// serveCorporationJournal serves the whole journal as a single page, most
+// recent first.
+func (s *Server) serveCorporationJournal(w http.ResponseWriter, r *http.Request, sid, sdiv string) {
+	if _, ok := s.authorize(w, r, esi.ReadCorporationWalletsScope); !ok {
+		return
+	}
+	k, ok := s.walletKey(w, r, sid, sdiv)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	js := s.journals[k]
+	resp := make([]*wallet.GetCorporationsCorporationIDWalletsDivisionJournalOKBodyItems0, 0, len(js))
+	for i := len(js) - 1; i >= 0; i-- {
+		j := js[i]
+		resp = append(resp, &wallet.GetCorporationsCorporationIDWalletsDivisionJournalOKBodyItems0{
+			Amount:        j.Amount,
+			Balance:       j.Balance,
+			ContextID:     j.ContextID,
+			ContextIDType: j.ContextIDType,
+			Date:          dateTime(j.Date),
+			Description:   str(j.Description),
+			FirstPartyID:  j.FirstPartyID,
+			ID:            i64(j.ID),
+			Reason:        j.Reason,
+			RefType:       str(j.RefType),
+			SecondPartyID: j.SecondPartyID,
+			Tax:           j.Tax,
+			TaxReceiverID: j.TaxReceiverID,
+		})
+	}
+	s.mu.Unlock()
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveCorporationTransactions serves up to maxTransactionsPerPage
+// transactions, most recent first, that are before the from_id.
+func (s *Server) serveCorporationTransactions(w http.ResponseWriter, r *http.Request, sid, sdiv string) {
+	if _, ok := s.authorize(w, r, esi.ReadCorporationWalletsScope); !ok {
+		return
+	}
+	k, ok := s.walletKey(w, r, sid, sdiv)
+	if !ok {
+		return
+	}
+	var fromID int64
+	if f := r.URL.Query().Get("from_id"); len(f) > 0 {
+		var err error
+		fromID, err = strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid from_id: %s", f))
+			return
+		}
+	}
+	s.mu.Lock()
+	ts := s.transactions[k]
+	resp := make([]*wallet.GetCorporationsCorporationIDWalletsDivisionTransactionsOKBodyItems0, 0, maxTransactionsPerPage)
+	for i := len(ts) - 1; i >= 0 && len(resp) < maxTransactionsPerPage; i-- {
+		t := ts[i]
+		if fromID != 0 && t.ID >= fromID {
+			continue
+		}
+		resp = append(resp, &wallet.GetCorporationsCorporationIDWalletsDivisionTransactionsOKBodyItems0{
+			ClientID:      i32(t.ClientID),
+			Date:          dateTime(t.Date),
+			IsBuy:         boolean(t.IsBuy),
+			JournalRefID:  i64(t.JournalRefID),
+			LocationID:    i64(t.LocationID),
+			Quantity:      i32(t.Quantity),
+			TransactionID: i64(t.ID),
+			TypeID:        i32(t.TypeID),
+			UnitPrice:     f64(t.UnitPrice),
+		})
+	}
+	s.mu.Unlock()
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// ownCharacter ensures the character in the path is the one the token was
+// granted by, as ESI only serves a character's own calendar and mail.
+func (s *Server) ownCharacter(w http.ResponseWriter, r *http.Request, sid string, scope esi.Scope) (int32, bool) {
+	g, ok := s.authorize(w, r, scope)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(sid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", sid))
+		return 0, false
+	} else if int32(id) != g.charID {
+		s.writeError(w, r, http.StatusForbidden, "token not valid for character")
+		return 0, false
+	}
+	return g.charID, true
+}
+
+func (s *Server) serveCalendar(w http.ResponseWriter, r *http.Request, sid string) {
+	charID, ok := s.ownCharacter(w, r, sid, esi.ReadCalendarEventsScope)
+	if !ok {
+		return
+	}
+	var fromEvent int64
+	if f := r.URL.Query().Get("from_event"); len(f) > 0 {
+		var err error
+		fromEvent, err = strconv.ParseInt(f, 10, 32)
+		if err != nil {
+			s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid from_event: %s", f))
+			return
+		}
+	}
+	s.mu.Lock()
+	es := s.calendars[charID]
+	resp := make([]*calendar.GetCharactersCharacterIDCalendarOKBodyItems0, 0, maxCalendarEventsPerPage)
+	found := fromEvent == 0
+	for _, e := range es {
+		if len(resp) == maxCalendarEventsPerPage {
+			break
+		} else if !found {
+			found = int64(e.ID) == fromEvent
+			continue
+		}
+		resp = append(resp, &calendar.GetCharactersCharacterIDCalendarOKBodyItems0{
+			EventDate:     strfmt.DateTime(e.Date),
+			EventID:       e.ID,
+			EventResponse: e.Response,
+			Importance:    e.Importance,
+			Title:         e.Title,
+		})
+	}
+	s.mu.Unlock()
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+func (s *Server) serveCalendarEvent(w http.ResponseWriter, r *http.Request, sid, seid string) {
+	charID, ok := s.ownCharacter(w, r, sid, esi.ReadCalendarEventsScope)
+	if !ok {
+		return
+	}
+	s.serveByID(w, r, seid, func(id int32) (interface{}, bool) {
+		for _, e := range s.calendars[charID] {
+			if e.ID != id {
+				continue
+			}
+			return &calendar.GetCharactersCharacterIDCalendarEventIDOKBody{
+				Date:       dateTime(e.Date),
+				Duration:   i32(int32(e.Duration / time.Minute)),
+				EventID:    i32(e.ID),
+				Importance: i32(e.Importance),
+				OwnerID:    i32(e.OwnerID),
+				OwnerName:  str(e.OwnerName),
+				OwnerType:  str(e.OwnerType),
+				Response:   str(e.Response),
+				Text:       str(e.Text),
+				Title:      str(e.Title),
+			}, true
+		}
+		return nil, false
+	})
+}
+
+func (s *Server) serveRespondCalendarEvent(w http.ResponseWriter, r *http.Request, sid, seid string) {
+	charID, ok := s.ownCharacter(w, r, sid, esi.RespondCalendarEventsScope)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseInt(seid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", seid))
+		return
+	}
+	var body calendar.PutCharactersCharacterIDCalendarEventIDBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Response == nil || !esi.IsCalendarResponse(*body.Response) {
+		s.writeError(w, r, http.StatusBadRequest, "invalid response")
+		return
+	}
+	s.mu.Lock()
+	found := false
+	for i, e := range s.calendars[charID] {
+		if e.ID == int32(id) {
+			s.calendars[charID][i].Response = *body.Response
+			found = true
+		}
+	}
+	s.mu.Unlock()
+	if !found {
+		s.writeError(w, r, http.StatusNotFound, fmt.Sprintf("not found: %d", id))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) serveCharacterRoles(w http.ResponseWriter, r *http.Request, sid string) {
+	charID, ok := s.ownCharacter(w, r, sid, esi.ReadCorporationRolesScope)
+	if !ok {
+		return
+	}
+	s.serveLocked(w, r, func() interface{} {
+		return &character.GetCharactersCharacterIDRolesOKBody{
+			Roles: s.roles[charID],
+		}
+	})
+}
+
+func mailRecipients(rs []esi.MailRecipient) []*mail.GetCharactersCharacterIDMailOKBodyItems0RecipientsItems0 {
+	v := make([]*mail.GetCharactersCharacterIDMailOKBodyItems0RecipientsItems0, len(rs))
+	for i, r := range rs {
+		v[i] = &mail.GetCharactersCharacterIDMailOKBodyItems0RecipientsItems0{
+			RecipientID:   i32(r.ID),
+			RecipientType: str(r.Type),
+		}
+	}
+	return v
+}
+
+// hasAnyLabel determines whether the mail has one of the labels, or whether
+// no labels were asked for.
+func hasAnyLabel(m esi.Mail, labels []int32) bool {
+	if len(labels) == 0 {
+		return true
+	}
+	for _, want := range labels {
+		for _, l := range m.Labels {
+			if l == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Server) serveMailHeaders(w http.ResponseWriter, r *http.Request, sid string) {
+	charID, ok := s.ownCharacter(w, r, sid, esi.ReadMailScope)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	var lastMailID int64
+	if l := q.Get("last_mail_id"); len(l) > 0 {
+		var err error
+		lastMailID, err = strconv.ParseInt(l, 10, 32)
+		if err != nil {
+			s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid last_mail_id: %s", l))
+			return
+		}
+	}
+	var labels []int32
+	for _, v := range q["labels"] {
+		for _, sl := range strings.Split(v, ",") {
+			l, err := strconv.ParseInt(sl, 10, 32)
+			if err != nil {
+				s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid labels: %s", v))
+				return
+			}
+			labels = append(labels, int32(l))
+		}
+	}
+	s.mu.Lock()
+	ms := s.mailboxes[charID]
+	resp := make([]*mail.GetCharactersCharacterIDMailOKBodyItems0, 0, maxMailPerPage)
+	for i := len(ms) - 1; i >= 0 && len(resp) < maxMailPerPage; i-- {
+		m := ms[i]
+		if (lastMailID != 0 && int64(m.ID) >= lastMailID) || !hasAnyLabel(m, labels) {
+			continue
+		}
+		resp = append(resp, &mail.GetCharactersCharacterIDMailOKBodyItems0{
+			From:       m.From,
+			IsRead:     m.IsRead,
+			Labels:     m.Labels,
+			MailID:     m.ID,
+			Recipients: mailRecipients(m.Recipients),
+			Subject:    m.Subject,
+			Timestamp:  strfmt.DateTime(m.Timestamp),
+		})
+	}
+	s.mu.Unlock()
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+func (s *Server) serveMail(w http.ResponseWriter, r *http.Request, sid, smid string) {
+	charID, ok := s.ownCharacter(w, r, sid, esi.ReadMailScope)
+	if !ok {
+		return
+	}
+	s.serveByID(w, r, smid, func(id int32) (interface{}, bool) {
+		for _, m := range s.mailboxes[charID] {
+			if m.ID != id {
+				continue
+			}
+			v := &mail.GetCharactersCharacterIDMailMailIDOKBody{
+				Body:      m.Body,
+				From:      m.From,
+				Read:      m.IsRead,
+				Subject:   m.Subject,
+				Timestamp: strfmt.DateTime(m.Timestamp),
+			}
+			for _, l := range m.Labels {
+				v.Labels = append(v.Labels, i32(l))
+			}
+			for _, r := range m.Recipients {
+				v.Recipients = append(v.Recipients, &mail.GetCharactersCharacterIDMailMailIDOKBodyRecipientsItems0{
+					RecipientID:   i32(r.ID),
+					RecipientType: str(r.Type),
+				})
+			}
+			return v, true
+		}
+		return nil, false
+	})
+}
+
+func (s *Server) serveUpdateMail(w http.ResponseWriter, r *http.Request, sid, smid string) {
+	charID, ok := s.ownCharacter(w, r, sid, esi.OrganizeMailScope)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseInt(smid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", smid))
+		return
+	}
+	var body mail.PutCharactersCharacterIDMailMailIDBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.writeError(w, r, http.StatusBadRequest, "invalid contents")
+		return
+	}
+	s.mu.Lock()
+	found := false
+	for i, m := range s.mailboxes[charID] {
+		if m.ID != int32(id) {
+			continue
+		}
+		found = true
+		s.mailboxes[charID][i].IsRead = body.Read
+		if body.Labels != nil {
+			labels := make([]int32, 0, len(body.Labels))
+			for _, l := range body.Labels {
+				if l != nil {
+					labels = append(labels, *l)
+				}
+			}
+			s.mailboxes[charID][i].Labels = labels
+		}
+	}
+	s.mu.Unlock()
+	if !found {
+		s.writeError(w, r, http.StatusNotFound, fmt.Sprintf("not found: %d", id))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveSendMail delivers the mail to the sender's sent mail, and to the inbox
+// of each recipient character.
+func (s *Server) serveSendMail(w http.ResponseWriter, r *http.Request, sid string) {
+	charID, ok := s.ownCharacter(w, r, sid, esi.SendMailScope)
+	if !ok {
+		return
+	}
+	var body mail.PostCharactersCharacterIDMailBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Subject == nil || body.Body == nil || len(body.Recipients) == 0 {
+		s.writeError(w, r, http.StatusBadRequest, "invalid mail")
+		return
+	}
+	s.mu.Lock()
+	s.nextMailID++
+	m := esi.Mail{
+		MailHeader: esi.MailHeader{
+			ID:        s.nextMailID,
+			From:      charID,
+			Subject:   *body.Subject,
+			Timestamp: time.Now().UTC(),
+			IsRead:    true,
+			Labels:    []int32{sentMailLabel},
+		},
+		Body: *body.Body,
+	}
+	for _, rc := range body.Recipients {
+		if rc == nil || rc.RecipientID == nil || rc.RecipientType == nil {
+			continue
+		}
+		m.Recipients = append(m.Recipients, esi.MailRecipient{ID: *rc.RecipientID, Type: *rc.RecipientType})
+	}
+	s.mailboxes[charID] = append(s.mailboxes[charID], m)
+	m.IsRead = false
+	m.Labels = []int32{inboxMailLabel}
+	for _, rc := range m.Recipients {
+		if rc.Type == esi.MailRecipientCharacter && rc.ID != charID {
+			s.mailboxes[rc.ID] = append(s.mailboxes[rc.ID], m)
+		}
+	}
+	id := m.ID
+	s.mu.Unlock()
+	s.writeJSON(w, r, http.StatusCreated, id)
+}
+
+func (s *Server) serveMailLabels(w http.ResponseWriter, r *http.Request, sid string) {
+	charID, ok := s.ownCharacter(w, r, sid, esi.ReadMailScope)
+	if !ok {
+		return
+	}
+	s.serveLocked(w, r, func() interface{} {
+		unread := make(map[int32]int32)
+		var total int32
+		for _, m := range s.mailboxes[charID] {
+			if m.IsRead {
+				continue
+			}
+			total++
+			for _, l := range m.Labels {
+				unread[l]++
+			}
+		}
+		resp := &mail.GetCharactersCharacterIDMailLabelsOKBody{
+			TotalUnreadCount: i32(total),
+		}
+		labels := append([]esi.MailLabel{
+			{ID: inboxMailLabel, Name: "Inbox"},
+			{ID: sentMailLabel, Name: "Sent"},
+		}, s.mailLabels[charID]...)
+		for _, l := range labels {
+			resp.Labels = append(resp.Labels, &mail.GetCharactersCharacterIDMailLabelsOKBodyLabelsItems0{
+				Color:       str(l.Color),
+				LabelID:     i32(l.ID),
+				Name:        l.Name,
+				UnreadCount: i32(unread[l.ID]),
+			})
+		}
+		return resp
+	})
+}
+
+func (s *Server) serveMailingLists(w http.ResponseWriter, r *http.Request, sid string) {
+	charID, ok := s.ownCharacter(w, r, sid, esi.ReadMailScope)
+	if !ok {
+		return
+	}
+	s.serveLocked(w, r, func() interface{} {
+		resp := make([]*mail.GetCharactersCharacterIDMailListsOKBodyItems0, 0, len(s.mailingLists[charID]))
+		for _, l := range s.mailingLists[charID] {
+			resp = append(resp, &mail.GetCharactersCharacterIDMailListsOKBodyItems0{
+				MailingListID: i32(l.ID),
+				Name:          str(l.Name),
+			})
+		}
+		return resp
+	})
+}
+
+// serveCorporationFactionWarfareStats serves empty statistics for
+// corporations that are not enlisted, like ESI.
+func (s *Server) serveCorporationFactionWarfareStats(w http.ResponseWriter, r *http.Request, sid string) {
+	if _, ok := s.authorize(w, r, esi.ReadFactionWarfareStatsScope); !ok {
+		return
+	}
+	s.serveByID(w, r, sid, func(id int32) (interface{}, bool) {
+		if _, ok := s.corporations[id]; !ok {
+			return nil, false
+		}
+		if v, ok := s.fwStats[id]; ok {
+			return v, true
+		}
+		return &faction_warfare.GetCorporationsCorporationIDFwStatsOKBody{
+			Kills: &faction_warfare.GetCorporationsCorporationIDFwStatsOKBodyKills{
+				LastWeek:  i32(0),
+				Total:     i32(0),
+				Yesterday: i32(0),
+			},
+			VictoryPoints: &faction_warfare.GetCorporationsCorporationIDFwStatsOKBodyVictoryPoints{
+				LastWeek:  i32(0),
+				Total:     i32(0),
+				Yesterday: i32(0),
+			},
+		}, true
+	})
+}
+
+func (s *Server) serveCorporationKillmails(w http.ResponseWriter, r *http.Request, sid string) {
+	if _, ok := s.authorize(w, r, esi.ReadCorporationKillmailsScope); !ok {
+		return
+	}
+	s.serveByID(w, r, sid, func(id int32) (interface{}, bool) {
+		if _, ok := s.corporations[id]; !ok {
+			return nil, false
+		}
+		refs := make([]*killmails.GetCorporationsCorporationIDKillmailsRecentOKBodyItems0, 0, len(s.corpKms[id]))
+		for _, kmID := range s.corpKms[id] {
+			refs = append(refs, &killmails.GetCorporationsCorporationIDKillmailsRecentOKBodyItems0{
+				KillmailHash: str(s.kmHashes[kmID]),
+				KillmailID:   i32(kmID),
+			})
+		}
+		return refs, true
+	})
+}
+
+// serveKillmail requires the killmail's hash to match, like ESI.
+func (s *Server) serveKillmail(w http.ResponseWriter, r *http.Request, sid, hash string) {
+	s.serveByID(w, r, sid, func(id int32) (interface{}, bool) {
+		if s.kmHashes[id] != hash {
+			return nil, false
+		}
+		v, ok := s.killmails[id]
+		return v, ok
+	})
+}
+
+// serveCharacterAffiliation serves the corporation and alliance of each
+// character, and like ESI fails if any ID is not a known character.
+func (s *Server) serveCharacterAffiliation(w http.ResponseWriter, r *http.Request) {
+	var ids []int32
+	if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+		s.writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := make([]*character.PostCharactersAffiliationOKBodyItems0, 0, len(ids))
+	s.mu.Lock()
+	for _, id := range ids {
+		c, ok := s.characters[id]
+		if !ok {
+			break
+		}
+		resp = append(resp, &character.PostCharactersAffiliationOKBodyItems0{
+			AllianceID:    c.AllianceID,
+			CharacterID:   i32(id),
+			CorporationID: c.CorporationID,
+			FactionID:     c.FactionID,
+		})
+	}
+	s.mu.Unlock()
+	if len(resp) < len(ids) {
+		s.writeError(w, r, http.StatusNotFound, "Invalid character ID")
+		return
+	}
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveUniverseNames resolves every kind of entity the Server knows of, and
+// like ESI fails if any ID is unknown.
+func (s *Server) serveUniverseNames(w http.ResponseWriter, r *http.Request) {
+	var ids []int32
+	if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+		s.writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.mu.Lock()
+	names := s.namesLocked()
+	s.mu.Unlock()
+	resp := make([]*universe.PostUniverseNamesOKBodyItems0, 0, len(ids))
+	for _, id := range ids {
+		n, ok := names[id]
+		if !ok {
+			s.writeError(w, r, http.StatusNotFound, "Ensure all IDs are valid before resolving.")
+			return
+		}
+		resp = append(resp, n)
+	}
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveUniverseIDs resolves exact names, ignoring case.
+func (s *Server) serveUniverseIDs(w http.ResponseWriter, r *http.Request) {
+	var names []string
+	if err := json.NewDecoder(r.Body).Decode(&names); err != nil {
+		s.writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	want := make(map[string]bool, len(names))
+	for _, n := range names {
+		want[strings.ToLower(n)] = true
+	}
+	s.mu.Lock()
+	all := s.namesLocked()
+	s.mu.Unlock()
+	resp := &universe.PostUniverseIdsOKBody{}
+	for _, n := range all {
+		if !want[strings.ToLower(*n.Name)] {
+			continue
+		}
+		switch *n.Category {
+		case "character":
+			resp.Characters = append(resp.Characters, &universe.PostUniverseIdsOKBodyCharactersItems0{ID: *n.ID, Name: *n.Name})
+		case "corporation":
+			resp.Corporations = append(resp.Corporations, &universe.PostUniverseIdsOKBodyCorporationsItems0{ID: *n.ID, Name: *n.Name})
+		case "alliance":
+			resp.Alliances = append(resp.Alliances, &universe.PostUniverseIdsOKBodyAlliancesItems0{ID: *n.ID, Name: *n.Name})
+		case "faction":
+			resp.Factions = append(resp.Factions, &universe.PostUniverseIdsOKBodyFactionsItems0{ID: *n.ID, Name: *n.Name})
+		case "station":
+			resp.Stations = append(resp.Stations, &universe.PostUniverseIdsOKBodyStationsItems0{ID: *n.ID, Name: *n.Name})
+		}
+	}
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+func (s *Server) namesLocked() map[int32]*universe.PostUniverseNamesOKBodyItems0 {
+	m := make(map[int32]*universe.PostUniverseNamesOKBodyItems0)
+	add := func(id int32, name *string, category string) {
+		m[id] = &universe.PostUniverseNamesOKBodyItems0{
+			ID:       &id,
+			Name:     name,
+			Category: &category,
+		}
+	}
+	for id, v := range s.characters {
+		add(id, v.Name, "character")
+	}
+	for id, v := range s.corporations {
+		add(id, v.Name, "corporation")
+	}
+	for id, v := range s.alliances {
+		add(id, v.Name, "alliance")
+	}
+	for _, v := range s.factions {
+		add(*v.FactionID, v.Name, "faction")
+	}
+	for id, v := range s.stations {
+		add(id, v.Name, "station")
+	}
+	for id, v := range s.names {
+		m[id] = v
+	}
+	return m
+}
+
+// serveMarketPrices serves the universe-wide prices.
+func (s *Server) serveMarketPrices(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	resp := make([]*market.GetMarketsPricesOKBodyItems0, 0, len(s.marketPrices))
+	for _, p := range s.marketPrices {
+		resp = append(resp, &market.GetMarketsPricesOKBodyItems0{
+			AdjustedPrice: p.AdjustedPrice,
+			AveragePrice:  p.AveragePrice,
+			TypeID:        i32(p.TypeID),
+		})
+	}
+	s.mu.Unlock()
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveRegionOrders serves a page of up to maxMarketOrdersPerPage of the
+// region's orders, along with the number of pages.
+func (s *Server) serveRegionOrders(w http.ResponseWriter, r *http.Request, sid string) {
+	id, err := strconv.ParseInt(sid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, "Invalid region_id")
+		return
+	}
+	page := 1
+	if p := r.URL.Query().Get("page"); len(p) > 0 {
+		if page, err = strconv.Atoi(p); err != nil || page < 1 {
+			s.writeError(w, r, http.StatusBadRequest, "Invalid page")
+			return
+		}
+	}
+	orderType := r.URL.Query().Get("order_type")
+	s.mu.Lock()
+	var os []esi.MarketOrder
+	for _, o := range s.marketOrders[int32(id)] {
+		if orderType == "all" || (orderType == "buy") == o.IsBuy {
+			os = append(os, o)
+		}
+	}
+	s.mu.Unlock()
+	pages := (len(os) + maxMarketOrdersPerPage - 1) / maxMarketOrdersPerPage
+	if pages == 0 {
+		pages = 1
+	}
+	if page > pages {
+		s.writeError(w, r, http.StatusNotFound, "Requested page does not exist!")
+		return
+	}
+	start := (page - 1) * maxMarketOrdersPerPage
+	end := start + maxMarketOrdersPerPage
+	if end > len(os) {
+		end = len(os)
+	}
+	resp := make([]*market.GetMarketsRegionIDOrdersOKBodyItems0, 0, end-start)
+	for _, o := range os[start:end] {
+		o := o
+		resp = append(resp, &market.GetMarketsRegionIDOrdersOKBodyItems0{
+			Duration:     i32(o.Duration),
+			IsBuyOrder:   &o.IsBuy,
+			Issued:       dateTime(o.Issued),
+			LocationID:   i64(o.LocationID),
+			MinVolume:    i32(o.MinVolume),
+			OrderID:      i64(o.ID),
+			Price:        &o.Price,
+			Range:        str(o.Range),
+			SystemID:     i32(o.SystemID),
+			TypeID:       i32(o.TypeID),
+			VolumeRemain: i32(o.VolumeRemain),
+			VolumeTotal:  i32(o.VolumeTotal),
+		})
+	}
+	w.Header().Set("X-Pages", strconv.Itoa(pages))
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveCorporationAssets serves up to maxAssetsPerPage of the corporation's
+// assets.
+func (s *Server) serveCorporationAssets(w http.ResponseWriter, r *http.Request, sid string) {
+	if _, ok := s.authorize(w, r, esi.ReadCorporationAssetsScope); !ok {
+		return
+	}
+	id, err := strconv.ParseInt(sid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", sid))
+		return
+	}
+	page := 1
+	if p := r.URL.Query().Get("page"); len(p) > 0 {
+		if page, err = strconv.Atoi(p); err != nil || page < 1 {
+			s.writeError(w, r, http.StatusBadRequest, "Invalid page")
+			return
+		}
+	}
+	s.mu.Lock()
+	as := s.corpAssets[int32(id)]
+	s.mu.Unlock()
+	pages := (len(as) + maxAssetsPerPage - 1) / maxAssetsPerPage
+	if pages == 0 {
+		pages = 1
+	}
+	if page > pages {
+		s.writeError(w, r, http.StatusNotFound, "Requested page does not exist!")
+		return
+	}
+	start := (page - 1) * maxAssetsPerPage
+	end := start + maxAssetsPerPage
+	if end > len(as) {
+		end = len(as)
+	}
+	resp := make([]*assets.GetCorporationsCorporationIDAssetsOKBodyItems0, 0, end-start)
+	for _, a := range as[start:end] {
+		a := a
+		resp = append(resp, &assets.GetCorporationsCorporationIDAssetsOKBodyItems0{
+			IsBlueprintCopy: a.IsBlueprintCopy,
+			IsSingleton:     &a.IsSingleton,
+			ItemID:          i64(a.ItemID),
+			LocationFlag:    str(a.LocationFlag),
+			LocationID:      i64(a.LocationID),
+			LocationType:    str(a.LocationType),
+			Quantity:        i32(a.Quantity),
+			TypeID:          i32(a.TypeID),
+		})
+	}
+	w.Header().Set("X-Pages", strconv.Itoa(pages))
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveCorporationAssetNames names the corporation's assets, which like ESI
+// is "None" for those never named.
+func (s *Server) serveCorporationAssetNames(w http.ResponseWriter, r *http.Request, sid string) {
+	if _, ok := s.authorize(w, r, esi.ReadCorporationAssetsScope); !ok {
+		return
+	}
+	id, err := strconv.ParseInt(sid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", sid))
+		return
+	}
+	var ids []int64
+	if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+		s.writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(ids) > esi.MaxAssetNamesPerRequest {
+		s.writeError(w, r, http.StatusBadRequest, "Too many item_ids")
+		return
+	}
+	s.mu.Lock()
+	owned := make(map[int64]bool)
+	for _, a := range s.corpAssets[int32(id)] {
+		owned[a.ItemID] = true
+	}
+	resp := make([]*assets.PostCorporationsCorporationIDAssetsNamesOKBodyItems0, 0, len(ids))
+	for _, itemID := range ids {
+		if !owned[itemID] {
+			resp = nil
+			break
+		}
+		n, ok := s.assetNames[itemID]
+		if !ok {
+			n = "None"
+		}
+		resp = append(resp, &assets.PostCorporationsCorporationIDAssetsNamesOKBodyItems0{
+			ItemID: i64(itemID),
+			Name:   str(n),
+		})
+	}
+	s.mu.Unlock()
+	if resp == nil {
+		s.writeError(w, r, http.StatusNotFound, "Invalid IDs in the request")
+		return
+	}
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveCorporationIndustryJobs serves up to maxIndustryJobsPerPage of the
+// corporation's industry jobs, leaving out completed ones unless asked for.
+func (s *Server) serveCorporationIndustryJobs(w http.ResponseWriter, r *http.Request, sid string) {
+	if _, ok := s.authorize(w, r, esi.ReadCorporationIndustryJobsScope); !ok {
+		return
+	}
+	id, err := strconv.ParseInt(sid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", sid))
+		return
+	}
+	page := 1
+	if p := r.URL.Query().Get("page"); len(p) > 0 {
+		if page, err = strconv.Atoi(p); err != nil || page < 1 {
+			s.writeError(w, r, http.StatusBadRequest, "Invalid page")
+			return
+		}
+	}
+	includeCompleted := r.URL.Query().Get("include_completed") == "true"
+	s.mu.Lock()
+	var js []esi.IndustryJob
+	for _, j := range s.industryJobs[int32(id)] {
+		if includeCompleted || j.Status == esi.IndustryJobActive || j.Status == esi.IndustryJobPaused || j.Status == esi.IndustryJobReady {
+			js = append(js, j)
+		}
+	}
+	s.mu.Unlock()
+	pages := (len(js) + maxIndustryJobsPerPage - 1) / maxIndustryJobsPerPage
+	if pages == 0 {
+		pages = 1
+	}
+	if page > pages {
+		s.writeError(w, r, http.StatusNotFound, "Requested page does not exist!")
+		return
+	}
+	start := (page - 1) * maxIndustryJobsPerPage
+	end := start + maxIndustryJobsPerPage
+	if end > len(js) {
+		end = len(js)
+	}
+	resp := make([]*industry.GetCorporationsCorporationIDIndustryJobsOKBodyItems0, 0, end-start)
+	for _, j := range js[start:end] {
+		resp = append(resp, &industry.GetCorporationsCorporationIDIndustryJobsOKBodyItems0{
+			ActivityID:           i32(j.ActivityID),
+			BlueprintID:          i64(j.BlueprintID),
+			BlueprintLocationID:  i64(j.BlueprintLocationID),
+			BlueprintTypeID:      i32(j.BlueprintTypeID),
+			CompletedCharacterID: j.CompletedCharacterID,
+			CompletedDate:        strfmt.DateTime(j.CompletedDate),
+			Cost:                 j.Cost,
+			Duration:             i32(j.Duration),
+			EndDate:              dateTime(j.EndDate),
+			FacilityID:           i64(j.FacilityID),
+			InstallerID:          i32(j.InstallerID),
+			JobID:                i32(j.ID),
+			LicensedRuns:         j.LicensedRuns,
+			LocationID:           i64(j.LocationID),
+			OutputLocationID:     i64(j.OutputLocationID),
+			PauseDate:            strfmt.DateTime(j.PauseDate),
+			Probability:          j.Probability,
+			ProductTypeID:        j.ProductTypeID,
+			Runs:                 i32(j.Runs),
+			StartDate:            dateTime(j.StartDate),
+			Status:               str(j.Status),
+			SuccessfulRuns:       j.SuccessfulRuns,
+		})
+	}
+	w.Header().Set("X-Pages", strconv.Itoa(pages))
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveMiningObservers serves the corporation's mining observers as a single
+// page.
+func (s *Server) serveMiningObservers(w http.ResponseWriter, r *http.Request, sid string) {
+	if _, ok := s.authorize(w, r, esi.ReadCorporationMiningScope); !ok {
+		return
+	}
+	id, err := strconv.ParseInt(sid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", sid))
+		return
+	}
+	s.mu.Lock()
+	os := s.observers[int32(id)]
+	resp := make([]*industry.GetCorporationCorporationIDMiningObserversOKBodyItems0, 0, len(os))
+	for _, o := range os {
+		d := strfmt.Date(o.LastUpdated)
+		resp = append(resp, &industry.GetCorporationCorporationIDMiningObserversOKBodyItems0{
+			LastUpdated:  &d,
+			ObserverID:   i64(o.ID),
+			ObserverType: str(o.Type),
+		})
+	}
+	s.mu.Unlock()
+	w.Header().Set("X-Pages", "1")
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveMiningLedger serves an observer's ledger as a single page.
+func (s *Server) serveMiningLedger(w http.ResponseWriter, r *http.Request, sid, sobserver string) {
+	if _, ok := s.authorize(w, r, esi.ReadCorporationMiningScope); !ok {
+		return
+	}
+	if _, err := strconv.ParseInt(sid, 10, 32); err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", sid))
+		return
+	}
+	observerID, err := strconv.ParseInt(sobserver, 10, 64)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid observer_id: %s", sobserver))
+		return
+	}
+	s.mu.Lock()
+	es := s.ledgers[observerID]
+	resp := make([]*industry.GetCorporationCorporationIDMiningObserversObserverIDOKBodyItems0, 0, len(es))
+	for _, e := range es {
+		d := strfmt.Date(e.Day)
+		resp = append(resp, &industry.GetCorporationCorporationIDMiningObserversObserverIDOKBodyItems0{
+			CharacterID:           i32(e.CharacterID),
+			LastUpdated:           &d,
+			Quantity:              i64(e.Quantity),
+			RecordedCorporationID: i32(e.RecordedCorporationID),
+			TypeID:                i32(e.TypeID),
+		})
+	}
+	s.mu.Unlock()
+	w.Header().Set("X-Pages", "1")
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveCorporationContracts serves the corporation's contracts as a single
+// page.
+func (s *Server) serveCorporationContracts(w http.ResponseWriter, r *http.Request, sid string) {
+	if _, ok := s.authorize(w, r, esi.ReadCorporationContractsScope); !ok {
+		return
+	}
+	id, err := strconv.ParseInt(sid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", sid))
+		return
+	}
+	s.mu.Lock()
+	cs := s.contracts[int32(id)]
+	s.mu.Unlock()
+	resp := make([]*contracts.GetCorporationsCorporationIDContractsOKBodyItems0, 0, len(cs))
+	for _, c := range cs {
+		resp = append(resp, &contracts.GetCorporationsCorporationIDContractsOKBodyItems0{
+			AcceptorID:          i32(c.AcceptorID),
+			AssigneeID:          i32(c.AssigneeID),
+			Availability:        str(c.Availability),
+			Collateral:          c.Collateral,
+			ContractID:          i32(c.ID),
+			DateAccepted:        strfmt.DateTime(c.DateAccepted),
+			DateCompleted:       strfmt.DateTime(c.DateCompleted),
+			DateExpired:         dateTime(c.DateExpired),
+			DateIssued:          dateTime(c.DateIssued),
+			DaysToComplete:      c.DaysToComplete,
+			EndLocationID:       c.EndLocationID,
+			ForCorporation:      boolean(c.ForCorporation),
+			IssuerCorporationID: i32(c.IssuerCorporationID),
+			IssuerID:            i32(c.IssuerID),
+			Price:               c.Price,
+			Reward:              c.Reward,
+			StartLocationID:     c.StartLocationID,
+			Status:              str(c.Status),
+			Title:               c.Title,
+			Type:                str(c.Type),
+			Volume:              c.Volume,
+		})
+	}
+	w.Header().Set("X-Pages", "1")
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveCorporationContacts serves the corporation's contacts as a single page.
+func (s *Server) serveCorporationContacts(w http.ResponseWriter, r *http.Request, sid string) {
+	if _, ok := s.authorize(w, r, esi.ReadCorporationContactsScope); !ok {
+		return
+	}
+	id, err := strconv.ParseInt(sid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", sid))
+		return
+	}
+	s.mu.Lock()
+	cs := s.corpContacts[int32(id)]
+	s.mu.Unlock()
+	resp := make([]*contacts.GetCorporationsCorporationIDContactsOKBodyItems0, 0, len(cs))
+	for _, c := range cs {
+		resp = append(resp, &contacts.GetCorporationsCorporationIDContactsOKBodyItems0{
+			ContactID:   i32(c.ID),
+			ContactType: str(c.Type),
+			Standing:    f32(float32(c.Standing)),
+		})
+	}
+	w.Header().Set("X-Pages", "1")
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// serveAllianceContacts serves the alliance's contacts as a single page.
+func (s *Server) serveAllianceContacts(w http.ResponseWriter, r *http.Request, sid string) {
+	if _, ok := s.authorize(w, r, esi.ReadAllianceContactsScope); !ok {
+		return
+	}
+	id, err := strconv.ParseInt(sid, 10, 32)
+	if err != nil {
+		s.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid id: %s", sid))
+		return
+	}
+	s.mu.Lock()
+	cs := s.allyContacts[int32(id)]
+	s.mu.Unlock()
+	resp := make([]*contacts.GetAlliancesAllianceIDContactsOKBodyItems0, 0, len(cs))
+	for _, c := range cs {
+		resp = append(resp, &contacts.GetAlliancesAllianceIDContactsOKBodyItems0{
+			ContactID:   i32(c.ID),
+			ContactType: str(c.Type),
+			Standing:    f32(float32(c.Standing)),
+		})
+	}
+	w.Header().Set("X-Pages", "1")
+	s.writeJSON(w, r, http.StatusOK, resp)
+}
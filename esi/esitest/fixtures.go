@@ -0,0 +1,471 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esitest
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/esi/client/alliance"
+	"github.com/cjslep/dharma/esi/client/character"
+	"github.com/cjslep/dharma/esi/client/corporation"
+	"github.com/cjslep/dharma/esi/client/faction_warfare"
+	"github.com/cjslep/dharma/esi/client/killmails"
+	"github.com/cjslep/dharma/esi/client/universe"
+	"github.com/cjslep/dharma/esi/client/wallet"
+	"github.com/go-openapi/strfmt"
+)
+
+const (
+	imagesPathPrefix = "/images/"
+
+	// IDs of the seeded universe.
+	CaldariStateID      int32 = 500001
+	CaldariRaceID       int32 = 1
+	DeteisBloodlineID   int32 = 1
+	TubeChildAncestryID int32 = 24
+	JitaStationID       int32 = 60003760
+	CaldariNavyCorpID   int32 = 1000035
+)
+
+// seedUniverse adds the nearly static universe data that characters and
+// corporations refer to.
+func (s *Server) seedUniverse() {
+	s.factions = []*universe.GetUniverseFactionsOKBodyItems0{
+		{
+			CorporationID:        CaldariNavyCorpID,
+			Description:          str("The Caldari State is ruled by several mega-corporations."),
+			FactionID:            i32(CaldariStateID),
+			IsUnique:             boolean(true),
+			MilitiaCorporationID: 1000180,
+			Name:                 str("Caldari State"),
+			SizeFactor:           f32(5),
+			SolarSystemID:        30000145,
+			StationCount:         i32(1503),
+			StationSystemCount:   i32(503),
+		},
+	}
+	s.races = []*universe.GetUniverseRacesOKBodyItems0{
+		{
+			AllianceID:  i32(CaldariStateID),
+			Description: str("Founded on the tenets of patriotism and hard work."),
+			Name:        str("Caldari"),
+			RaceID:      i32(CaldariRaceID),
+		},
+	}
+	s.bloodlines = []*universe.GetUniverseBloodlinesOKBodyItems0{
+		{
+			BloodlineID:   i32(DeteisBloodlineID),
+			Charisma:      i32(6),
+			CorporationID: i32(1000006),
+			Description:   str("The Deteis are regarded as a people of the state."),
+			Intelligence:  i32(7),
+			Memory:        i32(7),
+			Name:          str("Deteis"),
+			Perception:    i32(5),
+			RaceID:        i32(CaldariRaceID),
+			ShipTypeID:    i32(601),
+			Willpower:     i32(5),
+		},
+	}
+	s.ancestries = []*universe.GetUniverseAncestriesOKBodyItems0{
+		{
+			BloodlineID:      i32(DeteisBloodlineID),
+			Description:      str("Raised in the corporate creches of the State."),
+			ID:               i32(TubeChildAncestryID),
+			Name:             str("Tube Child"),
+			ShortDescription: "Raised by the State.",
+		},
+	}
+	s.stations[JitaStationID] = &universe.GetUniverseStationsStationIDOKBody{
+		MaxDockableShipVolume:    f32(50000000),
+		Name:                     str("Jita IV - Moon 4 - Caldari Navy Assembly Plant"),
+		OfficeRentalCost:         f32(10000),
+		Owner:                    CaldariNavyCorpID,
+		RaceID:                   CaldariRaceID,
+		ReprocessingEfficiency:   f32(0.5),
+		ReprocessingStationsTake: f32(0.05),
+		Services:                 []string{"market", "office-rental"},
+		StationID:                i32(JitaStationID),
+		SystemID:                 i32(30000142),
+		TypeID:                   i32(1531),
+	}
+}
+
+// AddCharacter adds a Caldari character belonging to the corporation and
+// alliance. The allianceID may be zero.
+func (s *Server) AddCharacter(id int32, name string, corpID, allianceID int32) {
+	s.SetCharacter(id, &character.GetCharactersCharacterIDOKBody{
+		AllianceID:     allianceID,
+		AncestryID:     TubeChildAncestryID,
+		Birthday:       dateTime(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)),
+		BloodlineID:    i32(DeteisBloodlineID),
+		CorporationID:  i32(corpID),
+		Gender:         str("female"),
+		Name:           str(name),
+		RaceID:         i32(CaldariRaceID),
+		SecurityStatus: f32(0),
+	})
+}
+
+// SetCharacter serves the character exactly as given.
+func (s *Server) SetCharacter(id int32, c *character.GetCharactersCharacterIDOKBody) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.characters[id] = c
+}
+
+// AddCorporation adds a corporation headquartered in Jita. The allianceID may
+// be zero.
+func (s *Server) AddCorporation(id int32, name, ticker string, ceoID, allianceID int32) {
+	s.SetCorporation(id, &corporation.GetCorporationsCorporationIDOKBody{
+		AllianceID:    allianceID,
+		CeoID:         i32(ceoID),
+		CreatorID:     i32(ceoID),
+		DateFounded:   *dateTime(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)),
+		HomeStationID: JitaStationID,
+		MemberCount:   i32(1),
+		Name:          str(name),
+		TaxRate:       f32(0.1),
+		Ticker:        str(ticker),
+	})
+}
+
+// SetCorporation serves the corporation exactly as given.
+func (s *Server) SetCorporation(id int32, c *corporation.GetCorporationsCorporationIDOKBody) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.corporations[id] = c
+}
+
+// AddAlliance adds an alliance created and run by the corporation.
+func (s *Server) AddAlliance(id int32, name, ticker string, creatorID, executorID int32) {
+	s.SetAlliance(id, &alliance.GetAlliancesAllianceIDOKBody{
+		CreatorCorporationID:  i32(executorID),
+		CreatorID:             i32(creatorID),
+		DateFounded:           dateTime(time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC)),
+		ExecutorCorporationID: executorID,
+		Name:                  str(name),
+		Ticker:                str(ticker),
+	})
+}
+
+// SetAlliance serves the alliance exactly as given.
+func (s *Server) SetAlliance(id int32, a *alliance.GetAlliancesAllianceIDOKBody) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alliances[id] = a
+}
+
+// SetCorporationWallets sets the balance of each of the corporation's wallet
+// divisions.
+func (s *Server) SetCorporationWallets(corpID int32, balances map[int32]float64) {
+	ws := make([]*wallet.GetCorporationsCorporationIDWalletsOKBodyItems0, 0, len(balances))
+	for div, bal := range balances {
+		ws = append(ws, &wallet.GetCorporationsCorporationIDWalletsOKBodyItems0{
+			Balance:  f64(bal),
+			Division: i32(div),
+		})
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.wallets[corpID] = ws
+}
+
+// AddCorporationJournal appends entries to the wallet division's journal,
+// which must have increasing IDs.
+func (s *Server) AddCorporationJournal(corpID, division int32, js ...esi.JournalEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := walletKey{corpID: corpID, division: division}
+	s.journals[k] = append(s.journals[k], js...)
+}
+
+// AddCorporationTransactions appends transactions to the wallet division,
+// which must have increasing IDs.
+func (s *Server) AddCorporationTransactions(corpID, division int32, ts ...esi.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := walletKey{corpID: corpID, division: division}
+	s.transactions[k] = append(s.transactions[k], ts...)
+}
+
+// SetCorporationFactionWarfareStats sets the corporation's current faction
+// warfare statistics.
+func (s *Server) SetCorporationFactionWarfareStats(corpID int32, st *esi.FactionWarfareStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fwStats[corpID] = &faction_warfare.GetCorporationsCorporationIDFwStatsOKBody{
+		EnlistedOn: strfmt.DateTime(st.EnlistedOn),
+		FactionID:  st.FactionID,
+		Kills: &faction_warfare.GetCorporationsCorporationIDFwStatsOKBodyKills{
+			LastWeek:  i32(st.KillsLastWeek),
+			Total:     i32(st.KillsTotal),
+			Yesterday: i32(st.KillsYesterday),
+		},
+		Pilots: st.Pilots,
+		VictoryPoints: &faction_warfare.GetCorporationsCorporationIDFwStatsOKBodyVictoryPoints{
+			LastWeek:  i32(st.VPLastWeek),
+			Total:     i32(st.VPTotal),
+			Yesterday: i32(st.VPYesterday),
+		},
+	}
+}
+
+// AddCalendarEvent adds an event to the character's calendar, with the
+// character's response to it. Events should be added in order of their date.
+func (s *Server) AddCalendarEvent(charID int32, e esi.CalendarEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calendars[charID] = append(s.calendars[charID], e)
+}
+
+// AddMail adds a mail to the character's mailbox. Mail should be added oldest
+// first, and their IDs must increase.
+func (s *Server) AddMail(charID int32, m esi.Mail) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mailboxes[charID] = append(s.mailboxes[charID], m)
+	if m.ID > s.nextMailID {
+		s.nextMailID = m.ID
+	}
+}
+
+// AddMailLabel adds a label to organize the character's mail with. Every
+// character has the inbox and sent labels.
+func (s *Server) AddMailLabel(charID int32, l esi.MailLabel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mailLabels[charID] = append(s.mailLabels[charID], l)
+}
+
+// AddMailingList subscribes the character to a mailing list.
+func (s *Server) AddMailingList(charID int32, l esi.MailingList) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mailingLists[charID] = append(s.mailingLists[charID], l)
+}
+
+// SetCharacterRoles sets the roles the character holds in their
+// corporation.
+func (s *Server) SetCharacterRoles(charID int32, roles ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roles[charID] = roles
+}
+
+// AddName adds a name for an entity the Server does not otherwise know of,
+// such as an item type or solar system, so that it may be resolved.
+func (s *Server) AddName(id int32, name, category string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.names[id] = &universe.PostUniverseNamesOKBodyItems0{
+		Category: str(category),
+		ID:       i32(id),
+		Name:     str(name),
+	}
+}
+
+// AddCorporationKillmail adds a killmail to the corporation's recent kills
+// and losses.
+func (s *Server) AddCorporationKillmail(corpID int32, km *esi.Killmail) {
+	toItem := func(i esi.KillmailItem) *killmails.GetKillmailsKillmailIDKillmailHashOKBodyVictimItemsItems0 {
+		v := &killmails.GetKillmailsKillmailIDKillmailHashOKBodyVictimItemsItems0{
+			Flag:              i32(i.Flag),
+			ItemTypeID:        i32(i.TypeID),
+			QuantityDestroyed: i.QuantityDestroyed,
+			QuantityDropped:   i.QuantityDropped,
+			Singleton:         i32(i.Singleton),
+		}
+		for _, n := range i.Items {
+			v.Items = append(v.Items, &killmails.GetKillmailsKillmailIDKillmailHashOKBodyVictimItemsItems0ItemsItems0{
+				Flag:              i32(n.Flag),
+				ItemTypeID:        i32(n.TypeID),
+				QuantityDestroyed: n.QuantityDestroyed,
+				QuantityDropped:   n.QuantityDropped,
+				Singleton:         i32(n.Singleton),
+			})
+		}
+		return v
+	}
+	v := km.Victim
+	p := &killmails.GetKillmailsKillmailIDKillmailHashOKBody{
+		KillmailID:    i32(km.ID),
+		KillmailTime:  dateTime(km.Time),
+		MoonID:        km.MoonID,
+		SolarSystemID: i32(km.SolarSystemID),
+		Victim: &killmails.GetKillmailsKillmailIDKillmailHashOKBodyVictim{
+			AllianceID:    v.AllianceID,
+			CharacterID:   v.CharacterID,
+			CorporationID: v.CorporationID,
+			DamageTaken:   i32(v.DamageTaken),
+			FactionID:     v.FactionID,
+			ShipTypeID:    i32(v.ShipTypeID),
+		},
+		WarID: km.WarID,
+	}
+	for _, i := range v.Items {
+		p.Victim.Items = append(p.Victim.Items, toItem(i))
+	}
+	for _, a := range km.Attackers {
+		p.Attackers = append(p.Attackers, &killmails.GetKillmailsKillmailIDKillmailHashOKBodyAttackersItems0{
+			AllianceID:     a.AllianceID,
+			CharacterID:    a.CharacterID,
+			CorporationID:  a.CorporationID,
+			DamageDone:     i32(a.DamageDone),
+			FactionID:      a.FactionID,
+			FinalBlow:      boolean(a.FinalBlow),
+			SecurityStatus: f32(a.SecurityStatus),
+			ShipTypeID:     a.ShipTypeID,
+			WeaponTypeID:   a.WeaponTypeID,
+		})
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.killmails[km.ID] = p
+	s.kmHashes[km.ID] = km.Hash
+	s.corpKms[corpID] = append(s.corpKms[corpID], km.ID)
+}
+
+// imageURL is where the Server serves a placeholder portrait or logo.
+func (s *Server) imageURL(kind string, id int32, size int) string {
+	u := &url.URL{
+		Scheme:   "https",
+		Host:     s.Host(),
+		Path:     fmt.Sprintf("%s%s/%d", imagesPathPrefix, kind, id),
+		RawQuery: url.Values{"size": []string{strconv.Itoa(size)}}.Encode(),
+	}
+	return u.String()
+}
+
+// serveImage serves a square grey PNG of the requested size.
+func (s *Server) serveImage(w http.ResponseWriter, r *http.Request) {
+	size, err := strconv.Atoi(r.URL.Query().Get("size"))
+	if err != nil || size <= 0 || size > 1024 {
+		size = 64
+	}
+	img := image.NewGray(image.Rect(0, 0, size, size))
+	for i := range img.Pix {
+		img.Pix[i] = color.Gray{Y: 0x80}.Y
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Expires", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	png.Encode(w, img)
+}
+
+func str(s string) *string {
+	return &s
+}
+
+func i32(i int32) *int32 {
+	return &i
+}
+
+func i64(i int64) *int64 {
+	return &i
+}
+
+func f32(f float32) *float32 {
+	return &f
+}
+
+func f64(f float64) *float64 {
+	return &f
+}
+
+func boolean(b bool) *bool {
+	return &b
+}
+
+func dateTime(t time.Time) *strfmt.DateTime {
+	d := strfmt.DateTime(t)
+	return &d
+}
+
+// SetMarketPrices sets the universe-wide market prices.
+func (s *Server) SetMarketPrices(ps ...esi.MarketPrice) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marketPrices = ps
+}
+
+// AddMarketOrders adds orders to the region's order book.
+func (s *Server) AddMarketOrders(regionID int32, os ...esi.MarketOrder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marketOrders[regionID] = append(s.marketOrders[regionID], os...)
+}
+
+// AddCorporationAssets adds assets owned by the corporation.
+func (s *Server) AddCorporationAssets(corpID int32, as ...esi.Asset) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.corpAssets[corpID] = append(s.corpAssets[corpID], as...)
+}
+
+// SetAssetName names an asset, as a player would a ship or container.
+func (s *Server) SetAssetName(itemID int64, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.assetNames[itemID] = name
+}
+
+// AddCorporationIndustryJobs adds industry jobs installed by the corporation's
+// members.
+func (s *Server) AddCorporationIndustryJobs(corpID int32, js ...esi.IndustryJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.industryJobs[corpID] = append(s.industryJobs[corpID], js...)
+}
+
+// AddCorporationContracts adds contracts the corporation issued, accepted, or
+// was assigned.
+func (s *Server) AddCorporationContracts(corpID int32, cs ...esi.Contract) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.contracts[corpID] = append(s.contracts[corpID], cs...)
+}
+
+// AddCorporationContacts adds the corporation's standings towards others.
+func (s *Server) AddCorporationContacts(corpID int32, cs ...esi.Contact) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.corpContacts[corpID] = append(s.corpContacts[corpID], cs...)
+}
+
+// AddAllianceContacts adds the alliance's standings towards others.
+func (s *Server) AddAllianceContacts(allianceID int32, cs ...esi.Contact) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allyContacts[allianceID] = append(s.allyContacts[allianceID], cs...)
+}
+
+// AddMiningObserver adds a mining observer owned by the corporation, with what
+// was mined at it.
+func (s *Server) AddMiningObserver(corpID int32, o esi.MiningObserver, es ...esi.MiningLedgerEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observers[corpID] = append(s.observers[corpID], o)
+	s.ledgers[o.ID] = append(s.ledgers[o.ID], es...)
+}
@@ -0,0 +1,336 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package esitest provides a stand-in for ESI and EVE Online's single sign on,
+// served locally so that dharma's ESI clients may be exercised without the
+// network.
+//
+// The Server is seeded with a small universe, and further characters,
+// corporations, and alliances may be added. Responses are swagger-compatible,
+// carry the caching and error limit headers that ESI sends, and authenticated
+// routes require access tokens the Server itself issued.
+package esitest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/esi/client"
+	"github.com/cjslep/dharma/esi/client/alliance"
+	"github.com/cjslep/dharma/esi/client/character"
+	"github.com/cjslep/dharma/esi/client/corporation"
+	"github.com/cjslep/dharma/esi/client/faction_warfare"
+	"github.com/cjslep/dharma/esi/client/killmails"
+	"github.com/cjslep/dharma/esi/client/universe"
+	"github.com/cjslep/dharma/esi/client/wallet"
+	"github.com/go-openapi/strfmt"
+)
+
+const (
+	// ClientID is the application client identifier the Server accepts.
+	ClientID = "esitest-client"
+	// Secret is the application secret the Server accepts.
+	Secret = "esitest-secret"
+
+	defaultErrorLimit       = 100
+	defaultErrorLimitWindow = time.Minute
+	defaultTokenLifetime    = 20 * time.Minute
+)
+
+// Server is a fake ESI and single sign on service.
+//
+// It is safe to add data and adjust its behavior while it serves requests.
+type Server struct {
+	s   *httptest.Server
+	key *rsa.PrivateKey
+
+	mu sync.Mutex
+	// ESI data
+	characters   map[int32]*character.GetCharactersCharacterIDOKBody
+	corporations map[int32]*corporation.GetCorporationsCorporationIDOKBody
+	alliances    map[int32]*alliance.GetAlliancesAllianceIDOKBody
+	wallets      map[int32][]*wallet.GetCorporationsCorporationIDWalletsOKBodyItems0
+	factions     []*universe.GetUniverseFactionsOKBodyItems0
+	races        []*universe.GetUniverseRacesOKBodyItems0
+	bloodlines   []*universe.GetUniverseBloodlinesOKBodyItems0
+	ancestries   []*universe.GetUniverseAncestriesOKBodyItems0
+	stations     map[int32]*universe.GetUniverseStationsStationIDOKBody
+	journals     map[walletKey][]esi.JournalEntry
+	fwStats      map[int32]*faction_warfare.GetCorporationsCorporationIDFwStatsOKBody
+	transactions map[walletKey][]esi.Transaction
+	names        map[int32]*universe.PostUniverseNamesOKBodyItems0
+	killmails    map[int32]*killmails.GetKillmailsKillmailIDKillmailHashOKBody
+	kmHashes     map[int32]string
+	corpKms      map[int32][]int32
+	calendars    map[int32][]esi.CalendarEvent
+	mailboxes    map[int32][]esi.Mail
+	mailLabels   map[int32][]esi.MailLabel
+	mailingLists map[int32][]esi.MailingList
+	nextMailID   int32
+	roles        map[int32][]string
+	marketPrices []esi.MarketPrice
+	marketOrders map[int32][]esi.MarketOrder
+	corpAssets   map[int32][]esi.Asset
+	assetNames   map[int64]string
+	industryJobs map[int32][]esi.IndustryJob
+	observers    map[int32][]esi.MiningObserver
+	ledgers      map[int64][]esi.MiningLedgerEntry
+	contracts    map[int32][]esi.Contract
+	corpContacts map[int32][]esi.Contact
+	allyContacts map[int32][]esi.Contact
+	// ESI behavior
+	cacheFor         time.Duration
+	errorLimitRemain int
+	errorLimitReset  time.Time
+	requests         map[string]int
+	// Single sign on state
+	loginAs       int32
+	tokenLifetime time.Duration
+	codes         map[string]*grant
+	refreshes     map[string]*grant
+	accesses      map[string]*grant
+	nRefreshed    int
+	nRevoked      int
+}
+
+// walletKey identifies one of a corporation's wallet divisions.
+type walletKey struct {
+	corpID   int32
+	division int32
+}
+
+// grant is a character's authorization of a set of scopes.
+type grant struct {
+	charID int32
+	scopes []string
+	// challenge is the PKCE code challenge the authorization was requested
+	// with, if any.
+	challenge string
+}
+
+// NewServer starts a Server seeded with a small universe. It must be closed
+// when no longer needed.
+func NewServer() (*Server, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		key:              key,
+		characters:       make(map[int32]*character.GetCharactersCharacterIDOKBody),
+		corporations:     make(map[int32]*corporation.GetCorporationsCorporationIDOKBody),
+		alliances:        make(map[int32]*alliance.GetAlliancesAllianceIDOKBody),
+		wallets:          make(map[int32][]*wallet.GetCorporationsCorporationIDWalletsOKBodyItems0),
+		stations:         make(map[int32]*universe.GetUniverseStationsStationIDOKBody),
+		journals:         make(map[walletKey][]esi.JournalEntry),
+		fwStats:          make(map[int32]*faction_warfare.GetCorporationsCorporationIDFwStatsOKBody),
+		transactions:     make(map[walletKey][]esi.Transaction),
+		names:            make(map[int32]*universe.PostUniverseNamesOKBodyItems0),
+		killmails:        make(map[int32]*killmails.GetKillmailsKillmailIDKillmailHashOKBody),
+		kmHashes:         make(map[int32]string),
+		corpKms:          make(map[int32][]int32),
+		calendars:        make(map[int32][]esi.CalendarEvent),
+		mailboxes:        make(map[int32][]esi.Mail),
+		mailLabels:       make(map[int32][]esi.MailLabel),
+		mailingLists:     make(map[int32][]esi.MailingList),
+		roles:            make(map[int32][]string),
+		marketOrders:     make(map[int32][]esi.MarketOrder),
+		corpAssets:       make(map[int32][]esi.Asset),
+		assetNames:       make(map[int64]string),
+		industryJobs:     make(map[int32][]esi.IndustryJob),
+		observers:        make(map[int32][]esi.MiningObserver),
+		ledgers:          make(map[int64][]esi.MiningLedgerEntry),
+		contracts:        make(map[int32][]esi.Contract),
+		corpContacts:     make(map[int32][]esi.Contact),
+		allyContacts:     make(map[int32][]esi.Contact),
+		errorLimitRemain: defaultErrorLimit,
+		requests:         make(map[string]int),
+		tokenLifetime:    defaultTokenLifetime,
+		codes:            make(map[string]*grant),
+		refreshes:        make(map[string]*grant),
+		accesses:         make(map[string]*grant),
+	}
+	s.seedUniverse()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest/", s.serveESI)
+	mux.HandleFunc(ssoAuthorizePath, s.serveAuthorize)
+	mux.HandleFunc(ssoTokenPath, s.serveToken)
+	mux.HandleFunc(ssoKeysPath, s.serveKeys)
+	mux.HandleFunc(ssoRevokePath, s.serveRevoke)
+	mux.HandleFunc(imagesPathPrefix, s.serveImage)
+	s.s = httptest.NewTLSServer(mux)
+	return s, nil
+}
+
+// Close shuts down the Server.
+func (s *Server) Close() {
+	s.s.Close()
+}
+
+// Host is the host and port the Server listens on.
+func (s *Server) Host() string {
+	return s.s.Listener.Addr().String()
+}
+
+// HTTPClient trusts the Server's certificate.
+func (s *Server) HTTPClient() *http.Client {
+	return s.s.Client()
+}
+
+// ESIClient is a generated ESI client that issues requests to the Server.
+func (s *Server) ESIClient() *client.ESI {
+	cfg := client.DefaultTransportConfig().
+		WithHost(s.Host()).
+		WithSchemes([]string{"https"})
+	return client.NewHTTPClientWithConfig(strfmt.Default, cfg)
+}
+
+// ThinClient issues requests to the Server.
+func (s *Server) ThinClient() *esi.ThinClient {
+	return &esi.ThinClient{
+		ESIClient: s.ESIClient(),
+		Timeout:   10 * time.Second,
+		Client:    s.HTTPClient(),
+	}
+}
+
+// OAuth2Client authenticates with the Server as the application identified by
+// ClientID and Secret.
+func (s *Server) OAuth2Client(redirectURI string) *esi.OAuth2Client {
+	return &esi.OAuth2Client{
+		RedirectURI: redirectURI,
+		ClientID:    ClientID,
+		Secret:      Secret,
+		Client:      s.HTTPClient(),
+		SSOHost:     s.Host(),
+	}
+}
+
+// PKCEOAuth2Client authenticates with the Server as the application identified
+// by ClientID alone, using the PKCE flow.
+func (s *Server) PKCEOAuth2Client(redirectURI string) *esi.OAuth2Client {
+	return &esi.OAuth2Client{
+		RedirectURI: redirectURI,
+		ClientID:    ClientID,
+		Client:      s.HTTPClient(),
+		SSOHost:     s.Host(),
+		PKCE:        true,
+	}
+}
+
+// SetCacheDuration sets how long ESI responses claim to be fresh for. By
+// default they are already expired, so that every request reaches the Server.
+func (s *Server) SetCacheDuration(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cacheFor = d
+}
+
+// SetErrorLimitRemain sets the number of errors remaining in the current
+// error limit window.
+func (s *Server) SetErrorLimitRemain(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errorLimitRemain = n
+	if s.errorLimitReset.IsZero() {
+		s.errorLimitReset = time.Now().Add(defaultErrorLimitWindow)
+	}
+}
+
+// Requests is the number of requests received for the ESI route, such as
+// "/corporations/98000001/", including ones answered with 304 Not Modified.
+func (s *Server) Requests(route string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[route]
+}
+
+// writeJSON writes v along with the caching and error limit headers that ESI
+// provides, honoring If-None-Match.
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		status = http.StatusInternalServerError
+		b = []byte(`{"error":"could not marshal response"}`)
+	}
+	s.mu.Lock()
+	now := time.Now()
+	if status >= 400 {
+		s.spendErrorLocked(now)
+	}
+	remain, reset := s.errorLimitLocked(now)
+	expires := now.Add(s.cacheFor)
+	s.mu.Unlock()
+
+	h := w.Header()
+	h.Set("X-Esi-Error-Limit-Remain", fmt.Sprintf("%d", remain))
+	h.Set("X-Esi-Error-Limit-Reset", fmt.Sprintf("%d", reset))
+	if status != http.StatusOK {
+		h.Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(b)
+		return
+	}
+	etag := fmt.Sprintf("\"%x\"", sha256.Sum256(b))
+	h.Set("Cache-Control", "public")
+	h.Set("ETag", etag)
+	h.Set("Expires", expires.UTC().Format(http.TimeFormat))
+	h.Set("Last-Modified", now.UTC().Format(http.TimeFormat))
+	if strings.Contains(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+func (s *Server) writeError(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	s.writeJSON(w, r, status, map[string]string{"error": msg})
+}
+
+// spendErrorLocked counts an error response against the error limit.
+func (s *Server) spendErrorLocked(now time.Time) {
+	s.errorLimitLocked(now)
+	if s.errorLimitRemain > 0 {
+		s.errorLimitRemain--
+	}
+	if s.errorLimitReset.IsZero() {
+		s.errorLimitReset = now.Add(defaultErrorLimitWindow)
+	}
+}
+
+// errorLimitLocked returns the errors remaining and the seconds until the
+// error limit window resets, beginning a new window if the last one elapsed.
+func (s *Server) errorLimitLocked(now time.Time) (remain, resetSec int) {
+	if !s.errorLimitReset.IsZero() && !now.Before(s.errorLimitReset) {
+		s.errorLimitRemain = defaultErrorLimit
+		s.errorLimitReset = time.Time{}
+	}
+	if s.errorLimitReset.IsZero() {
+		return s.errorLimitRemain, int(defaultErrorLimitWindow.Seconds())
+	}
+	return s.errorLimitRemain, int(s.errorLimitReset.Sub(now).Seconds())
+}
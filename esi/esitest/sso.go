@@ -0,0 +1,326 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esitest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/pascaldekloe/jwt"
+)
+
+const (
+	ssoAuthorizePath = "/v2/oauth/authorize/"
+	ssoTokenPath     = "/v2/oauth/token"
+	ssoKeysPath      = "/oauth/jwks"
+	ssoRevokePath    = "/v2/oauth/revoke"
+	// issuer must be one that esi.ValidateEveClaims accepts.
+	issuer = "login.eveonline.com"
+	keyID  = "JWT-Signature-Key"
+)
+
+// SetLoginCharacter chooses the character that logs in whenever a user is
+// sent to the Server's authorize page.
+func (s *Server) SetLoginCharacter(charID int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loginAs = charID
+}
+
+// SetTokenLifetime sets how long newly issued access tokens are valid for.
+func (s *Server) SetTokenLifetime(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenLifetime = d
+}
+
+// Grant authorizes the application to act on behalf of the character with
+// the scopes, returning the authorization code to exchange for tokens.
+func (s *Server) Grant(charID int32, scopes ...esi.Scope) string {
+	g := &grant{charID: charID}
+	for _, scope := range scopes {
+		g.scopes = append(g.scopes, string(scope))
+	}
+	return s.issueCode(g)
+}
+
+func (s *Server) issueCode(g *grant) string {
+	code := randomString()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.codes[code] = g
+	return code
+}
+
+// Tokens authorizes the character with the scopes, returning the tokens the
+// application would obtain at the end of a successful login.
+func (s *Server) Tokens(charID int32, scopes ...esi.Scope) (*esi.Tokens, error) {
+	o := s.OAuth2Client("")
+	jr, err := o.GetAuthorization(s.Grant(charID, scopes...), "")
+	if err != nil {
+		return nil, err
+	}
+	ks, err := o.FetchEveOnlineKeys()
+	if err != nil {
+		return nil, err
+	}
+	claims, err := esi.ValidateToken([]byte(jr.AccessToken), ks)
+	if err != nil {
+		return nil, err
+	}
+	return esi.NewTokens(jr, claims)
+}
+
+// Refreshes is the number of times tokens were refreshed.
+func (s *Server) Refreshes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nRefreshed
+}
+
+// Revocations is the number of refresh tokens that were revoked.
+func (s *Server) Revocations() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nRevoked
+}
+
+// serveAuthorize immediately logs in as the login character, granting every
+// requested scope, and redirects back to the application.
+func (s *Server) serveAuthorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || q.Get("client_id") != ClientID || q.Get("response_type") != "code" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+	challenge := q.Get("code_challenge")
+	if len(challenge) > 0 && q.Get("code_challenge_method") != "S256" {
+		http.Error(w, "unsupported code challenge method", http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	charID := s.loginAs
+	s.mu.Unlock()
+	if charID == 0 {
+		http.Error(w, "no login character set", http.StatusBadRequest)
+		return
+	}
+	g := &grant{charID: charID, challenge: challenge}
+	for _, scope := range strings.FieldsFunc(q.Get("scope"), func(r rune) bool { return r == ',' || r == ' ' }) {
+		g.scopes = append(g.scopes, scope)
+	}
+	v := redirect.Query()
+	v.Set("code", s.issueCode(g))
+	v.Set("state", q.Get("state"))
+	redirect.RawQuery = v.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// serveToken exchanges authorization codes and refresh tokens for new tokens.
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, `{"error":"invalid_request"}`, http.StatusBadRequest)
+		return
+	}
+	if !s.isApplication(r) {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+	var g *grant
+	s.mu.Lock()
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		code := r.PostForm.Get("code")
+		g = s.codes[code]
+		delete(s.codes, code)
+		if g != nil && !verifies(r, g) {
+			g = nil
+		}
+	case "refresh_token":
+		refresh := r.PostForm.Get("refresh_token")
+		g = s.refreshes[refresh]
+		delete(s.refreshes, refresh)
+		if g != nil {
+			s.nRefreshed++
+		}
+	}
+	lifetime := s.tokenLifetime
+	var name string
+	if g != nil {
+		if c, ok := s.characters[g.charID]; ok {
+			name = *c.Name
+		}
+	}
+	s.mu.Unlock()
+	if g == nil {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	access, err := s.sign(g, name, lifetime)
+	if err != nil {
+		http.Error(w, `{"error":"server_error"}`, http.StatusInternalServerError)
+		return
+	}
+	refresh := randomString()
+	s.mu.Lock()
+	s.refreshes[refresh] = g
+	s.accesses[access] = g
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"access_token":%q,"expires_in":%d,"token_type":"Bearer","refresh_token":%q}`,
+		access, int(lifetime.Seconds()), refresh)
+}
+
+// serveRevoke invalidates a refresh token. Like the single sign on, unknown
+// tokens are not an error.
+func (s *Server) serveRevoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("token_type_hint") != "refresh_token" {
+		http.Error(w, `{"error":"invalid_request"}`, http.StatusBadRequest)
+		return
+	}
+	if !s.isApplication(r) {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+	refresh := r.PostForm.Get("token")
+	s.mu.Lock()
+	if _, ok := s.refreshes[refresh]; ok {
+		delete(s.refreshes, refresh)
+		s.nRevoked++
+	}
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+// isPublicClient determines whether the request identifies the application by
+// its client id alone, as the PKCE flow does, instead of with the secret.
+func isPublicClient(r *http.Request) bool {
+	return len(r.Header.Get("Authorization")) == 0
+}
+
+// verifies determines whether the token request may exchange the grant's code.
+// Codes requested with a PKCE code challenge need the matching code verifier,
+// and public clients cannot exchange codes requested without one.
+func verifies(r *http.Request, g *grant) bool {
+	if len(g.challenge) == 0 {
+		return !isPublicClient(r)
+	}
+	return esi.PKCEChallenge(r.PostForm.Get("code_verifier")) == g.challenge
+}
+
+// isApplication determines whether the request authenticates as ClientID. The
+// request's form must already be parsed.
+func (s *Server) isApplication(r *http.Request) bool {
+	if isPublicClient(r) {
+		return r.PostForm.Get("client_id") == ClientID
+	}
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Basic ")
+	// OAuth2Client encodes the credentials with the URL alphabet.
+	b, err := base64.URLEncoding.DecodeString(auth)
+	if err != nil {
+		if b, err = base64.StdEncoding.DecodeString(auth); err != nil {
+			return false
+		}
+	}
+	return string(b) == ClientID+":"+Secret
+}
+
+// sign creates an access token shaped like the ones EVE Online's single sign
+// on issues.
+func (s *Server) sign(g *grant, name string, lifetime time.Duration) (string, error) {
+	now := time.Now()
+	var c jwt.Claims
+	c.Issuer = issuer
+	c.Subject = fmt.Sprintf("CHARACTER:EVE:%d", g.charID)
+	c.Audiences = []string{ClientID, "EVE Online"}
+	c.Issued = jwt.NewNumericTime(now)
+	c.Expires = jwt.NewNumericTime(now.Add(lifetime))
+	c.KeyID = keyID
+	scp := make([]interface{}, 0, len(g.scopes))
+	for _, scope := range g.scopes {
+		scp = append(scp, scope)
+	}
+	c.Set = map[string]interface{}{
+		"name": name,
+		"scp":  scp,
+		"jti":  randomString(),
+	}
+	b, err := c.RSASign(jwt.RS256, s.key)
+	return string(b), err
+}
+
+// serveKeys publishes the key that signs the access tokens.
+func (s *Server) serveKeys(w http.ResponseWriter, r *http.Request) {
+	enc := base64.URLEncoding.WithPadding(base64.NoPadding)
+	n := enc.EncodeToString(s.key.PublicKey.N.Bytes())
+	e := enc.EncodeToString(big.NewInt(int64(s.key.PublicKey.E)).Bytes())
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"keys":[{"alg":"RS256","kid":%q,"kty":"RSA","use":"sig","n":%q,"e":%q}],"SkipUnresolvedJsonWebKeys":true}`,
+		keyID, n, e)
+}
+
+// authorize ensures the request carries an access token the Server issued,
+// and that the character granted the scope. Otherwise it writes the error that
+// ESI would.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, scope esi.Scope) (*grant, bool) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	claims, err := jwt.RSACheck([]byte(token), &s.key.PublicKey)
+	if err != nil || !claims.Valid(time.Now()) {
+		s.writeError(w, r, http.StatusUnauthorized, "authorization not provided")
+		return nil, false
+	}
+	s.mu.Lock()
+	g, ok := s.accesses[token]
+	s.mu.Unlock()
+	if !ok {
+		s.writeError(w, r, http.StatusUnauthorized, "authorization not provided")
+		return nil, false
+	}
+	for _, granted := range g.scopes {
+		if granted == string(scope) {
+			return g, true
+		}
+	}
+	s.writeError(w, r, http.StatusForbidden, "token not valid for scope")
+	return nil, false
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
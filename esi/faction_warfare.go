@@ -0,0 +1,66 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"time"
+)
+
+// FactionWarfareStats are a corporation's faction warfare statistics at one
+// point in time. A corporation not enlisted in faction warfare has a zero
+// FactionID.
+type FactionWarfareStats struct {
+	FactionID      int32
+	EnlistedOn     time.Time
+	Pilots         int32
+	KillsYesterday int32
+	KillsLastWeek  int32
+	KillsTotal     int32
+	VPYesterday    int32
+	VPLastWeek     int32
+	VPTotal        int32
+}
+
+// CorporationFactionWarfareStats obtains the corporation's current faction
+// warfare statistics, as seen by the character.
+func (x *AuthClient) CorporationFactionWarfareStats(ctx context.Context, charID, corpID int32) (*FactionWarfareStats, error) {
+	auth, err := x.auth(ctx, charID, ReadFactionWarfareStatsScope)
+	if err != nil {
+		return nil, err
+	}
+	p, err := x.t.corporationFactionWarfareStats(ctx, auth, corpID)
+	if err != nil {
+		return nil, err
+	}
+	s := &FactionWarfareStats{
+		FactionID:  p.FactionID,
+		EnlistedOn: time.Time(p.EnlistedOn),
+		Pilots:     p.Pilots,
+	}
+	if k := p.Kills; k != nil {
+		s.KillsYesterday = deref32(k.Yesterday)
+		s.KillsLastWeek = deref32(k.LastWeek)
+		s.KillsTotal = deref32(k.Total)
+	}
+	if v := p.VictoryPoints; v != nil {
+		s.VPYesterday = deref32(v.Yesterday)
+		s.VPLastWeek = deref32(v.LastWeek)
+		s.VPTotal = deref32(v.Total)
+	}
+	return s, nil
+}
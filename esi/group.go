@@ -0,0 +1,115 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultCallTimeout bounds a shared call when no timeout is configured.
+const defaultCallTimeout = time.Minute
+
+// call is an in-flight call whose result is shared by everyone who asked for
+// it while it was in-flight.
+type call struct {
+	done chan struct{}
+	v    interface{}
+	err  error
+}
+
+// callGroup coalesces concurrent calls with the same key into a single call.
+//
+// Results are not remembered once a call completes, so later calls with the
+// same key are issued again, and may be served by the HTTP cache instead.
+type callGroup struct {
+	// timeout bounds each shared call.
+	timeout time.Duration
+	mu      sync.Mutex
+	m       map[string]*call
+}
+
+// Do calls fn, unless a call with the same key is already in-flight, in which
+// case it waits for and returns that call's result instead.
+//
+// The call does not belong to any one caller, so fn is given a context that is
+// detached from ctx and bounded by the group's timeout. A caller whose ctx is
+// done stops waiting, without failing the call for the others.
+//
+// Since the result is shared, callers must not modify it.
+func (g *callGroup) Do(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.m == nil {
+		g.m = make(map[string]*call)
+	}
+	c, ok := g.m[key]
+	if !ok {
+		c = &call{done: make(chan struct{})}
+		g.m[key] = c
+		go g.do(key, c, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.v, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (g *callGroup) do(key string, c *call, fn func(ctx context.Context) (interface{}, error)) {
+	timeout := g.timeout
+	if timeout <= 0 {
+		timeout = defaultCallTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	c.v, c.err = fn(ctx)
+
+	g.mu.Lock()
+	delete(g.m, key)
+	g.mu.Unlock()
+	close(c.done)
+}
+
+// forEach calls fn for each index in [0, n), using at most max concurrent
+// goroutines. The errors are returned in index order.
+func forEach(n, max int, fn func(i int) error) []error {
+	errs := make([]error, n)
+	if max <= 0 || max > n {
+		max = n
+	}
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(max)
+	for w := 0; w < max; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				errs[i] = fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		idx <- i
+	}
+	close(idx)
+	wg.Wait()
+	return errs
+}
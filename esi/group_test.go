@@ -0,0 +1,70 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCallGroupOutlivesCancelledCaller(t *testing.T) {
+	var g callGroup
+	release := make(chan struct{})
+	started := make(chan struct{})
+	fn := func(ctx context.Context) (interface{}, error) {
+		close(started)
+		select {
+		case <-release:
+			return "result", nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := g.Do(first, "key", fn)
+		firstErr <- err
+	}()
+	<-started
+	cancel()
+	if err := <-firstErr; err != context.Canceled {
+		t.Fatalf("got %v for the cancelled caller, want %v", err, context.Canceled)
+	}
+	time.AfterFunc(50*time.Millisecond, func() { close(release) })
+	v, err := g.Do(context.Background(), "key", func(ctx context.Context) (interface{}, error) {
+		return nil, errors.New("call was not shared")
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if v != "result" {
+		t.Fatalf("got %v, want result", v)
+	}
+}
+
+func TestCallGroupTimeout(t *testing.T) {
+	g := callGroup{timeout: 10 * time.Millisecond}
+	_, err := g.Do(context.Background(), "key", func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,130 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"time"
+)
+
+// The activities of an industry job.
+const (
+	IndustryManufacturing       int32 = 1
+	IndustryTimeEfficiency      int32 = 3
+	IndustryMaterialEfficiency  int32 = 4
+	IndustryCopying             int32 = 5
+	IndustryReverseEngineering  int32 = 7
+	IndustryInvention           int32 = 8
+	IndustryReactions           int32 = 9
+	IndustryReactionsDeprecated int32 = 11
+)
+
+// The statuses of an industry job. ESI reports jobs that are finished but not
+// yet delivered as still active, so the end date must be checked too.
+const (
+	IndustryJobActive    = "active"
+	IndustryJobCancelled = "cancelled"
+	IndustryJobDelivered = "delivered"
+	IndustryJobPaused    = "paused"
+	IndustryJobReady     = "ready"
+	IndustryJobReverted  = "reverted"
+)
+
+type IndustryJob struct {
+	ID                  int32
+	ActivityID          int32
+	Status              string
+	InstallerID         int32
+	FacilityID          int64
+	LocationID          int64
+	BlueprintID         int64
+	BlueprintTypeID     int32
+	BlueprintLocationID int64
+	OutputLocationID    int64
+	ProductTypeID       int32
+	Runs                int32
+	LicensedRuns        int32
+	SuccessfulRuns      int32
+	Probability         float32
+	Cost                float64
+	Duration            int32
+	StartDate           time.Time
+	EndDate             time.Time
+	PauseDate           time.Time
+	CompletedDate       time.Time
+	// CompletedCharacterID delivered the job, which is zero if it is not
+	// yet delivered.
+	CompletedCharacterID int32
+}
+
+// IsReady determines whether the job is finished and waiting to be delivered.
+func (j IndustryJob) IsReady(now time.Time) bool {
+	return j.Status == IndustryJobReady ||
+		(j.Status == IndustryJobActive && !j.EndDate.After(now))
+}
+
+// CorporationIndustryJobs obtains the corporation's industry jobs, including
+// those completed in the last 90 days, as seen by the character.
+func (x *AuthClient) CorporationIndustryJobs(ctx context.Context, charID, corpID int32) ([]IndustryJob, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationIndustryJobsScope)
+	if err != nil {
+		return nil, err
+	}
+	var js []IndustryJob
+	for page, pages := int32(1), int32(1); page <= pages; page++ {
+		p, n, err := x.t.corporationIndustryJobs(ctx, auth, corpID, page)
+		if err != nil {
+			return nil, err
+		}
+		pages = n
+		for _, j := range p {
+			if j == nil || j.JobID == nil {
+				continue
+			}
+			ij := IndustryJob{
+				ID:                   *j.JobID,
+				ActivityID:           deref32(j.ActivityID),
+				Status:               derefString(j.Status),
+				InstallerID:          deref32(j.InstallerID),
+				FacilityID:           deref64(j.FacilityID),
+				LocationID:           deref64(j.LocationID),
+				BlueprintID:          deref64(j.BlueprintID),
+				BlueprintTypeID:      deref32(j.BlueprintTypeID),
+				BlueprintLocationID:  deref64(j.BlueprintLocationID),
+				OutputLocationID:     deref64(j.OutputLocationID),
+				ProductTypeID:        j.ProductTypeID,
+				Runs:                 deref32(j.Runs),
+				LicensedRuns:         j.LicensedRuns,
+				SuccessfulRuns:       j.SuccessfulRuns,
+				Probability:          j.Probability,
+				Cost:                 j.Cost,
+				Duration:             deref32(j.Duration),
+				PauseDate:            time.Time(j.PauseDate),
+				CompletedDate:        time.Time(j.CompletedDate),
+				CompletedCharacterID: j.CompletedCharacterID,
+			}
+			if j.StartDate != nil {
+				ij.StartDate = time.Time(*j.StartDate)
+			}
+			if j.EndDate != nil {
+				ij.EndDate = time.Time(*j.EndDate)
+			}
+			js = append(js, ij)
+		}
+	}
+	return js, nil
+}
@@ -27,14 +27,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/big"
-	"net/http"
 
 	"github.com/pascaldekloe/jwt"
 	"github.com/pkg/errors"
 )
 
-func FetchEveOnlineKeys() (*OAuthKeysMetadata, error) {
-	resp, err := http.Get("https://login.eveonline.com/oauth/jwks")
+// FetchEveOnlineKeys obtains the public keys that sign the single sign on
+// JWTs.
+func (o *OAuth2Client) FetchEveOnlineKeys() (*OAuthKeysMetadata, error) {
+	resp, err := o.Client.Get(o.ssoURL(ssoKeysPath))
 	if err != nil {
 		return nil, fmt.Errorf("error fetching ESI JWT public keys: %w", err)
 	} else {
@@ -61,15 +62,46 @@ type OAuthKeysMetadata struct {
 var _ driver.Valuer = OAuthKeysMetadata{}
 var _ sql.Scanner = &OAuthKeysMetadata{}
 
-func (o *OAuthKeysMetadata) JWTKey() *KeysMetadata {
+// Key obtains the key with the key ID, or nil if there is none.
+func (o *OAuthKeysMetadata) Key(kid string) *KeysMetadata {
 	for _, v := range o.Keys {
-		if v.Id == "JWT-Signature-Key" {
+		if v.Id == kid {
 			return v
 		}
 	}
 	return nil
 }
 
+// UnknownKeyIDError is returned when a JWT is signed by a key that is not in
+// any of the known key sets, such as when the single sign on rotated its keys
+// since they were last fetched.
+type UnknownKeyIDError struct {
+	KeyID string
+}
+
+func (u *UnknownKeyIDError) Error() string {
+	return fmt.Sprintf("jwt signed with unknown key id: %q", u.KeyID)
+}
+
+// ValidateToken checks the JWT's signature with the key its header names,
+// searching the key sets in order. Passing the previous key set along with
+// the current one allows tokens signed before a key rotation to validate.
+func ValidateToken(b []byte, sets ...*OAuthKeysMetadata) (*jwt.Claims, error) {
+	unverified, err := jwt.ParseWithoutCheck(b)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range sets {
+		if o == nil {
+			continue
+		}
+		if k := o.Key(unverified.KeyID); k != nil {
+			return k.ValidateToken(b)
+		}
+	}
+	return nil, &UnknownKeyIDError{KeyID: unverified.KeyID}
+}
+
 func (o OAuthKeysMetadata) Value() (driver.Value, error) {
 	return json.Marshal(o)
 }
@@ -0,0 +1,212 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cjslep/dharma/esi/client/killmails"
+	"github.com/cjslep/dharma/internal/util"
+)
+
+// KillmailRef identifies a killmail, which is enough to fetch it in full.
+type KillmailRef struct {
+	ID   int32
+	Hash string
+}
+
+type Killmail struct {
+	ID            int32
+	Hash          string
+	Time          time.Time
+	SolarSystemID int32
+	// MoonID and WarID are zero if not applicable.
+	MoonID    int32
+	WarID     int32
+	Victim    KillmailVictim
+	Attackers []KillmailAttacker
+}
+
+// KillmailVictim is the one that lost their ship or structure. The
+// CharacterID is zero for structures and NPCs.
+type KillmailVictim struct {
+	CharacterID   int32
+	CorporationID int32
+	AllianceID    int32
+	FactionID     int32
+	ShipTypeID    int32
+	DamageTaken   int32
+	Items         []KillmailItem
+}
+
+// KillmailAttacker is one of the parties involved in a kill. The CharacterID
+// is zero for NPCs.
+type KillmailAttacker struct {
+	CharacterID    int32
+	CorporationID  int32
+	AllianceID     int32
+	FactionID      int32
+	ShipTypeID     int32
+	WeaponTypeID   int32
+	DamageDone     int32
+	FinalBlow      bool
+	SecurityStatus float32
+}
+
+// KillmailItem is fitted to or carried by the victim. Items may contain other
+// items, such as the contents of a container, but only one level deep.
+type KillmailItem struct {
+	TypeID            int32
+	Flag              int32
+	Singleton         int32
+	QuantityDestroyed int64
+	QuantityDropped   int64
+	Items             []KillmailItem
+}
+
+// RecentCorporationKillmails obtains the references to the kills and losses
+// of the corporation over the last 90 days, as seen by the character.
+func (x *AuthClient) RecentCorporationKillmails(ctx context.Context, charID, corpID int32) ([]KillmailRef, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationKillmailsScope)
+	if err != nil {
+		return nil, err
+	}
+	var refs []KillmailRef
+	for page, pages := int32(1), int32(1); page <= pages; page++ {
+		var p []*killmails.GetCorporationsCorporationIDKillmailsRecentOKBodyItems0
+		p, pages, err = x.t.corporationKillmailsRecent(ctx, auth, corpID, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range p {
+			if k.KillmailID == nil || k.KillmailHash == nil {
+				continue
+			}
+			refs = append(refs, KillmailRef{
+				ID:   *k.KillmailID,
+				Hash: *k.KillmailHash,
+			})
+		}
+	}
+	return refs, nil
+}
+
+// Killmails fetches the killmails in full.
+func (x *Client) Killmails(ctx context.Context, refs []KillmailRef) ([]*Killmail, error) {
+	kms := make([]*Killmail, len(refs))
+	errs := forEach(len(refs), x.maxConcurrent, func(idx int) error {
+		km, err := x.Killmail(ctx, refs[idx])
+		if err != nil {
+			return err
+		}
+		kms[idx] = km
+		return nil
+	})
+	err := util.ToErrors(errs)
+	if err != nil {
+		return nil, err
+	}
+	return kms, nil
+}
+
+// Killmail fetches a killmail in full.
+func (x *Client) Killmail(ctx context.Context, ref KillmailRef) (*Killmail, error) {
+	v, err := x.g.Do(ctx, fmt.Sprintf("killmail:%d", ref.ID), func(ctx context.Context) (interface{}, error) {
+		return x.t.killmail(ctx, ref.ID, ref.Hash)
+	})
+	if err != nil {
+		return nil, err
+	}
+	p := v.(*killmails.GetKillmailsKillmailIDKillmailHashOKBody)
+	km := &Killmail{
+		ID:        ref.ID,
+		Hash:      ref.Hash,
+		MoonID:    p.MoonID,
+		WarID:     p.WarID,
+		Attackers: make([]KillmailAttacker, 0, len(p.Attackers)),
+	}
+	if p.KillmailTime != nil {
+		km.Time = time.Time(*p.KillmailTime)
+	}
+	if p.SolarSystemID != nil {
+		km.SolarSystemID = *p.SolarSystemID
+	}
+	if v := p.Victim; v != nil {
+		km.Victim = KillmailVictim{
+			CharacterID:   v.CharacterID,
+			CorporationID: v.CorporationID,
+			AllianceID:    v.AllianceID,
+			FactionID:     v.FactionID,
+			Items:         make([]KillmailItem, 0, len(v.Items)),
+		}
+		if v.ShipTypeID != nil {
+			km.Victim.ShipTypeID = *v.ShipTypeID
+		}
+		if v.DamageTaken != nil {
+			km.Victim.DamageTaken = *v.DamageTaken
+		}
+		for _, i := range v.Items {
+			if i == nil {
+				continue
+			}
+			item := KillmailItem{
+				TypeID:            deref32(i.ItemTypeID),
+				Flag:              deref32(i.Flag),
+				Singleton:         deref32(i.Singleton),
+				QuantityDestroyed: i.QuantityDestroyed,
+				QuantityDropped:   i.QuantityDropped,
+			}
+			for _, n := range i.Items {
+				if n == nil {
+					continue
+				}
+				item.Items = append(item.Items, KillmailItem{
+					TypeID:            deref32(n.ItemTypeID),
+					Flag:              deref32(n.Flag),
+					Singleton:         deref32(n.Singleton),
+					QuantityDestroyed: n.QuantityDestroyed,
+					QuantityDropped:   n.QuantityDropped,
+				})
+			}
+			km.Victim.Items = append(km.Victim.Items, item)
+		}
+	}
+	for _, a := range p.Attackers {
+		if a == nil {
+			continue
+		}
+		ka := KillmailAttacker{
+			CharacterID:   a.CharacterID,
+			CorporationID: a.CorporationID,
+			AllianceID:    a.AllianceID,
+			FactionID:     a.FactionID,
+			ShipTypeID:    a.ShipTypeID,
+			WeaponTypeID:  a.WeaponTypeID,
+			DamageDone:    deref32(a.DamageDone),
+		}
+		if a.FinalBlow != nil {
+			ka.FinalBlow = *a.FinalBlow
+		}
+		if a.SecurityStatus != nil {
+			ka.SecurityStatus = *a.SecurityStatus
+		}
+		km.Attackers = append(km.Attackers, ka)
+	}
+	return km, nil
+}
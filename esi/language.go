@@ -0,0 +1,46 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"golang.org/x/text/language"
+)
+
+var (
+	// esiLanguageCodes are the languages ESI localizes responses into.
+	esiLanguageCodes = []string{"en", "en-us", "de", "fr", "ja", "ru", "ko"}
+	esiLanguageTags  = []language.Tag{
+		language.English,
+		language.AmericanEnglish,
+		language.German,
+		language.French,
+		language.Japanese,
+		language.Russian,
+		language.Korean,
+	}
+	esiLanguageMatcher = language.NewMatcher(esiLanguageTags)
+)
+
+// esiLanguage maps the language to the closest one ESI supports, defaulting to
+// English.
+func esiLanguage(l language.Tag) string {
+	_, i, conf := esiLanguageMatcher.Match(l)
+	if conf == language.No {
+		return esiLanguageCodes[0]
+	}
+	return esiLanguageCodes[i]
+}
@@ -0,0 +1,249 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"time"
+
+	"github.com/cjslep/dharma/esi/client/mail"
+	"github.com/pkg/errors"
+)
+
+const (
+	MailRecipientAlliance    = "alliance"
+	MailRecipientCharacter   = "character"
+	MailRecipientCorporation = "corporation"
+	MailRecipientMailingList = "mailing_list"
+)
+
+const (
+	// MaxMailRecipients is the most recipients ESI accepts for one mail.
+	MaxMailRecipients = 50
+	// MaxMailSubjectLength is the longest subject ESI accepts.
+	MaxMailSubjectLength = 1000
+	// MaxMailBodyLength is the longest body ESI accepts.
+	MaxMailBodyLength = 10000
+	// NMailPerPage is the number of mail headers ESI returns at a time.
+	NMailPerPage = 50
+)
+
+type MailRecipient struct {
+	ID   int32
+	Type string
+}
+
+// MailHeader is a mail in a character's mailbox, without its body.
+type MailHeader struct {
+	ID         int32
+	From       int32
+	Subject    string
+	Timestamp  time.Time
+	IsRead     bool
+	Labels     []int32
+	Recipients []MailRecipient
+}
+
+type Mail struct {
+	MailHeader
+	Body string
+}
+
+type MailLabel struct {
+	ID          int32
+	Name        string
+	Color       string
+	UnreadCount int32
+}
+
+type MailingList struct {
+	ID   int32
+	Name string
+}
+
+// MailHeaders obtains a page of the character's mail, newest first. Only mail
+// with one of the labels is included, unless no labels are given. Mail newer
+// than or equal to beforeID is skipped, unless it is zero.
+func (x *AuthClient) MailHeaders(ctx context.Context, charID int32, labels []int32, beforeID int32) ([]MailHeader, error) {
+	auth, err := x.auth(ctx, charID, ReadMailScope)
+	if err != nil {
+		return nil, err
+	}
+	var before *int32
+	if beforeID != 0 {
+		before = &beforeID
+	}
+	p, err := x.t.characterMail(ctx, auth, charID, labels, before)
+	if err != nil {
+		return nil, err
+	}
+	hs := make([]MailHeader, 0, len(p))
+	for _, m := range p {
+		if m == nil {
+			continue
+		}
+		h := MailHeader{
+			ID:        m.MailID,
+			From:      m.From,
+			Subject:   m.Subject,
+			Timestamp: time.Time(m.Timestamp),
+			IsRead:    m.IsRead,
+			Labels:    m.Labels,
+		}
+		for _, r := range m.Recipients {
+			if r == nil {
+				continue
+			}
+			h.Recipients = append(h.Recipients, MailRecipient{
+				ID:   deref32(r.RecipientID),
+				Type: derefString(r.RecipientType),
+			})
+		}
+		hs = append(hs, h)
+	}
+	return hs, nil
+}
+
+// Mail obtains a mail in the character's mailbox, along with its body.
+func (x *AuthClient) Mail(ctx context.Context, charID, mailID int32) (*Mail, error) {
+	auth, err := x.auth(ctx, charID, ReadMailScope)
+	if err != nil {
+		return nil, err
+	}
+	p, err := x.t.characterMailByID(ctx, auth, charID, mailID)
+	if err != nil {
+		return nil, err
+	}
+	m := &Mail{
+		MailHeader: MailHeader{
+			ID:        mailID,
+			From:      p.From,
+			Subject:   p.Subject,
+			Timestamp: time.Time(p.Timestamp),
+			IsRead:    p.Read,
+		},
+		Body: p.Body,
+	}
+	for _, l := range p.Labels {
+		if l != nil {
+			m.Labels = append(m.Labels, *l)
+		}
+	}
+	for _, r := range p.Recipients {
+		if r == nil {
+			continue
+		}
+		m.Recipients = append(m.Recipients, MailRecipient{
+			ID:   deref32(r.RecipientID),
+			Type: derefString(r.RecipientType),
+		})
+	}
+	return m, nil
+}
+
+// UpdateMail sets the labels of a mail in the character's mailbox and
+// whether it has been read. Labels not given are removed from the mail.
+func (x *AuthClient) UpdateMail(ctx context.Context, charID, mailID int32, labels []int32, read bool) error {
+	auth, err := x.auth(ctx, charID, OrganizeMailScope)
+	if err != nil {
+		return err
+	}
+	body := mail.PutCharactersCharacterIDMailMailIDBody{
+		Labels: make([]*int32, len(labels)),
+		Read:   read,
+	}
+	for i := range labels {
+		body.Labels[i] = &labels[i]
+	}
+	return x.t.updateCharacterMail(ctx, auth, charID, mailID, body)
+}
+
+// MailLabels obtains the labels the character organizes their mail with, and
+// the total number of unread mail.
+func (x *AuthClient) MailLabels(ctx context.Context, charID int32) ([]MailLabel, int32, error) {
+	auth, err := x.auth(ctx, charID, ReadMailScope)
+	if err != nil {
+		return nil, 0, err
+	}
+	p, err := x.t.characterMailLabels(ctx, auth, charID)
+	if err != nil {
+		return nil, 0, err
+	}
+	ls := make([]MailLabel, 0, len(p.Labels))
+	for _, l := range p.Labels {
+		if l == nil {
+			continue
+		}
+		ls = append(ls, MailLabel{
+			ID:          deref32(l.LabelID),
+			Name:        l.Name,
+			Color:       derefString(l.Color),
+			UnreadCount: deref32(l.UnreadCount),
+		})
+	}
+	return ls, deref32(p.TotalUnreadCount), nil
+}
+
+// MailingLists obtains the mailing lists the character is subscribed to.
+func (x *AuthClient) MailingLists(ctx context.Context, charID int32) ([]MailingList, error) {
+	auth, err := x.auth(ctx, charID, ReadMailScope)
+	if err != nil {
+		return nil, err
+	}
+	p, err := x.t.characterMailingLists(ctx, auth, charID)
+	if err != nil {
+		return nil, err
+	}
+	ls := make([]MailingList, 0, len(p))
+	for _, l := range p {
+		if l == nil {
+			continue
+		}
+		ls = append(ls, MailingList{
+			ID:   deref32(l.MailingListID),
+			Name: derefString(l.Name),
+		})
+	}
+	return ls, nil
+}
+
+// SendMail sends a mail from the character, returning the new mail's ID.
+func (x *AuthClient) SendMail(ctx context.Context, charID int32, to []MailRecipient, subject, body string) (int32, error) {
+	if len(to) == 0 || len(to) > MaxMailRecipients {
+		return 0, errors.Errorf("mail must have between 1 and %d recipients, got %d", MaxMailRecipients, len(to))
+	} else if len(subject) > MaxMailSubjectLength {
+		return 0, errors.Errorf("mail subject is longer than %d", MaxMailSubjectLength)
+	} else if len(body) > MaxMailBodyLength {
+		return 0, errors.Errorf("mail body is longer than %d", MaxMailBodyLength)
+	}
+	auth, err := x.auth(ctx, charID, SendMailScope)
+	if err != nil {
+		return 0, err
+	}
+	m := mail.PostCharactersCharacterIDMailBody{
+		Body:       &body,
+		Subject:    &subject,
+		Recipients: make([]*mail.PostCharactersCharacterIDMailParamsBodyRecipientsItems0, len(to)),
+	}
+	for i := range to {
+		m.Recipients[i] = &mail.PostCharactersCharacterIDMailParamsBodyRecipientsItems0{
+			RecipientID:   &to[i].ID,
+			RecipientType: &to[i].Type,
+		}
+	}
+	return x.t.sendCharacterMail(ctx, auth, charID, m)
+}
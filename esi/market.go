@@ -0,0 +1,105 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"time"
+)
+
+// MarketPrice is CCP Games' universe-wide price of a type, which is what
+// industry costs and contract collateral are based on.
+type MarketPrice struct {
+	TypeID        int32
+	AdjustedPrice float64
+	AveragePrice  float64
+}
+
+type MarketOrder struct {
+	ID           int64
+	TypeID       int32
+	LocationID   int64
+	SystemID     int32
+	IsBuy        bool
+	Price        float64
+	VolumeRemain int32
+	VolumeTotal  int32
+	MinVolume    int32
+	Issued       time.Time
+	Duration     int32
+	Range        string
+}
+
+// MarketPrices obtains the universe-wide prices of every type on the market.
+func (x *Client) MarketPrices(ctx context.Context) ([]MarketPrice, error) {
+	p, err := x.t.marketsPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ps := make([]MarketPrice, 0, len(p))
+	for _, mp := range p {
+		if mp == nil || mp.TypeID == nil {
+			continue
+		}
+		ps = append(ps, MarketPrice{
+			TypeID:        *mp.TypeID,
+			AdjustedPrice: mp.AdjustedPrice,
+			AveragePrice:  mp.AveragePrice,
+		})
+	}
+	return ps, nil
+}
+
+// RegionOrders obtains every buy and sell order in the region's order book,
+// fetching all of its pages.
+func (x *Client) RegionOrders(ctx context.Context, regionID int32) ([]MarketOrder, error) {
+	var os []MarketOrder
+	for page, pages := int32(1), int32(1); page <= pages; page++ {
+		p, n, err := x.t.marketsRegionOrders(ctx, regionID, page)
+		if err != nil {
+			return nil, err
+		}
+		pages = n
+		for _, o := range p {
+			if o == nil || o.OrderID == nil {
+				continue
+			}
+			mo := MarketOrder{
+				ID:           *o.OrderID,
+				TypeID:       deref32(o.TypeID),
+				LocationID:   deref64(o.LocationID),
+				SystemID:     deref32(o.SystemID),
+				VolumeRemain: deref32(o.VolumeRemain),
+				VolumeTotal:  deref32(o.VolumeTotal),
+				MinVolume:    deref32(o.MinVolume),
+				Duration:     deref32(o.Duration),
+				Range:        derefString(o.Range),
+			}
+			if o.IsBuyOrder != nil {
+				mo.IsBuy = *o.IsBuyOrder
+			}
+			if o.Price != nil {
+				mo.Price = *o.Price
+			}
+			if o.Issued != nil {
+				mo.Issued = time.Time(*o.Issued)
+			}
+			os = append(os, mo)
+		}
+	}
+	return os, nil
+}
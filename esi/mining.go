@@ -0,0 +1,101 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"time"
+)
+
+// MiningObserver is a structure, such as a refinery, that records the ore
+// mined near it.
+type MiningObserver struct {
+	ID          int64
+	Type        string
+	LastUpdated time.Time
+}
+
+// MiningLedgerEntry is the ore of one type a character mined at an observer
+// on one day.
+type MiningLedgerEntry struct {
+	CharacterID           int32
+	RecordedCorporationID int32
+	TypeID                int32
+	Quantity              int64
+	Day                   time.Time
+}
+
+// CorporationMiningObservers obtains the corporation's mining observers, as
+// seen by the character.
+func (x *AuthClient) CorporationMiningObservers(ctx context.Context, charID, corpID int32) ([]MiningObserver, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationMiningScope)
+	if err != nil {
+		return nil, err
+	}
+	var os []MiningObserver
+	for page, pages := int32(1), int32(1); page <= pages; page++ {
+		p, n, err := x.t.corporationMiningObservers(ctx, auth, corpID, page)
+		if err != nil {
+			return nil, err
+		}
+		pages = n
+		for _, o := range p {
+			if o == nil || o.ObserverID == nil {
+				continue
+			}
+			mo := MiningObserver{
+				ID:   *o.ObserverID,
+				Type: derefString(o.ObserverType),
+			}
+			if o.LastUpdated != nil {
+				mo.LastUpdated = time.Time(*o.LastUpdated)
+			}
+			os = append(os, mo)
+		}
+	}
+	return os, nil
+}
+
+// CorporationMiningLedger obtains what was mined at the observer in the last
+// 30 days, as seen by the character.
+func (x *AuthClient) CorporationMiningLedger(ctx context.Context, charID, corpID int32, observerID int64) ([]MiningLedgerEntry, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationMiningScope)
+	if err != nil {
+		return nil, err
+	}
+	var es []MiningLedgerEntry
+	for page, pages := int32(1), int32(1); page <= pages; page++ {
+		p, n, err := x.t.corporationMiningLedger(ctx, auth, corpID, observerID, page)
+		if err != nil {
+			return nil, err
+		}
+		pages = n
+		for _, e := range p {
+			if e == nil || e.CharacterID == nil || e.TypeID == nil || e.LastUpdated == nil {
+				continue
+			}
+			es = append(es, MiningLedgerEntry{
+				CharacterID:           *e.CharacterID,
+				RecordedCorporationID: deref32(e.RecordedCorporationID),
+				TypeID:                *e.TypeID,
+				Quantity:              deref64(e.Quantity),
+				Day:                   time.Time(*e.LastUpdated),
+			})
+		}
+	}
+	return es, nil
+}
@@ -0,0 +1,164 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+
+	"github.com/cjslep/dharma/esi/client/universe"
+	"golang.org/x/text/language"
+)
+
+const (
+	// ESI limits the number of IDs that may be resolved at once.
+	maxNamesPerRequest = 1000
+	// ESI limits the number of names that may be resolved at once.
+	maxIDsPerRequest = 500
+)
+
+const (
+	AllianceCategory      = "alliance"
+	CharacterCategory     = "character"
+	ConstellationCategory = "constellation"
+	CorporationCategory   = "corporation"
+	FactionCategory       = "faction"
+	InventoryTypeCategory = "inventory_type"
+	RegionCategory        = "region"
+	SolarSystemCategory   = "solar_system"
+	StationCategory       = "station"
+	AgentCategory         = "agent"
+)
+
+// Entity is the name of anything in the Eve universe that has an ID.
+type Entity struct {
+	ID       int32
+	Name     string
+	Category string
+}
+
+// Names resolves the names of the IDs in bulk, which may be characters,
+// corporations, alliances, and other universe entities. IDs that are not
+// valid are omitted.
+func (x *Client) Names(ctx context.Context, ids []int32) ([]Entity, error) {
+	es := make([]Entity, 0, len(ids))
+	for start := 0; start < len(ids); start += maxNamesPerRequest {
+		end := start + maxNamesPerRequest
+		if end > len(ids) {
+			end = len(ids)
+		}
+		b, err := x.names(ctx, ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, b...)
+	}
+	return es, nil
+}
+
+// names resolves one batch of IDs. ESI fails the whole request if any one of
+// the IDs is invalid, so then each half is resolved on its own until only the
+// invalid IDs are left out.
+func (x *Client) names(ctx context.Context, ids []int32) ([]Entity, error) {
+	p, err := x.t.universeNames(ctx, ids)
+	if _, ok := err.(*universe.PostUniverseNamesNotFound); ok {
+		if len(ids) == 1 {
+			return nil, nil
+		}
+		mid := len(ids) / 2
+		a, err := x.names(ctx, ids[:mid])
+		if err != nil {
+			return nil, err
+		}
+		b, err := x.names(ctx, ids[mid:])
+		if err != nil {
+			return nil, err
+		}
+		return append(a, b...), nil
+	} else if err != nil {
+		return nil, err
+	}
+	es := make([]Entity, 0, len(p))
+	for _, n := range p {
+		var e Entity
+		if n.ID != nil {
+			e.ID = *n.ID
+		}
+		if n.Name != nil {
+			e.Name = *n.Name
+		}
+		if n.Category != nil {
+			e.Category = *n.Category
+		}
+		es = append(es, e)
+	}
+	return es, nil
+}
+
+// IDs resolves the exact names into their IDs in bulk. Names that do not
+// match anything are omitted, and a name may match entities in more than one
+// category.
+func (x *Client) IDs(ctx context.Context, names []string, l language.Tag) ([]Entity, error) {
+	es := make([]Entity, 0, len(names))
+	add := func(category string, id int32, name string) {
+		es = append(es, Entity{
+			ID:       id,
+			Name:     name,
+			Category: category,
+		})
+	}
+	for start := 0; start < len(names); start += maxIDsPerRequest {
+		end := start + maxIDsPerRequest
+		if end > len(names) {
+			end = len(names)
+		}
+		p, err := x.t.universeIDs(ctx, names[start:end], l)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range p.Agents {
+			add(AgentCategory, v.ID, v.Name)
+		}
+		for _, v := range p.Alliances {
+			add(AllianceCategory, v.ID, v.Name)
+		}
+		for _, v := range p.Characters {
+			add(CharacterCategory, v.ID, v.Name)
+		}
+		for _, v := range p.Constellations {
+			add(ConstellationCategory, v.ID, v.Name)
+		}
+		for _, v := range p.Corporations {
+			add(CorporationCategory, v.ID, v.Name)
+		}
+		for _, v := range p.Factions {
+			add(FactionCategory, v.ID, v.Name)
+		}
+		for _, v := range p.InventoryTypes {
+			add(InventoryTypeCategory, v.ID, v.Name)
+		}
+		for _, v := range p.Regions {
+			add(RegionCategory, v.ID, v.Name)
+		}
+		for _, v := range p.Stations {
+			add(StationCategory, v.ID, v.Name)
+		}
+		for _, v := range p.Systems {
+			add(SolarSystemCategory, v.ID, v.Name)
+		}
+	}
+	return es, nil
+}
@@ -0,0 +1,40 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+)
+
+const (
+	// RoleDirector grants all other corporation roles.
+	RoleDirector = "Director"
+)
+
+// CorporationRoles obtains the roles the character holds in their
+// corporation, regardless of location.
+func (x *AuthClient) CorporationRoles(ctx context.Context, charID int32) ([]string, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationRolesScope)
+	if err != nil {
+		return nil, err
+	}
+	p, err := x.t.characterRoles(ctx, auth, charID)
+	if err != nil {
+		return nil, err
+	}
+	return p.Roles, nil
+}
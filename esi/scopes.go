@@ -0,0 +1,39 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+// Scope is an ESI scope a character may grant through SSO.
+type Scope string
+
+const (
+	ReadCorporationMembershipScope   Scope = "esi-corporations.read_corporation_membership.v1"
+	ReadFactionWarfareStatsScope     Scope = "esi-corporations.read_fw_stats.v1"
+	ReadCorporationKillmailsScope    Scope = "esi-killmails.read_corporation_killmails.v1"
+	ReadCorporationWalletsScope      Scope = "esi-wallet.read_corporation_wallets.v1"
+	ReadCorporationRolesScope        Scope = "esi-characters.read_corporation_roles.v1"
+	ReadCorporationAssetsScope       Scope = "esi-assets.read_corporation_assets.v1"
+	ReadCorporationIndustryJobsScope Scope = "esi-industry.read_corporation_jobs.v1"
+	ReadCorporationMiningScope       Scope = "esi-industry.read_corporation_mining.v1"
+	ReadCorporationContractsScope    Scope = "esi-contracts.read_corporation_contracts.v1"
+	ReadCorporationContactsScope     Scope = "esi-corporations.read_contacts.v1"
+	ReadAllianceContactsScope        Scope = "esi-alliances.read_contacts.v1"
+	ReadCalendarEventsScope          Scope = "esi-calendar.read_calendar_events.v1"
+	RespondCalendarEventsScope       Scope = "esi-calendar.respond_calendar_events.v1"
+	ReadMailScope                    Scope = "esi-mail.read_mail.v1"
+	SendMailScope                    Scope = "esi-mail.send_mail.v1"
+	OrganizeMailScope                Scope = "esi-mail.organize_mail.v1"
+)
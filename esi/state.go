@@ -18,6 +18,7 @@ package esi
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 )
 
@@ -34,3 +35,21 @@ func Random(n int) (string, error) {
 	}
 	return base64.URLEncoding.EncodeToString(b), nil
 }
+
+// NewPKCE generates a code verifier and its S256 code challenge for the proof
+// key for code exchange flow. The challenge accompanies the authorization
+// request while the verifier is kept secret until exchanging the code.
+func NewPKCE() (verifier, challenge string, err error) {
+	b, err := randb(32)
+	if err != nil {
+		return "", "", err
+	}
+	verifier = base64.RawURLEncoding.EncodeToString(b)
+	return verifier, PKCEChallenge(verifier), nil
+}
+
+// PKCEChallenge computes the S256 code challenge of the code verifier.
+func PKCEChallenge(verifier string) string {
+	h := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
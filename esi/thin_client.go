@@ -23,9 +23,21 @@ import (
 
 	"github.com/cjslep/dharma/esi/client"
 	"github.com/cjslep/dharma/esi/client/alliance"
+	"github.com/cjslep/dharma/esi/client/assets"
+	"github.com/cjslep/dharma/esi/client/calendar"
 	"github.com/cjslep/dharma/esi/client/character"
+	"github.com/cjslep/dharma/esi/client/contacts"
+	"github.com/cjslep/dharma/esi/client/contracts"
 	"github.com/cjslep/dharma/esi/client/corporation"
+	"github.com/cjslep/dharma/esi/client/faction_warfare"
+	"github.com/cjslep/dharma/esi/client/industry"
+	"github.com/cjslep/dharma/esi/client/killmails"
+	"github.com/cjslep/dharma/esi/client/mail"
+	"github.com/cjslep/dharma/esi/client/market"
 	"github.com/cjslep/dharma/esi/client/search"
+	"github.com/cjslep/dharma/esi/client/universe"
+	"github.com/cjslep/dharma/esi/client/wallet"
+	"github.com/go-openapi/runtime"
 	"golang.org/x/text/language"
 )
 
@@ -42,7 +54,7 @@ type ThinClient struct {
 // search is a thin wrapper for ESI Search.
 func (e *ThinClient) search(c context.Context, q string, cats []string, isStrict bool, l language.Tag) (*search.GetSearchOKBody, error) {
 	p := search.NewGetSearchParams()
-	lang := l.String()
+	lang := esiLanguage(l)
 	p.WithTimeout(e.Timeout).
 		WithContext(c).
 		WithHTTPClient(e.Client).
@@ -141,3 +153,568 @@ func (e *ThinClient) allianceIcon(c context.Context, id int32) (*alliance.GetAll
 	}
 	return resp.GetPayload(), nil
 }
+
+// corporationWallets is a thin wrapper for ESI corporation wallets.
+func (e *ThinClient) corporationWallets(c context.Context, auth runtime.ClientAuthInfoWriter, id int32) ([]*wallet.GetCorporationsCorporationIDWalletsOKBodyItems0, error) {
+	p := wallet.NewGetCorporationsCorporationIDWalletsParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithCorporationID(id)
+	resp, err := e.ESIClient.Wallet.GetCorporationsCorporationIDWallets(p, auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// universeNames is a thin wrapper for ESI universe names.
+func (e *ThinClient) universeNames(c context.Context, ids []int32) ([]*universe.PostUniverseNamesOKBodyItems0, error) {
+	p := universe.NewPostUniverseNamesParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithIds(ids)
+	resp, err := e.ESIClient.Universe.PostUniverseNames(p)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// characterAffiliation is a thin wrapper for ESI character affiliation.
+func (e *ThinClient) characterAffiliation(c context.Context, ids []int32) ([]*character.PostCharactersAffiliationOKBodyItems0, error) {
+	p := character.NewPostCharactersAffiliationParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCharacters(ids)
+	resp, err := e.ESIClient.Character.PostCharactersAffiliation(p)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// universeIDs is a thin wrapper for ESI universe ids.
+func (e *ThinClient) universeIDs(c context.Context, names []string, l language.Tag) (*universe.PostUniverseIdsOKBody, error) {
+	p := universe.NewPostUniverseIdsParams()
+	lang := esiLanguage(l)
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithLanguage(&lang).
+		WithNames(names)
+	resp, err := e.ESIClient.Universe.PostUniverseIds(p)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// universeFactions is a thin wrapper for ESI universe factions.
+func (e *ThinClient) universeFactions(c context.Context, lang string) ([]*universe.GetUniverseFactionsOKBodyItems0, error) {
+	p := universe.NewGetUniverseFactionsParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithLanguage(&lang)
+	resp, err := e.ESIClient.Universe.GetUniverseFactions(p)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// universeRaces is a thin wrapper for ESI universe races.
+func (e *ThinClient) universeRaces(c context.Context, lang string) ([]*universe.GetUniverseRacesOKBodyItems0, error) {
+	p := universe.NewGetUniverseRacesParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithLanguage(&lang)
+	resp, err := e.ESIClient.Universe.GetUniverseRaces(p)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// universeBloodlines is a thin wrapper for ESI universe bloodlines.
+func (e *ThinClient) universeBloodlines(c context.Context, lang string) ([]*universe.GetUniverseBloodlinesOKBodyItems0, error) {
+	p := universe.NewGetUniverseBloodlinesParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithLanguage(&lang)
+	resp, err := e.ESIClient.Universe.GetUniverseBloodlines(p)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// universeAncestries is a thin wrapper for ESI universe ancestries.
+func (e *ThinClient) universeAncestries(c context.Context, lang string) ([]*universe.GetUniverseAncestriesOKBodyItems0, error) {
+	p := universe.NewGetUniverseAncestriesParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithLanguage(&lang)
+	resp, err := e.ESIClient.Universe.GetUniverseAncestries(p)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// universeStation is a thin wrapper for ESI universe station.
+func (e *ThinClient) universeStation(c context.Context, id int32) (*universe.GetUniverseStationsStationIDOKBody, error) {
+	p := universe.NewGetUniverseStationsStationIDParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithStationID(id)
+	resp, err := e.ESIClient.Universe.GetUniverseStationsStationID(p)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// corporationMembers is a thin wrapper for ESI corporation members.
+func (e *ThinClient) corporationMembers(c context.Context, auth runtime.ClientAuthInfoWriter, id int32) ([]int32, error) {
+	p := corporation.NewGetCorporationsCorporationIDMembersParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithCorporationID(id)
+	resp, err := e.ESIClient.Corporation.GetCorporationsCorporationIDMembers(p, auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// corporationKillmailsRecent is a thin wrapper for ESI corporation recent
+// killmails, returning one page and the number of pages.
+func (e *ThinClient) corporationKillmailsRecent(c context.Context, auth runtime.ClientAuthInfoWriter, id, page int32) ([]*killmails.GetCorporationsCorporationIDKillmailsRecentOKBodyItems0, int32, error) {
+	p := killmails.NewGetCorporationsCorporationIDKillmailsRecentParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCorporationID(id).
+		WithPage(&page)
+	resp, err := e.ESIClient.Killmails.GetCorporationsCorporationIDKillmailsRecent(p, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.GetPayload(), resp.XPages, nil
+}
+
+// killmail is a thin wrapper for ESI killmail.
+func (e *ThinClient) killmail(c context.Context, id int32, hash string) (*killmails.GetKillmailsKillmailIDKillmailHashOKBody, error) {
+	p := killmails.NewGetKillmailsKillmailIDKillmailHashParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithKillmailID(id).
+		WithKillmailHash(hash)
+	resp, err := e.ESIClient.Killmails.GetKillmailsKillmailIDKillmailHash(p)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// corporationJournal is a thin wrapper for ESI corporation wallet journal,
+// returning one page and the number of pages.
+func (e *ThinClient) corporationJournal(c context.Context, auth runtime.ClientAuthInfoWriter, id, division, page int32) ([]*wallet.GetCorporationsCorporationIDWalletsDivisionJournalOKBodyItems0, int32, error) {
+	p := wallet.NewGetCorporationsCorporationIDWalletsDivisionJournalParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCorporationID(id).
+		WithDivision(division).
+		WithPage(&page)
+	resp, err := e.ESIClient.Wallet.GetCorporationsCorporationIDWalletsDivisionJournal(p, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.GetPayload(), resp.XPages, nil
+}
+
+// corporationTransactions is a thin wrapper for ESI corporation wallet
+// transactions. A nil fromID obtains the most recent transactions.
+func (e *ThinClient) corporationTransactions(c context.Context, auth runtime.ClientAuthInfoWriter, id, division int32, fromID *int64) ([]*wallet.GetCorporationsCorporationIDWalletsDivisionTransactionsOKBodyItems0, error) {
+	p := wallet.NewGetCorporationsCorporationIDWalletsDivisionTransactionsParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCorporationID(id).
+		WithDivision(division).
+		WithFromID(fromID)
+	resp, err := e.ESIClient.Wallet.GetCorporationsCorporationIDWalletsDivisionTransactions(p, auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// corporationFactionWarfareStats is a thin wrapper for ESI corporation faction
+// warfare stats.
+func (e *ThinClient) corporationFactionWarfareStats(c context.Context, auth runtime.ClientAuthInfoWriter, id int32) (*faction_warfare.GetCorporationsCorporationIDFwStatsOKBody, error) {
+	p := faction_warfare.NewGetCorporationsCorporationIDFwStatsParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCorporationID(id)
+	resp, err := e.ESIClient.FactionWarfare.GetCorporationsCorporationIDFwStats(p, auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// characterCalendar is a thin wrapper for ESI character calendar. A nil
+// fromEvent obtains the next upcoming events.
+func (e *ThinClient) characterCalendar(c context.Context, auth runtime.ClientAuthInfoWriter, id int32, fromEvent *int32) ([]*calendar.GetCharactersCharacterIDCalendarOKBodyItems0, error) {
+	p := calendar.NewGetCharactersCharacterIDCalendarParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCharacterID(id).
+		WithFromEvent(fromEvent)
+	resp, err := e.ESIClient.Calendar.GetCharactersCharacterIDCalendar(p, auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// characterCalendarEvent is a thin wrapper for ESI character calendar event.
+func (e *ThinClient) characterCalendarEvent(c context.Context, auth runtime.ClientAuthInfoWriter, id, eventID int32) (*calendar.GetCharactersCharacterIDCalendarEventIDOKBody, error) {
+	p := calendar.NewGetCharactersCharacterIDCalendarEventIDParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCharacterID(id).
+		WithEventID(eventID)
+	resp, err := e.ESIClient.Calendar.GetCharactersCharacterIDCalendarEventID(p, auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// respondCalendarEvent is a thin wrapper for ESI responding to a character
+// calendar event.
+func (e *ThinClient) respondCalendarEvent(c context.Context, auth runtime.ClientAuthInfoWriter, id, eventID int32, response string) error {
+	p := calendar.NewPutCharactersCharacterIDCalendarEventIDParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCharacterID(id).
+		WithEventID(eventID).
+		WithResponse(calendar.PutCharactersCharacterIDCalendarEventIDBody{
+			Response: &response,
+		})
+	_, err := e.ESIClient.Calendar.PutCharactersCharacterIDCalendarEventID(p, auth)
+	return err
+}
+
+// characterMail is a thin wrapper for ESI character mail headers. A nil
+// lastMailID obtains the newest mail.
+func (e *ThinClient) characterMail(c context.Context, auth runtime.ClientAuthInfoWriter, id int32, labels []int32, lastMailID *int32) ([]*mail.GetCharactersCharacterIDMailOKBodyItems0, error) {
+	p := mail.NewGetCharactersCharacterIDMailParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCharacterID(id).
+		WithLabels(labels).
+		WithLastMailID(lastMailID)
+	resp, err := e.ESIClient.Mail.GetCharactersCharacterIDMail(p, auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// characterMailByID is a thin wrapper for ESI character mail contents.
+func (e *ThinClient) characterMailByID(c context.Context, auth runtime.ClientAuthInfoWriter, id, mailID int32) (*mail.GetCharactersCharacterIDMailMailIDOKBody, error) {
+	p := mail.NewGetCharactersCharacterIDMailMailIDParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCharacterID(id).
+		WithMailID(mailID)
+	resp, err := e.ESIClient.Mail.GetCharactersCharacterIDMailMailID(p, auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// updateCharacterMail is a thin wrapper for ESI updating a character's mail
+// labels and read flag.
+func (e *ThinClient) updateCharacterMail(c context.Context, auth runtime.ClientAuthInfoWriter, id, mailID int32, body mail.PutCharactersCharacterIDMailMailIDBody) error {
+	p := mail.NewPutCharactersCharacterIDMailMailIDParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCharacterID(id).
+		WithMailID(mailID).
+		WithContents(body)
+	_, err := e.ESIClient.Mail.PutCharactersCharacterIDMailMailID(p, auth)
+	return err
+}
+
+// sendCharacterMail is a thin wrapper for ESI sending mail as a character.
+func (e *ThinClient) sendCharacterMail(c context.Context, auth runtime.ClientAuthInfoWriter, id int32, body mail.PostCharactersCharacterIDMailBody) (int32, error) {
+	p := mail.NewPostCharactersCharacterIDMailParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCharacterID(id).
+		WithMail(body)
+	resp, err := e.ESIClient.Mail.PostCharactersCharacterIDMail(p, auth)
+	if err != nil {
+		return 0, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// characterMailLabels is a thin wrapper for ESI character mail labels.
+func (e *ThinClient) characterMailLabels(c context.Context, auth runtime.ClientAuthInfoWriter, id int32) (*mail.GetCharactersCharacterIDMailLabelsOKBody, error) {
+	p := mail.NewGetCharactersCharacterIDMailLabelsParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCharacterID(id)
+	resp, err := e.ESIClient.Mail.GetCharactersCharacterIDMailLabels(p, auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// characterMailingLists is a thin wrapper for ESI character mailing lists.
+func (e *ThinClient) characterMailingLists(c context.Context, auth runtime.ClientAuthInfoWriter, id int32) ([]*mail.GetCharactersCharacterIDMailListsOKBodyItems0, error) {
+	p := mail.NewGetCharactersCharacterIDMailListsParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCharacterID(id)
+	resp, err := e.ESIClient.Mail.GetCharactersCharacterIDMailLists(p, auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// characterRoles is a thin wrapper for ESI character corporation roles.
+func (e *ThinClient) characterRoles(c context.Context, auth runtime.ClientAuthInfoWriter, id int32) (*character.GetCharactersCharacterIDRolesOKBody, error) {
+	p := character.NewGetCharactersCharacterIDRolesParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCharacterID(id)
+	resp, err := e.ESIClient.Character.GetCharactersCharacterIDRoles(p, auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// marketsPrices is a thin wrapper for ESI market prices.
+func (e *ThinClient) marketsPrices(c context.Context) ([]*market.GetMarketsPricesOKBodyItems0, error) {
+	p := market.NewGetMarketsPricesParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server)
+	resp, err := e.ESIClient.Market.GetMarketsPrices(p)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// marketsRegionOrders is a thin wrapper for ESI region market orders,
+// returning one page of both buy and sell orders and the number of pages.
+func (e *ThinClient) marketsRegionOrders(c context.Context, regionID, page int32) ([]*market.GetMarketsRegionIDOrdersOKBodyItems0, int32, error) {
+	p := market.NewGetMarketsRegionIDOrdersParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithRegionID(regionID).
+		WithOrderType("all").
+		WithPage(&page)
+	resp, err := e.ESIClient.Market.GetMarketsRegionIDOrders(p)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.GetPayload(), resp.XPages, nil
+}
+
+// corporationAssets is a thin wrapper for ESI corporation assets, returning one
+// page and the number of pages.
+func (e *ThinClient) corporationAssets(c context.Context, auth runtime.ClientAuthInfoWriter, id, page int32) ([]*assets.GetCorporationsCorporationIDAssetsOKBodyItems0, int32, error) {
+	p := assets.NewGetCorporationsCorporationIDAssetsParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCorporationID(id).
+		WithPage(&page)
+	resp, err := e.ESIClient.Assets.GetCorporationsCorporationIDAssets(p, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.GetPayload(), resp.XPages, nil
+}
+
+// corporationAssetNames is a thin wrapper for ESI corporation asset names.
+func (e *ThinClient) corporationAssetNames(c context.Context, auth runtime.ClientAuthInfoWriter, id int32, itemIDs []int64) ([]*assets.PostCorporationsCorporationIDAssetsNamesOKBodyItems0, error) {
+	p := assets.NewPostCorporationsCorporationIDAssetsNamesParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCorporationID(id).
+		WithItemIds(itemIDs)
+	resp, err := e.ESIClient.Assets.PostCorporationsCorporationIDAssetsNames(p, auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+// corporationIndustryJobs is a thin wrapper for ESI corporation industry jobs,
+// returning one page, including completed jobs, and the number of pages.
+func (e *ThinClient) corporationIndustryJobs(c context.Context, auth runtime.ClientAuthInfoWriter, id, page int32) ([]*industry.GetCorporationsCorporationIDIndustryJobsOKBodyItems0, int32, error) {
+	includeCompleted := true
+	p := industry.NewGetCorporationsCorporationIDIndustryJobsParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCorporationID(id).
+		WithIncludeCompleted(&includeCompleted).
+		WithPage(&page)
+	resp, err := e.ESIClient.Industry.GetCorporationsCorporationIDIndustryJobs(p, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.GetPayload(), resp.XPages, nil
+}
+
+// corporationMiningObservers is a thin wrapper for ESI corporation mining
+// observers, returning one page and the number of pages.
+func (e *ThinClient) corporationMiningObservers(c context.Context, auth runtime.ClientAuthInfoWriter, id, page int32) ([]*industry.GetCorporationCorporationIDMiningObserversOKBodyItems0, int32, error) {
+	p := industry.NewGetCorporationCorporationIDMiningObserversParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCorporationID(id).
+		WithPage(&page)
+	resp, err := e.ESIClient.Industry.GetCorporationCorporationIDMiningObservers(p, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.GetPayload(), resp.XPages, nil
+}
+
+// corporationMiningLedger is a thin wrapper for ESI corporation mining
+// observer ledgers, returning one page and the number of pages.
+func (e *ThinClient) corporationMiningLedger(c context.Context, auth runtime.ClientAuthInfoWriter, id int32, observerID int64, page int32) ([]*industry.GetCorporationCorporationIDMiningObserversObserverIDOKBodyItems0, int32, error) {
+	p := industry.NewGetCorporationCorporationIDMiningObserversObserverIDParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCorporationID(id).
+		WithObserverID(observerID).
+		WithPage(&page)
+	resp, err := e.ESIClient.Industry.GetCorporationCorporationIDMiningObserversObserverID(p, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.GetPayload(), resp.XPages, nil
+}
+
+// corporationContacts is a thin wrapper for ESI corporation contacts,
+// returning one page and the number of pages.
+func (e *ThinClient) corporationContacts(c context.Context, auth runtime.ClientAuthInfoWriter, id, page int32) ([]*contacts.GetCorporationsCorporationIDContactsOKBodyItems0, int32, error) {
+	p := contacts.NewGetCorporationsCorporationIDContactsParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCorporationID(id).
+		WithPage(&page)
+	resp, err := e.ESIClient.Contacts.GetCorporationsCorporationIDContacts(p, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.GetPayload(), resp.XPages, nil
+}
+
+// allianceContacts is a thin wrapper for ESI alliance contacts, returning one
+// page and the number of pages.
+func (e *ThinClient) allianceContacts(c context.Context, auth runtime.ClientAuthInfoWriter, id, page int32) ([]*contacts.GetAlliancesAllianceIDContactsOKBodyItems0, int32, error) {
+	p := contacts.NewGetAlliancesAllianceIDContactsParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithAllianceID(id).
+		WithPage(&page)
+	resp, err := e.ESIClient.Contacts.GetAlliancesAllianceIDContacts(p, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.GetPayload(), resp.XPages, nil
+}
+
+// corporationContracts is a thin wrapper for ESI corporation contracts,
+// returning one page and the number of pages.
+func (e *ThinClient) corporationContracts(c context.Context, auth runtime.ClientAuthInfoWriter, id, page int32) ([]*contracts.GetCorporationsCorporationIDContractsOKBodyItems0, int32, error) {
+	p := contracts.NewGetCorporationsCorporationIDContractsParams()
+	p.WithTimeout(e.Timeout).
+		WithContext(c).
+		WithHTTPClient(e.Client).
+		WithDatasource(&server).
+		WithCorporationID(id).
+		WithPage(&page)
+	resp, err := e.ESIClient.Contracts.GetCorporationsCorporationIDContracts(p, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.GetPayload(), resp.XPages, nil
+}
@@ -35,6 +35,8 @@ type Tokens struct {
 	AccessExpires time.Time `json:"access_expires"`
 	CID           int       `json:"character_id"`
 	CName         string    `json:"character_name"`
+	// Scopes is nil for tokens stored before scopes were recorded.
+	Scopes []string `json:"scopes"`
 }
 
 var _ driver.Valuer = &Tokens{}
@@ -53,6 +55,10 @@ func NewTokens(jwt *JWTResponse, c *jwt.Claims) (*Tokens, error) {
 	if !ok {
 		return nil, fmt.Errorf("cannot fetch character name from set: %v", c.Set)
 	}
+	scopes, err := scopesFromClaims(c)
+	if err != nil {
+		return nil, err
+	}
 	// TODO: Determine if other claims need to be processed
 	return &Tokens{
 		Access:        jwt.AccessToken,
@@ -60,9 +66,43 @@ func NewTokens(jwt *JWTResponse, c *jwt.Claims) (*Tokens, error) {
 		AccessExpires: c.Expires.Time(),
 		CID:           cid,
 		CName:         name,
+		Scopes:        scopes,
 	}, nil
 }
 
+// scopesFromClaims obtains the granted scopes, which EVE SSO provides as a
+// string when only one scope is granted and an array otherwise.
+func scopesFromClaims(c *jwt.Claims) ([]string, error) {
+	switch v := c.Set["scp"].(type) {
+	case nil:
+		return []string{}, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		s := make([]string, 0, len(v))
+		for _, i := range v {
+			scope, ok := i.(string)
+			if !ok {
+				return nil, fmt.Errorf("scope in jwt is not a string: %v", i)
+			}
+			s = append(s, scope)
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("cannot fetch scopes from set: %v", c.Set)
+	}
+}
+
+// HasScope determines whether the character granted the scope.
+func (t *Tokens) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *Tokens) Value() (driver.Value, error) {
 	return json.Marshal(t)
 }
@@ -0,0 +1,339 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// universeCache keeps the nearly static universe data in memory for the life
+// of the process. Everything but stations is kept per ESI language.
+type universeCache struct {
+	mu         sync.RWMutex
+	factions   map[string]map[int32]Faction
+	races      map[string]map[int32]Race
+	bloodlines map[string]map[int32]Bloodline
+	ancestries map[string]map[int32]Ancestry
+	stations   map[int32]Station
+}
+
+func newUniverseCache() *universeCache {
+	return &universeCache{
+		factions:   make(map[string]map[int32]Faction),
+		races:      make(map[string]map[int32]Race),
+		bloodlines: make(map[string]map[int32]Bloodline),
+		ancestries: make(map[string]map[int32]Ancestry),
+		stations:   make(map[int32]Station),
+	}
+}
+
+// Faction obtains the faction localized into the language.
+func (x *Client) Faction(ctx context.Context, id int32, l language.Tag) (*Faction, error) {
+	f, err := x.hydrateFaction(ctx, &Faction{ID: id}, esiLanguage(l))
+	if err != nil {
+		return nil, err
+	} else if !f.Hydrated {
+		return nil, errors.Errorf("unknown faction id: %d", id)
+	}
+	return f, nil
+}
+
+// Race obtains the race localized into the language.
+func (x *Client) Race(ctx context.Context, id int32, l language.Tag) (*Race, error) {
+	r, err := x.hydrateRace(ctx, &Race{ID: id}, esiLanguage(l))
+	if err != nil {
+		return nil, err
+	} else if !r.Hydrated {
+		return nil, errors.Errorf("unknown race id: %d", id)
+	}
+	return r, nil
+}
+
+// Bloodline obtains the bloodline localized into the language.
+func (x *Client) Bloodline(ctx context.Context, id int32, l language.Tag) (*Bloodline, error) {
+	b, err := x.hydrateBloodline(ctx, &Bloodline{ID: id}, esiLanguage(l))
+	if err != nil {
+		return nil, err
+	} else if !b.Hydrated {
+		return nil, errors.Errorf("unknown bloodline id: %d", id)
+	}
+	return b, nil
+}
+
+// Ancestry obtains the ancestry localized into the language.
+func (x *Client) Ancestry(ctx context.Context, id int32, l language.Tag) (*Ancestry, error) {
+	a, err := x.hydrateAncestry(ctx, &Ancestry{ID: id}, esiLanguage(l))
+	if err != nil {
+		return nil, err
+	} else if !a.Hydrated {
+		return nil, errors.Errorf("unknown ancestry id: %d", id)
+	}
+	return a, nil
+}
+
+// Station obtains the NPC station. Station names are not localized by ESI.
+func (x *Client) Station(ctx context.Context, id int32) (*Station, error) {
+	return x.hydrateStation(ctx, id)
+}
+
+// hydrateFaction returns a copy of the faction hydrated in the ESI language,
+// or the unhydrated faction if ESI does not know of it.
+func (x *Client) hydrateFaction(ctx context.Context, f *Faction, lang string) (*Faction, error) {
+	x.u.mu.RLock()
+	m, ok := x.u.factions[lang]
+	x.u.mu.RUnlock()
+	if !ok {
+		v, err := x.g.Do(ctx, "factions:"+lang, func(ctx context.Context) (interface{}, error) {
+			p, err := x.t.universeFactions(ctx, lang)
+			if err != nil {
+				return nil, err
+			}
+			m := make(map[int32]Faction, len(p))
+			for _, v := range p {
+				if v.FactionID == nil {
+					continue
+				}
+				h := Faction{
+					ID:            *v.FactionID,
+					Hydrated:      true,
+					SolarSystemID: v.SolarSystemID,
+				}
+				if v.Name != nil {
+					h.Name = *v.Name
+				}
+				if v.Description != nil {
+					h.Description = *v.Description
+				}
+				if v.CorporationID != 0 {
+					h.Corporation = &Corporation{ID: v.CorporationID}
+				}
+				if v.MilitiaCorporationID != 0 {
+					h.MilitiaCorporation = &Corporation{ID: v.MilitiaCorporationID}
+				}
+				m[h.ID] = h
+			}
+			x.u.mu.Lock()
+			x.u.factions[lang] = m
+			x.u.mu.Unlock()
+			return m, nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "error fetching factions")
+		}
+		m = v.(map[int32]Faction)
+	}
+	h, ok := m[f.ID]
+	if !ok {
+		return f, nil
+	}
+	return &h, nil
+}
+
+// hydrateRace returns a copy of the race hydrated in the ESI language, or the
+// unhydrated race if ESI does not know of it.
+func (x *Client) hydrateRace(ctx context.Context, r *Race, lang string) (*Race, error) {
+	x.u.mu.RLock()
+	m, ok := x.u.races[lang]
+	x.u.mu.RUnlock()
+	if !ok {
+		v, err := x.g.Do(ctx, "races:"+lang, func(ctx context.Context) (interface{}, error) {
+			p, err := x.t.universeRaces(ctx, lang)
+			if err != nil {
+				return nil, err
+			}
+			m := make(map[int32]Race, len(p))
+			for _, v := range p {
+				if v.RaceID == nil {
+					continue
+				}
+				h := Race{
+					ID:       *v.RaceID,
+					Hydrated: true,
+				}
+				if v.Name != nil {
+					h.Name = *v.Name
+				}
+				if v.Description != nil {
+					h.Description = *v.Description
+				}
+				m[h.ID] = h
+			}
+			x.u.mu.Lock()
+			x.u.races[lang] = m
+			x.u.mu.Unlock()
+			return m, nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "error fetching races")
+		}
+		m = v.(map[int32]Race)
+	}
+	h, ok := m[r.ID]
+	if !ok {
+		return r, nil
+	}
+	return &h, nil
+}
+
+// hydrateBloodline returns a copy of the bloodline hydrated in the ESI
+// language, or the unhydrated bloodline if ESI does not know of it.
+func (x *Client) hydrateBloodline(ctx context.Context, b *Bloodline, lang string) (*Bloodline, error) {
+	x.u.mu.RLock()
+	m, ok := x.u.bloodlines[lang]
+	x.u.mu.RUnlock()
+	if !ok {
+		v, err := x.g.Do(ctx, "bloodlines:"+lang, func(ctx context.Context) (interface{}, error) {
+			p, err := x.t.universeBloodlines(ctx, lang)
+			if err != nil {
+				return nil, err
+			}
+			m := make(map[int32]Bloodline, len(p))
+			for _, v := range p {
+				if v.BloodlineID == nil {
+					continue
+				}
+				h := Bloodline{
+					ID:       *v.BloodlineID,
+					Hydrated: true,
+				}
+				if v.Name != nil {
+					h.Name = *v.Name
+				}
+				if v.Description != nil {
+					h.Description = *v.Description
+				}
+				if v.RaceID != nil && *v.RaceID != 0 {
+					h.Race = &Race{ID: *v.RaceID}
+				}
+				if v.CorporationID != nil && *v.CorporationID != 0 {
+					h.Corporation = &Corporation{ID: *v.CorporationID}
+				}
+				m[h.ID] = h
+			}
+			x.u.mu.Lock()
+			x.u.bloodlines[lang] = m
+			x.u.mu.Unlock()
+			return m, nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "error fetching bloodlines")
+		}
+		m = v.(map[int32]Bloodline)
+	}
+	h, ok := m[b.ID]
+	if !ok {
+		return b, nil
+	}
+	return &h, nil
+}
+
+// hydrateAncestry returns a copy of the ancestry hydrated in the ESI
+// language, or the unhydrated ancestry if ESI does not know of it.
+func (x *Client) hydrateAncestry(ctx context.Context, a *Ancestry, lang string) (*Ancestry, error) {
+	x.u.mu.RLock()
+	m, ok := x.u.ancestries[lang]
+	x.u.mu.RUnlock()
+	if !ok {
+		v, err := x.g.Do(ctx, "ancestries:"+lang, func(ctx context.Context) (interface{}, error) {
+			p, err := x.t.universeAncestries(ctx, lang)
+			if err != nil {
+				return nil, err
+			}
+			m := make(map[int32]Ancestry, len(p))
+			for _, v := range p {
+				if v.ID == nil {
+					continue
+				}
+				h := Ancestry{
+					ID:               *v.ID,
+					Hydrated:         true,
+					ShortDescription: v.ShortDescription,
+				}
+				if v.Name != nil {
+					h.Name = *v.Name
+				}
+				if v.Description != nil {
+					h.Description = *v.Description
+				}
+				if v.BloodlineID != nil && *v.BloodlineID != 0 {
+					h.Bloodline = &Bloodline{ID: *v.BloodlineID}
+				}
+				m[h.ID] = h
+			}
+			x.u.mu.Lock()
+			x.u.ancestries[lang] = m
+			x.u.mu.Unlock()
+			return m, nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "error fetching ancestries")
+		}
+		m = v.(map[int32]Ancestry)
+	}
+	h, ok := m[a.ID]
+	if !ok {
+		return a, nil
+	}
+	return &h, nil
+}
+
+// hydrateStation fetches the station, unless it was previously fetched.
+func (x *Client) hydrateStation(ctx context.Context, id int32) (*Station, error) {
+	x.u.mu.RLock()
+	h, ok := x.u.stations[id]
+	x.u.mu.RUnlock()
+	if ok {
+		return &h, nil
+	}
+	v, err := x.g.Do(ctx, fmt.Sprintf("station:%d", id), func(ctx context.Context) (interface{}, error) {
+		p, err := x.t.universeStation(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		h := Station{
+			ID:       id,
+			Hydrated: true,
+			Services: p.Services,
+		}
+		if p.Name != nil {
+			h.Name = *p.Name
+		}
+		if p.SystemID != nil {
+			h.SystemID = *p.SystemID
+		}
+		if p.Owner != 0 {
+			h.Owner = &Corporation{ID: p.Owner}
+		}
+		if p.RaceID != 0 {
+			h.Race = &Race{ID: p.RaceID}
+		}
+		x.u.mu.Lock()
+		x.u.stations[id] = h
+		x.u.mu.Unlock()
+		return h, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	h = v.(Station)
+	return &h, nil
+}
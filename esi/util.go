@@ -24,8 +24,15 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+)
 
-	"github.com/cjslep/dharma/internal/features"
+const (
+	// DefaultSSOHost is where EVE Online's single sign on is served.
+	DefaultSSOHost   = "login.eveonline.com"
+	ssoAuthorizePath = "/v2/oauth/authorize/"
+	ssoTokenPath     = "/v2/oauth/token"
+	ssoKeysPath      = "/oauth/jwks"
+	ssoRevokePath    = "/v2/oauth/revoke"
 )
 
 type OAuth2Client struct {
@@ -33,13 +40,62 @@ type OAuth2Client struct {
 	ClientID    string
 	Secret      string
 	Client      *http.Client
+	// SSOHost is the host serving single sign on, which is DefaultSSOHost
+	// if empty.
+	SSOHost string
+	// PKCE uses the proof key for code exchange flow, identifying the
+	// application by its ClientID alone so that the Secret is not needed.
+	PKCE bool
+}
+
+func (o *OAuth2Client) ssoHost() string {
+	if len(o.SSOHost) == 0 {
+		return DefaultSSOHost
+	}
+	return o.SSOHost
 }
 
-func (o *OAuth2Client) GetURL(state string, scopes []features.Scope) *url.URL {
+func (o *OAuth2Client) ssoURL(path string) string {
 	u := &url.URL{
 		Scheme: "https",
-		Host:   "login.eveonline.com",
-		Path:   "/v2/oauth/authorize/",
+		Host:   o.ssoHost(),
+		Path:   path,
+	}
+	return u.String()
+}
+
+// newSSORequest creates a form POST to the single sign on that identifies the
+// application with either its secret or, for PKCE, its client id.
+func (o *OAuth2Client) newSSORequest(path string, data url.Values) (*http.Request, error) {
+	if o.PKCE {
+		data.Add("client_id", o.ClientID)
+	}
+	req, err := http.NewRequest(
+		"POST",
+		o.ssoURL(path),
+		strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Host = o.ssoHost()
+	req.Header = map[string][]string{
+		"Content-Type": {"application/x-www-form-urlencoded"},
+	}
+	if !o.PKCE {
+		rawAuth := fmt.Sprintf("%s:%s", o.ClientID, o.Secret)
+		auth := base64.URLEncoding.EncodeToString([]byte(rawAuth))
+		req.Header.Set("Authorization", fmt.Sprintf("Basic %s", auth))
+	}
+	return req, nil
+}
+
+// GetURL builds the authorization URL to send the user to. The challenge is
+// the PKCE code challenge, and is ignored unless the PKCE flow is used.
+func (o *OAuth2Client) GetURL(state, challenge string, scopes []Scope) *url.URL {
+	u := &url.URL{
+		Scheme: "https",
+		Host:   o.ssoHost(),
+		Path:   ssoAuthorizePath,
 	}
 	s := make([]string, len(scopes))
 	for i := range scopes {
@@ -51,29 +107,28 @@ func (o *OAuth2Client) GetURL(state string, scopes []features.Scope) *url.URL {
 	v.Add("client_id", o.ClientID)
 	v.Add("scope", strings.Join(s, ","))
 	v.Add("state", state)
+	if o.PKCE {
+		v.Add("code_challenge", challenge)
+		v.Add("code_challenge_method", "S256")
+	}
 	u.RawQuery = v.Encode()
 	return u
 }
 
-func (o *OAuth2Client) GetAuthorization(code string) (*JWTResponse, error) {
+// GetAuthorization exchanges the authorization code for tokens. The verifier is
+// the PKCE code verifier, and is ignored unless the PKCE flow is used.
+func (o *OAuth2Client) GetAuthorization(code, verifier string) (*JWTResponse, error) {
 	// Issue request
 	data := url.Values{}
 	data.Add("grant_type", "authorization_code")
 	data.Add("code", code)
-	req, err := http.NewRequest(
-		"POST",
-		"https://login.eveonline.com/v2/oauth/token",
-		strings.NewReader(data.Encode()))
+	if o.PKCE {
+		data.Add("code_verifier", verifier)
+	}
+	req, err := o.newSSORequest(ssoTokenPath, data)
 	if err != nil {
 		return nil, err
 	}
-	req.Host = "login.eveonline.com"
-	rawAuth := fmt.Sprintf("%s:%s", o.ClientID, o.Secret)
-	auth := base64.URLEncoding.EncodeToString([]byte(rawAuth))
-	req.Header = map[string][]string{
-		"Content-Type":  {"application/x-www-form-urlencoded"},
-		"Authorization": {fmt.Sprintf("Basic %s", auth)},
-	}
 	resp, err := o.Client.Do(req)
 	if err != nil {
 		return nil, err
@@ -97,20 +152,10 @@ func (o *OAuth2Client) GetRefresh(refresh string) (*JWTResponse, error) {
 	data := url.Values{}
 	data.Add("grant_type", "refresh_token")
 	data.Add("refresh_token", refresh)
-	req, err := http.NewRequest(
-		"POST",
-		"https://login.eveonline.com/v2/oauth/token",
-		strings.NewReader(data.Encode()))
+	req, err := o.newSSORequest(ssoTokenPath, data)
 	if err != nil {
 		return nil, err
 	}
-	req.Host = "login.eveonline.com"
-	rawAuth := fmt.Sprintf("%s:%s", o.ClientID, o.Secret)
-	auth := base64.URLEncoding.EncodeToString([]byte(rawAuth))
-	req.Header = map[string][]string{
-		"Content-Type":  {"application/x-www-form-urlencoded"},
-		"Authorization": {fmt.Sprintf("Basic %s", auth)},
-	}
 	resp, err := o.Client.Do(req)
 	if err != nil {
 		return nil, err
@@ -128,3 +173,49 @@ func (o *OAuth2Client) GetRefresh(refresh string) (*JWTResponse, error) {
 	}
 	return jwt, nil
 }
+
+// Revoke invalidates the refresh token at the single sign on, so that it may
+// no longer be used to obtain access tokens.
+func (o *OAuth2Client) Revoke(refresh string) error {
+	// Issue request
+	data := url.Values{}
+	data.Add("token_type_hint", "refresh_token")
+	data.Add("token", refresh)
+	req, err := o.newSSORequest(ssoRevokePath, data)
+	if err != nil {
+		return err
+	}
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Process response
+	if resp.StatusCode != http.StatusOK {
+		respb, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("could not revoke refresh token: status %d: %s", resp.StatusCode, respb)
+	}
+	return nil
+}
+
+func deref32(i *int32) int32 {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+func deref64(i *int64) int64 {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,172 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package esi
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// NWalletDivisions is the number of wallet divisions every corporation
+	// has, numbered from 1.
+	NWalletDivisions = 7
+)
+
+type JournalEntry struct {
+	ID            int64
+	Date          time.Time
+	RefType       string
+	Amount        float64
+	Balance       float64
+	FirstPartyID  int32
+	SecondPartyID int32
+	ContextID     int64
+	ContextIDType string
+	Description   string
+	Reason        string
+	Tax           float64
+	TaxReceiverID int32
+}
+
+type Transaction struct {
+	ID           int64
+	Date         time.Time
+	TypeID       int32
+	Quantity     int32
+	UnitPrice    float64
+	ClientID     int32
+	LocationID   int64
+	IsBuy        bool
+	JournalRefID int64
+}
+
+// CorporationJournal obtains the entries in the division's wallet journal
+// with an ID greater than afterID, as seen by the character.
+//
+// Pages are fetched newest first, and stop as soon as an entry at or before
+// afterID is seen, so that repeated calls only fetch what is new.
+func (x *AuthClient) CorporationJournal(ctx context.Context, charID, corpID, division int32, afterID int64) ([]JournalEntry, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationWalletsScope)
+	if err != nil {
+		return nil, err
+	}
+	var js []JournalEntry
+	for page, pages := int32(1), int32(1); page <= pages; page++ {
+		p, n, err := x.t.corporationJournal(ctx, auth, corpID, division, page)
+		if err != nil {
+			return nil, err
+		}
+		pages = n
+		seenOld := false
+		for _, e := range p {
+			if e == nil || e.ID == nil {
+				continue
+			} else if *e.ID <= afterID {
+				seenOld = true
+				continue
+			}
+			j := JournalEntry{
+				ID:            *e.ID,
+				Amount:        e.Amount,
+				Balance:       e.Balance,
+				FirstPartyID:  e.FirstPartyID,
+				SecondPartyID: e.SecondPartyID,
+				ContextID:     e.ContextID,
+				ContextIDType: e.ContextIDType,
+				Reason:        e.Reason,
+				Tax:           e.Tax,
+				TaxReceiverID: e.TaxReceiverID,
+			}
+			if e.Date != nil {
+				j.Date = time.Time(*e.Date)
+			}
+			if e.RefType != nil {
+				j.RefType = *e.RefType
+			}
+			if e.Description != nil {
+				j.Description = *e.Description
+			}
+			js = append(js, j)
+		}
+		if seenOld {
+			break
+		}
+	}
+	return js, nil
+}
+
+// CorporationTransactions obtains the transactions in the division's wallet
+// with an ID greater than afterID, as seen by the character.
+//
+// ESI returns the most recent transactions first, so older ones are walked
+// back to with from_id until one at or before afterID is seen.
+func (x *AuthClient) CorporationTransactions(ctx context.Context, charID, corpID, division int32, afterID int64) ([]Transaction, error) {
+	auth, err := x.auth(ctx, charID, ReadCorporationWalletsScope)
+	if err != nil {
+		return nil, err
+	}
+	var ts []Transaction
+	var fromID *int64
+	for {
+		p, err := x.t.corporationTransactions(ctx, auth, corpID, division, fromID)
+		if err != nil {
+			return nil, err
+		}
+		seenOld := false
+		var minID int64
+		for _, t := range p {
+			if t == nil || t.TransactionID == nil {
+				continue
+			}
+			id := *t.TransactionID
+			if fromID != nil && id >= *fromID {
+				// Already seen on the previous page
+				continue
+			} else if id <= afterID {
+				seenOld = true
+				continue
+			}
+			if minID == 0 || id < minID {
+				minID = id
+			}
+			tr := Transaction{
+				ID:           id,
+				TypeID:       deref32(t.TypeID),
+				Quantity:     deref32(t.Quantity),
+				ClientID:     deref32(t.ClientID),
+				JournalRefID: deref64(t.JournalRefID),
+				LocationID:   deref64(t.LocationID),
+			}
+			if t.Date != nil {
+				tr.Date = time.Time(*t.Date)
+			}
+			if t.UnitPrice != nil {
+				tr.UnitPrice = *t.UnitPrice
+			}
+			if t.IsBuy != nil {
+				tr.IsBuy = *t.IsBuy
+			}
+			ts = append(ts, tr)
+		}
+		if seenOld || minID == 0 {
+			break
+		}
+		fromID = &minID
+	}
+	return ts, nil
+}
@@ -26,8 +26,16 @@ import (
 	"github.com/cjslep/dharma/esi/client"
 	"github.com/cjslep/dharma/internal/api"
 	"github.com/cjslep/dharma/internal/api/account"
+	"github.com/cjslep/dharma/internal/api/calendar"
+	"github.com/cjslep/dharma/internal/api/corp"
 	"github.com/cjslep/dharma/internal/api/esiauth"
+	"github.com/cjslep/dharma/internal/api/evemail"
 	"github.com/cjslep/dharma/internal/api/forum"
+	"github.com/cjslep/dharma/internal/api/killboard"
+	"github.com/cjslep/dharma/internal/api/kos"
+	"github.com/cjslep/dharma/internal/api/local"
+	"github.com/cjslep/dharma/internal/api/logistics"
+	"github.com/cjslep/dharma/internal/api/market"
 	"github.com/cjslep/dharma/internal/api/media"
 	"github.com/cjslep/dharma/internal/api/site"
 	"github.com/cjslep/dharma/internal/async"
@@ -43,8 +51,10 @@ import (
 	"github.com/go-fed/activity/pub"
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/go-fed/apcore/app"
+	"github.com/go-fed/apcore/framework"
+	fdb "github.com/go-fed/apcore/framework/db"
+	apservices "github.com/go-fed/apcore/services"
 	"github.com/go-fed/apcore/util"
-	"github.com/gregjones/httpcache"
 	"github.com/mholt/binding"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/pelletier/go-toml/v2"
@@ -58,22 +68,26 @@ var _ app.S2SApplication = new(FederatedApp)
 
 type FederatedApp struct {
 	// At constructor time
-	b        *i18n.Bundle
-	bg       context.Context
-	software app.Software
-	apiQueue *async.Queue
-	fedQueue *async.Queue
-	features *features.Engine
+	b          *i18n.Bundle
+	bg         context.Context
+	software   app.Software
+	apiQueue   *async.Queue
+	fedQueue   *async.Queue
+	features   *features.Engine
+	tokenLocks *services.TokenLocks
 
 	// At config-setting time
-	debug  bool
-	schema string
-	apc    app.APCoreConfig
-	config *config.Config
-	l      *zerolog.Logger
-	oac    *esi.OAuth2Client
-	r      *render.Renderer
-	esi    *esi.Client
+	debug      bool
+	schema     string
+	apc        app.APCoreConfig
+	config     *config.Config
+	l          *zerolog.Logger
+	oac        *esi.OAuth2Client
+	r          *render.Renderer
+	esi        *esi.Client
+	esiCache   *esi.Cache
+	esiLimiter *esi.ErrorLimiter
+	marketHubs []services.MarketHub
 
 	// At build routes time
 	s  *services.State
@@ -94,23 +108,25 @@ func New(bg context.Context, software app.Software) (*FederatedApp, error) {
 	}
 
 	return &FederatedApp{
-		b:        b,
-		software: software,
-		bg:       bg,
-		apiQueue: async.NewQueue(bg),
-		fedQueue: async.NewQueue(bg),
-		features: features.New(b),
+		b:          b,
+		software:   software,
+		bg:         bg,
+		apiQueue:   async.NewQueue(bg),
+		fedQueue:   async.NewQueue(bg),
+		features:   features.New(b),
+		tokenLocks: &services.TokenLocks{},
 	}, nil
 }
 
 func (a *FederatedApp) apiContext() *api.Context {
-	return &api.Context{
+	ctx := &api.Context{
 		APIQueue:              a.apiQueue,
 		FedQueue:              a.fedQueue,
 		OAC:                   a.oac,
 		L:                     a.l,
-		ESI:                   &services.ESI{a.db, a.oac, a.l, a.esi, time.Hour * time.Duration(a.config.TokenRefreshPeriodicCheck), time.Hour * time.Duration(a.config.EvePublicKeyPeriodicFetch)},
+		ESI:                   &services.ESI{a.db, a.oac, a.l, a.esi, time.Hour * time.Duration(a.config.TokenRefreshPeriodicCheck), time.Hour * time.Duration(a.config.EvePublicKeyPeriodicFetch), time.Hour * time.Duration(a.config.ESICachePrunePeriodicCheck), time.Hour * time.Duration(a.config.ESICacheRetention), a.esiLimiter, a.tokenLocks},
 		Media:                 &services.Media{a.db, a.esi, time.Hour * time.Duration(a.config.EveCachedMediaDefaultExpiryDuration)},
+		Names:                 &services.Names{a.db, a.esi, time.Hour * time.Duration(a.config.EveEntityNameExpiry), time.Minute * time.Duration(a.config.EveUnknownNameExpiry)},
 		Tags:                  &services.Tags{a.db},
 		Posts:                 &services.Posts{a.db, a.f, a.fedQueue},
 		Threads:               &services.Threads{a.db},
@@ -121,6 +137,23 @@ func (a *FederatedApp) apiContext() *api.Context {
 		MustRender:            a.mustRender,
 		SupportedLanguageTags: a.r.LanguageTags,
 	}
+	ctx.Roster = &services.Roster{a.db, ctx.ESI, ctx.Names, a.l, time.Hour * time.Duration(a.config.RosterSyncPeriodicCheck), 24 * time.Hour * time.Duration(a.config.RosterShowLeftDays)}
+	ctx.Killboard = &services.Killboard{a.db, ctx.ESI, ctx.Names, a.l, time.Minute * time.Duration(a.config.KillmailPeriodicPoll), a.config.NKillmails}
+	ctx.Finance = &services.Finance{a.db, ctx.ESI, ctx.Names, a.l, time.Hour * time.Duration(a.config.WalletSyncPeriodicCheck), a.config.FinanceTaxMonths}
+	ctx.FactionWarfare = &services.FactionWarfare{a.db, ctx.ESI, a.l, time.Hour * time.Duration(a.config.FactionWarfarePeriodicSnapshot), a.config.FactionWarfareHistoryDays}
+	ctx.Calendar = &services.Calendar{a.db, ctx.ESI, a.l, time.Hour * time.Duration(a.config.CalendarSyncPeriodicCheck)}
+	ctx.Mail = &services.Mail{ctx.ESI, ctx.Names}
+	ctx.Roles = &services.Roles{a.db, ctx.ESI, a.l, time.Hour * time.Duration(a.config.RolesSyncPeriodicCheck)}
+	ctx.SDE = &services.SDE{a.db, a.l, a.config.SDEDirectory}
+	ctx.Market = &services.Market{a.db, ctx.ESI, ctx.SDE, a.l, time.Minute * time.Duration(a.config.MarketSyncPeriodicCheck), a.marketHubs}
+	ctx.Industry = &services.Industry{a.db, ctx.ESI, ctx.SDE, ctx.Names, a.b, a.l, time.Minute * time.Duration(a.config.IndustrySyncPeriodicCheck), 24 * time.Hour * time.Duration(a.config.IndustryShowDeliveredDays), a.config.IndustryNotifyReady, a.config.IndustryNotifyMaxMails}
+	ctx.Mining = &services.Mining{a.db, ctx.ESI, ctx.SDE, ctx.Market, ctx.Names, a.l, time.Hour * time.Duration(a.config.MiningSyncPeriodicCheck), float64(a.config.MiningTaxPercent)}
+	ctx.Logistics = &services.Logistics{a.db, ctx.ESI, ctx.Names, a.l, time.Minute * time.Duration(a.config.LogisticsSyncPeriodicCheck), 24 * time.Hour * time.Duration(a.config.LogisticsShowFinishedDays)}
+	ctx.KOS = &services.KOS{a.db, ctx.ESI, ctx.Names, a.l}
+	ctx.Standings = &services.Standings{a.db, ctx.ESI, a.l, time.Hour * time.Duration(a.config.StandingsSyncPeriodicCheck)}
+	ctx.Local = &services.Local{a.db, ctx.ESI, ctx.Names, a.l, time.Hour * time.Duration(a.config.LocalScanExpiryHours)}
+	ctx.Assets = &services.Assets{a.db, ctx.ESI, ctx.SDE, ctx.Names, a.l, time.Hour * time.Duration(a.config.AssetSyncPeriodicCheck), 24 * time.Hour * time.Duration(a.config.AssetSnapshotRetentionDays)}
+	return ctx
 }
 
 func (a *FederatedApp) mustRender(v *render.View) {
@@ -137,6 +170,22 @@ func (a *FederatedApp) OnCreateAdminUser(ctx context.Context, userID string, d a
 	return services.InitAsCommandLineAdminUser(ctx, db.New(d, apc.Schema()), userID)
 }
 
+// ImportSDE imports the Static Data Export unzipped in dharma_sde_directory
+// into the database of the configuration file, outside of the server.
+func (a *FederatedApp) ImportSDE(ctx context.Context, configFile string, debug bool) error {
+	apc, err := framework.LoadConfigFile(configFile, a, debug)
+	if err != nil {
+		return err
+	}
+	sqldb, _, err := fdb.NewDB(apc)
+	if err != nil {
+		return err
+	}
+	defer sqldb.Close()
+	s := &services.SDE{db.New(&apservices.Any{sqldb}, a.schema), a.l, a.config.SDEDirectory}
+	return s.Import(ctx)
+}
+
 func (a *FederatedApp) Start() error {
 	if err := a.apiQueue.Start(); err != nil {
 		return err
@@ -150,6 +199,19 @@ func (a *FederatedApp) Start() error {
 	ctx := a.apiContext()
 	ctx.ESI.GoPeriodicallyRefreshAllTokens(a.apiQueue.Messenger())
 	ctx.ESI.GoPeriodicallyFetchEvePublicKeys(a.apiQueue.Messenger())
+	ctx.ESI.GoPeriodicallyPruneESICache(a.apiQueue.Messenger())
+	ctx.Roster.GoPeriodicallySyncRoster(a.apiQueue.Messenger())
+	ctx.Killboard.GoPeriodicallyPollKillmails(a.apiQueue.Messenger())
+	ctx.Finance.GoPeriodicallySyncWallets(a.apiQueue.Messenger())
+	ctx.FactionWarfare.GoPeriodicallySnapshotStats(a.apiQueue.Messenger())
+	ctx.Calendar.GoPeriodicallySyncCalendars(a.apiQueue.Messenger())
+	ctx.Roles.GoPeriodicallySyncRoles(a.apiQueue.Messenger())
+	ctx.Market.GoPeriodicallySyncMarket(a.apiQueue.Messenger())
+	ctx.Assets.GoPeriodicallySnapshotAssets(a.apiQueue.Messenger())
+	ctx.Industry.GoPeriodicallySyncIndustryJobs(a.apiQueue.Messenger())
+	ctx.Mining.GoPeriodicallySyncMiningLedgers(a.apiQueue.Messenger())
+	ctx.Logistics.GoPeriodicallyMatchContracts(a.apiQueue.Messenger())
+	ctx.Standings.GoPeriodicallySyncStandings(a.apiQueue.Messenger())
 	return a.startupErr
 }
 
@@ -162,6 +224,7 @@ func (a *FederatedApp) Stop() error {
 
 func (a *FederatedApp) NewConfiguration() interface{} {
 	return &config.Config{
+		SSOUsePKCE:                          false,
 		ESITimeout:                          60,
 		EnableConsoleLogging:                false,
 		LogDir:                              "./",
@@ -173,10 +236,41 @@ func (a *FederatedApp) NewConfiguration() interface{} {
 		EvePublicKeyPeriodicFetch:           8,
 		EveCachedMediaDefaultExpiryDuration: 24,
 		MediaUploadMaxSizeMB:                10,
+		ESICacheRetention:                   168,
+		ESICachePrunePeriodicCheck:          24,
+		ESIErrorLimitThreshold:              20,
+		ESIErrorLimitMaxWait:                10,
+		EveEntityNameExpiry:                 72,
+		EveUnknownNameExpiry:                60,
+		ESIMaxConcurrency:                   10,
+		RosterSyncPeriodicCheck:             1,
+		RosterShowLeftDays:                  30,
+		KillmailPeriodicPoll:                15,
+		WalletSyncPeriodicCheck:             1,
+		FinanceTaxMonths:                    6,
+		FactionWarfarePeriodicSnapshot:      6,
+		FactionWarfareHistoryDays:           90,
+		CalendarSyncPeriodicCheck:           1,
+		RolesSyncPeriodicCheck:              1,
+		MarketHubs:                          "10000002:60003760",
+		MarketSyncPeriodicCheck:             60,
+		AssetSyncPeriodicCheck:              6,
+		AssetSnapshotRetentionDays:          2,
+		IndustrySyncPeriodicCheck:           15,
+		IndustryShowDeliveredDays:           7,
+		IndustryNotifyReady:                 false,
+		IndustryNotifyMaxMails:              4,
+		MiningSyncPeriodicCheck:             1,
+		MiningTaxPercent:                    10,
+		LogisticsSyncPeriodicCheck:          15,
+		LogisticsShowFinishedDays:           7,
+		StandingsSyncPeriodicCheck:          1,
+		LocalScanExpiryHours:                24,
 		NPreview:                            3,
 		LenPreview:                          80,
 		MaxHTMLDepth:                        255,
 		NListThreads:                        25,
+		NKillmails:                          50,
 		MailerEncryption:                    "starttls",
 		MailerAuthentication:                "none",
 		MailerKeepAlive:                     false,
@@ -194,21 +288,32 @@ func (a *FederatedApp) SetConfiguration(i interface{}, apc app.APCoreConfig, deb
 	a.apc = apc
 	a.schema = apc.Schema()
 	a.config = c
-	tp := httpcache.NewMemoryCacheTransport()
-	h := tp.Client()
+	a.l = log.Logger(debug || c.EnableConsoleLogging, c.LogDir, c.LogFile, c.NLogFiles, c.MaxMBSizeLogFiles, c.MaxDayAgeLogFiles)
+	a.esiLimiter = &esi.ErrorLimiter{
+		Transport: http.DefaultTransport,
+		Threshold: c.ESIErrorLimitThreshold,
+		MaxWait:   time.Second * time.Duration(c.ESIErrorLimitMaxWait),
+		L:         a.l,
+	}
+	var err error
+	if a.marketHubs, err = services.ParseMarketHubs(c.MarketHubs); err != nil {
+		return err
+	}
+	a.esiCache = esi.NewCache(a.bg)
+	h := &http.Client{Transport: a.esiCache.Transport(a.esiLimiter)}
 	a.oac = &esi.OAuth2Client{
 		RedirectURI: "https://" + apc.Host() + esiauth.Callback,
 		ClientID:    c.ClientID,
 		Secret:      c.APIKey,
 		Client:      h,
+		PKCE:        c.SSOUsePKCE,
 	}
 	a.esi = esi.New(&esi.ThinClient{
 		ESIClient: client.Default,
 		Timeout:   time.Second * time.Duration(a.config.ESITimeout),
 		Client:    h,
-	})
+	}, c.ESIMaxConcurrency)
 	a.r, a.startupErr = render.New(c, debug, "/static", a.b)
-	a.l = log.Logger(debug || c.EnableConsoleLogging, c.LogDir, c.LogFile, c.NLogFiles, c.MaxMBSizeLogFiles, c.MaxDayAgeLogFiles)
 	binding.MaxMemory = 1024 * 1024 * int64(c.MediaUploadMaxSizeMB)
 	return nil
 }
@@ -343,6 +448,9 @@ func (a *FederatedApp) GetUserWebHandlerFunc(f app.Framework) (app.VocabHandlerF
 
 func (a *FederatedApp) BuildRoutes(ar app.Router, d app.Database, f app.Framework) error {
 	a.db = db.New(d, a.schema)
+	a.esiCache.SetStore(a.db, func(err error) {
+		a.l.Error().Stack().Err(err).Msg("could not use persistent esi cache")
+	})
 	var err error
 	if a.s, err = services.NewState(util.Context{a.bg}, a.db, a.esi); err != nil {
 		return err
@@ -353,6 +461,14 @@ func (a *FederatedApp) BuildRoutes(ar app.Router, d app.Database, f app.Framewor
 	r := []api.Router{
 		&forum.Forum{ctx, data.AllTags, a.config.NPreview, a.config.LenPreview, a.config.MaxHTMLDepth, a.config.NListThreads},
 		&site.Site{ctx},
+		&corp.Corp{ctx},
+		&killboard.Killboard{ctx},
+		&calendar.Calendar{ctx},
+		&evemail.Mail{ctx},
+		&market.Market{ctx},
+		&logistics.Logistics{ctx},
+		&kos.KOS{ctx},
+		&local.Local{ctx},
 		&account.Account{ctx},
 		&esiauth.ESIAuth{ctx},
 		&media.Media{ctx, int64(a.config.MediaUploadMaxSizeMB) * 1024 * 1024},
@@ -61,4 +61,7 @@ func (a *Account) Route(r app.Router) {
 	r.NewRoute().Methods("POST").WebOnlyHandler(
 		"/account/characters",
 		api.MustHaveSessionAndLanguageCode(a.C, a.postCharacters))
+	r.NewRoute().Methods("POST").WebOnlyHandler(
+		"/account/characters/unlink",
+		api.MustHaveSessionAndLanguageCode(a.C, a.postUnlinkCharacter))
 }
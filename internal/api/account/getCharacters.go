@@ -35,7 +35,11 @@ func (a *Account) getCharacters(w http.ResponseWriter, r *http.Request, k app.Se
 		return
 	}
 
-	chars, err := a.C.ESI.GetCharactersForUser(util.Context{r.Context()}, userID)
+	lang := language.English
+	if len(langs) > 0 {
+		lang = langs[0]
+	}
+	chars, err := a.C.ESI.GetCharactersForUser(util.Context{r.Context()}, userID, lang)
 	if err != nil {
 		a.C.MustRenderError(w, r, errors.Wrap(err, "error getting characters for session"), langs...)
 		return
@@ -50,6 +54,7 @@ func (a *Account) getCharacters(w http.ResponseWriter, r *http.Request, k app.Se
 		map[string]interface{}{
 			"characters": chars,
 			"selectedID": sessions.GetCharacterSelected(k),
+			"err":        r.URL.Query().Get("err"),
 		},
 		langs...)
 	a.C.MustRender(v)
@@ -0,0 +1,92 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/api/paths"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/services"
+	"github.com/cjslep/dharma/internal/sessions"
+	"github.com/cjslep/dharma/internal/util"
+	"github.com/go-fed/apcore/app"
+	"github.com/mholt/binding"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+type characterUnlinkRequest struct {
+	CharacterID int32
+}
+
+func (c *characterUnlinkRequest) FieldMap(req *http.Request) binding.FieldMap {
+	return binding.FieldMap{
+		&c.CharacterID: binding.Field{
+			Form:     "character_id",
+			Required: true,
+		},
+	}
+}
+
+// postUnlinkCharacter revokes the access a character granted and removes it
+// from the user's account.
+func (a *Account) postUnlinkCharacter(w http.ResponseWriter, r *http.Request, k app.Session, langs []language.Tag) {
+	rc := api.From(r.Context())
+	cur := &characterUnlinkRequest{}
+	errs := binding.Bind(r, cur)
+	if errs.Len() > 0 {
+		v := render.NewBadRequestView(w, rc, langs...)
+		a.C.MustRender(v)
+		return
+	}
+
+	userID, err := k.UserID()
+	if err != nil {
+		v := render.NewBadRequestView(w, rc, langs...)
+		a.C.MustRender(v)
+		return
+	}
+
+	err = a.C.ESI.UnlinkCharacter(a.C.F.Context(r), userID, cur.CharacterID)
+	if err == services.NotUsersCharacterError {
+		v := render.NewBadRequestView(w, rc, langs...)
+		a.C.MustRender(v)
+		return
+	} else if err == services.AuthoritativeCharacterError {
+		u := paths.GetCharacterSelection(util.GetPreferredLanguage(langs))
+		u.RawQuery = url.Values{"err": []string{"authoritative"}}.Encode()
+		http.Redirect(w, r, u.String(), http.StatusFound)
+		return
+	} else if err != nil {
+		a.C.MustRenderError(w, r, errors.Wrap(err, "could not unlink character"), langs...)
+		return
+	}
+
+	if sessions.GetCharacterSelected(k) == cur.CharacterID {
+		sessions.SetCharacterSelected(k, 0)
+		if err := k.Save(r, w); err != nil {
+			a.C.MustRenderError(w, r, errors.Wrap(err, "could not save session"), langs...)
+			return
+		}
+	}
+
+	u := paths.GetCharacterSelection(util.GetPreferredLanguage(langs))
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
@@ -251,6 +251,64 @@ func enforceLoggedInAsAdmin(ctx *Context) mux.MiddlewareFunc {
 					langs = []language.Tag{language.English}
 				}
 				ctx.MustRender(render.NewNotFoundView(w, rc, langs...))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// enforceKOSEditor ensures that the request is for a logged-in user who may
+// edit the kill on sight list.
+func enforceKOSEditor(ctx *Context) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rc := From(r.Context())
+			editor, err := rc.IsKOSEditor()
+			if err != nil {
+				ctx.MustRenderError(w, r, err)
+				return
+			}
+			if !editor {
+				langs, err := rc.LanguageTags()
+				if err != nil {
+					langs = []language.Tag{language.English}
+				}
+				ctx.MustRender(render.NewNotFoundView(w, rc, langs...))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// enforceCharacterIsDirector ensures that the request is for a user whose
+// selected character is the CEO or a director of the managed corporation in
+// game.
+func enforceCharacterIsDirector(ctx *Context) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rc := From(r.Context())
+			k, err := rc.Session()
+			if err != nil {
+				ctx.MustRenderError(w, r, errors.New("could not obtain session for enforcing corporation roles"))
+				return
+			}
+			director := false
+			if charID := sessions.GetCharacterSelected(k); charID != 0 {
+				director, err = ctx.Roles.IsDirector(ctx.F.Context(r), charID)
+				if err != nil {
+					ctx.MustRenderError(w, r, err)
+					return
+				}
+			}
+			if !director {
+				langs, err := rc.LanguageTags()
+				if err != nil {
+					langs = []language.Tag{language.English}
+				}
+				ctx.MustRender(render.NewNotFoundView(w, rc, langs...))
+				return
 			}
 			next.ServeHTTP(w, r)
 		})
@@ -0,0 +1,75 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package calendar
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/services"
+	"github.com/cjslep/dharma/internal/sessions"
+	"github.com/cjslep/dharma/internal/util"
+	"github.com/go-fed/apcore/app"
+	"github.com/gorilla/mux"
+	"github.com/mholt/binding"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+type respondRequest struct {
+	Response string
+}
+
+func (c *respondRequest) FieldMap(req *http.Request) binding.FieldMap {
+	return binding.FieldMap{
+		&c.Response: binding.Field{
+			Form:     "response",
+			Required: true,
+		},
+	}
+}
+
+// postRespond sets the selected character's response to an event, both in
+// game and in dharma.
+func (s *Calendar) postRespond(w http.ResponseWriter, r *http.Request, k app.Session, langs []language.Tag) {
+	rc := api.From(r.Context())
+	id, err := strconv.ParseInt(mux.Vars(r)["event"], 10, 32)
+	if err != nil {
+		s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	}
+	rr := &respondRequest{}
+	if errs := binding.Bind(r, rr); errs.Len() > 0 || !esi.IsCalendarResponse(rr.Response) {
+		s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	}
+
+	charID := sessions.GetCharacterSelected(k)
+	err = s.C.Calendar.Respond(s.C.F.Context(r), charID, int32(id), rr.Response)
+	if err == services.NotInvitedError {
+		s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	} else if err != nil {
+		s.C.MustRenderError(w, r, errors.Wrap(err, "could not respond to calendar event"), langs...)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/%s/calendar", util.GetPreferredLanguage(langs)), http.StatusFound)
+}
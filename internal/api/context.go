@@ -36,6 +36,23 @@ type Context struct {
 	L                     *zerolog.Logger
 	ESI                   *services.ESI
 	Media                 *services.Media
+	Names                 *services.Names
+	Roster                *services.Roster
+	Killboard             *services.Killboard
+	Finance               *services.Finance
+	FactionWarfare        *services.FactionWarfare
+	Calendar              *services.Calendar
+	Mail                  *services.Mail
+	Roles                 *services.Roles
+	SDE                   *services.SDE
+	Market                *services.Market
+	Assets                *services.Assets
+	Industry              *services.Industry
+	Mining                *services.Mining
+	Logistics             *services.Logistics
+	KOS                   *services.KOS
+	Standings             *services.Standings
+	Local                 *services.Local
 	Tags                  *services.Tags
 	Posts                 *services.Posts
 	Threads               *services.Threads
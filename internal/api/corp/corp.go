@@ -0,0 +1,80 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package corp
+
+import (
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/go-fed/apcore/app"
+)
+
+type Corp struct {
+	C *api.Context
+}
+
+func (s *Corp) Route(r app.Router) {
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/corp/members",
+		api.CorpMustBeManaged(s.C,
+			api.MustHaveCharacterSelected(s.C,
+				api.MustHaveLanguageCode(s.getMembers))))
+	r.NewRoute().Methods("POST").WebOnlyHandler(
+		"/corp/members/kos",
+		api.CorpMustBeManaged(s.C,
+			api.MustHaveCharacterSelected(s.C,
+				api.MustBeAdmin(s.C,
+					api.MustHaveLanguageCode(s.postKOSEditor)))))
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/corp/fw",
+		api.CorpMustBeManaged(s.C,
+			api.MustHaveCharacterSelected(s.C,
+				api.MustHaveLanguageCode(s.getFactionWarfare))))
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/corp/industry",
+		api.CorpMustBeManaged(s.C,
+			api.MustHaveCharacterSelected(s.C,
+				api.MustHaveLanguageCode(s.getIndustry))))
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/corp/finance",
+		api.CorpMustBeManaged(s.C,
+			api.MustBeAdmin(s.C,
+				api.MustBeDirector(s.C,
+					api.MustHaveLanguageCode(s.getFinance)))))
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/corp/assets",
+		api.CorpMustBeManaged(s.C,
+			api.MustBeAdmin(s.C,
+				api.MustBeDirector(s.C,
+					api.MustHaveLanguageCode(s.getAssets)))))
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/corp/mining",
+		api.CorpMustBeManaged(s.C,
+			api.MustBeAdmin(s.C,
+				api.MustBeDirector(s.C,
+					api.MustHaveLanguageCode(s.getMining)))))
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/corp/mining/{year:[0-9]{4}}/{month:[0-9]{1,2}}",
+		api.CorpMustBeManaged(s.C,
+			api.MustBeAdmin(s.C,
+				api.MustBeDirector(s.C,
+					api.MustHaveLanguageCode(s.getMining)))))
+	r.NewRoute().Methods("POST").WebOnlyHandler(
+		"/corp/mining/rates",
+		api.CorpMustBeManaged(s.C,
+			api.MustBeAdmin(s.C,
+				api.MustBeDirector(s.C,
+					api.MustHaveSessionAndLanguageCode(s.C, s.postMiningRate)))))
+}
@@ -0,0 +1,74 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package corp
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/services"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// getAssets renders the corporation's latest asset snapshot, filtered by
+// location, hangar division, and item type or name.
+func (s *Corp) getAssets(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	rc := api.From(r.Context())
+	var f services.AssetFilter
+	var err error
+	q := r.URL.Query()
+	if l := q.Get("location"); len(l) > 0 {
+		if f.LocationID, err = strconv.ParseInt(l, 10, 64); err != nil {
+			s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+	}
+	if d := q.Get("division"); len(d) > 0 {
+		n, err := strconv.ParseInt(d, 10, 32)
+		if err != nil || n < 0 || n > esi.NHangarDivisions {
+			s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+		f.Division = int32(n)
+	}
+	f.Query = q.Get("q")
+	b, err := s.C.Assets.Browse(s.C.F.Context(r), f, langs[0])
+	if err != nil {
+		s.C.MustRenderError(w, r, errors.Wrap(err, "could not browse corporation assets"), langs...)
+		return
+	}
+	divisions := make([]int32, esi.NHangarDivisions)
+	for i := range divisions {
+		divisions[i] = int32(i + 1)
+	}
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"corp/assets",
+		rc,
+		map[string]interface{}{
+			"assets":    b,
+			"filter":    f,
+			"divisions": divisions,
+		},
+		langs...)
+	s.C.MustRender(v)
+}
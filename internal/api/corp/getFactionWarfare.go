@@ -0,0 +1,45 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package corp
+
+import (
+	"net/http"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+func (s *Corp) getFactionWarfare(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	st, err := s.C.FactionWarfare.GetStats(s.C.F.Context(r), langs[0])
+	if err != nil {
+		s.C.MustRenderError(w, r, errors.Wrap(err, "could not get faction warfare stats"), langs...)
+		return
+	}
+	rc := api.From(r.Context())
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"corp/faction_warfare",
+		rc,
+		map[string]interface{}{
+			"fw": st,
+		},
+		langs...)
+	s.C.MustRender(v)
+}
@@ -0,0 +1,55 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package corp
+
+import (
+	"net/http"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+func (s *Corp) getMembers(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	ms, err := s.C.Roster.GetRoster(s.C.F.Context(r))
+	if err != nil {
+		s.C.MustRenderError(w, r, errors.Wrap(err, "could not get corporation roster"), langs...)
+		return
+	}
+	rc := api.From(r.Context())
+	// Admins may choose which accounts edit the kill on sight list.
+	var editors map[string]bool
+	if admin, _ := rc.IsAdmin(); admin {
+		editors, err = s.C.KOS.GetEditors(s.C.F.Context(r))
+		if err != nil {
+			s.C.MustRenderError(w, r, errors.Wrap(err, "could not get kill on sight editors"), langs...)
+			return
+		}
+	}
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"corp/members",
+		rc,
+		map[string]interface{}{
+			"members": ms,
+			"editors": editors,
+		},
+		langs...)
+	s.C.MustRender(v)
+}
@@ -0,0 +1,77 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package corp
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// getMining renders the ore mined at the corporation's observers in a month
+// and the tax owed for it, defaulting to the current month.
+func (s *Corp) getMining(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	rc := api.From(r.Context())
+	now := time.Now().UTC()
+	year, month := now.Year(), now.Month()
+	vars := mux.Vars(r)
+	if y, ok := vars["year"]; ok {
+		var err error
+		year, err = strconv.Atoi(y)
+		if err != nil {
+			s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+		m, err := strconv.Atoi(vars["month"])
+		if err != nil || m < 1 || m > 12 {
+			s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+		month = time.Month(m)
+	}
+
+	report, err := s.C.Mining.GetReport(s.C.F.Context(r), year, month, langs[0])
+	if err != nil {
+		s.C.MustRenderError(w, r, errors.Wrap(err, "could not get mining report"), langs...)
+		return
+	}
+	prev, next := report.Month.AddDate(0, -1, 0), report.Month.AddDate(0, 1, 0)
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"corp/mining",
+		rc,
+		map[string]interface{}{
+			"report":   report,
+			"prevPath": miningMonthPath(langs[0], prev),
+			"nextPath": miningMonthPath(langs[0], next),
+			"hasNext":  !next.After(now),
+		},
+		langs...)
+	s.C.MustRender(v)
+}
+
+func miningMonthPath(lang language.Tag, t time.Time) string {
+	return fmt.Sprintf("/%s/corp/mining/%04d/%02d", lang, t.Year(), t.Month())
+}
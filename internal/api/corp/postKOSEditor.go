@@ -0,0 +1,79 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package corp
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/services"
+	"github.com/cjslep/dharma/internal/util"
+	ap_paths "github.com/go-fed/apcore/paths"
+	"github.com/mholt/binding"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+type kosEditorRequest struct {
+	UserID string
+	Editor bool
+}
+
+func (k *kosEditorRequest) FieldMap(req *http.Request) binding.FieldMap {
+	return binding.FieldMap{
+		&k.UserID: binding.Field{
+			Form:     "user",
+			Required: true,
+		},
+		&k.Editor: binding.Field{
+			Form: "editor",
+		},
+	}
+}
+
+// postKOSEditor grants or revokes an account's privilege to edit the kill on
+// sight list, keeping its other privileges.
+func (s *Corp) postKOSEditor(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	rc := api.From(r.Context())
+	kr := &kosEditorRequest{}
+	if errs := binding.Bind(r, kr); errs.Len() > 0 {
+		s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	}
+	ok, err := s.C.KOS.CanBeEditor(s.C.F.Context(r), kr.UserID)
+	if err != nil {
+		s.C.MustRenderError(w, r, errors.Wrap(err, "could not get characters for user"), langs...)
+		return
+	} else if !ok {
+		s.C.MustRender(render.NewNotFoundView(w, rc, langs...))
+		return
+	}
+	var priv services.Privileges
+	admin, err := s.C.F.GetPrivileges(s.C.F.Context(r), ap_paths.UUID(kr.UserID), &priv)
+	if err != nil {
+		s.C.MustRenderError(w, r, errors.Wrap(err, "could not get user privileges"), langs...)
+		return
+	}
+	priv.KOSEditor = kr.Editor
+	if err := s.C.F.SetPrivileges(s.C.F.Context(r), ap_paths.UUID(kr.UserID), admin, priv); err != nil {
+		s.C.MustRenderError(w, r, errors.Wrap(err, "could not set user privileges"), langs...)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/%s/corp/members", util.GetPreferredLanguage(langs)), http.StatusFound)
+}
@@ -0,0 +1,89 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package corp
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/go-fed/apcore/app"
+	"github.com/mholt/binding"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+type miningRateRequest struct {
+	CharacterID int32
+	// Rate is a percentage, and when empty the character owes the default
+	// rate again.
+	Rate  string
+	Year  int
+	Month int
+}
+
+func (m *miningRateRequest) FieldMap(req *http.Request) binding.FieldMap {
+	return binding.FieldMap{
+		&m.CharacterID: binding.Field{
+			Form:     "character",
+			Required: true,
+		},
+		&m.Rate: binding.Field{
+			Form: "rate",
+		},
+		&m.Year: binding.Field{
+			Form:     "year",
+			Required: true,
+		},
+		&m.Month: binding.Field{
+			Form:     "month",
+			Required: true,
+		},
+	}
+}
+
+// postMiningRate sets the mining tax rate of a character, then returns to the
+// month of the report it was set from.
+func (s *Corp) postMiningRate(w http.ResponseWriter, r *http.Request, k app.Session, langs []language.Tag) {
+	rc := api.From(r.Context())
+	mr := &miningRateRequest{}
+	if errs := binding.Bind(r, mr); errs.Len() > 0 || mr.Month < 1 || mr.Month > 12 {
+		s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	}
+	var err error
+	if rate := strings.TrimSpace(mr.Rate); len(rate) == 0 {
+		err = s.C.Mining.ResetTaxRate(s.C.F.Context(r), mr.CharacterID)
+	} else {
+		pct, perr := strconv.ParseFloat(rate, 64)
+		// Written so that NaN, which ParseFloat accepts, is rejected.
+		if perr != nil || !(pct >= 0 && pct <= 100) {
+			s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+		err = s.C.Mining.SetTaxRate(s.C.F.Context(r), mr.CharacterID, pct)
+	}
+	if err != nil {
+		s.C.MustRenderError(w, r, errors.Wrap(err, "could not set mining tax rate"), langs...)
+		return
+	}
+	month := time.Date(mr.Year, time.Month(mr.Month), 1, 0, 0, 0, 0, time.UTC)
+	http.Redirect(w, r, miningMonthPath(langs[0], month), http.StatusFound)
+}
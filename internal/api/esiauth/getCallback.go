@@ -36,7 +36,9 @@ func (e *ESIAuth) getCallback(w http.ResponseWriter, r *http.Request, k app.Sess
 	// Enforce state integrity
 	state := r.URL.Query().Get("state")
 	myState := sessions.GetESIOAuth2State(k)
+	verifier := sessions.GetESIOAuth2Verifier(k)
 	sessions.ClearESIOAuth2State(k)
+	sessions.ClearESIOAuth2Verifier(k)
 	if myState == "" || state != myState {
 		if err := k.Save(r, w); err != nil {
 			e.C.MustRenderErrorEnglish(w, r, errors.Wrap(err, "could not save session"))
@@ -48,33 +50,18 @@ func (e *ESIAuth) getCallback(w http.ResponseWriter, r *http.Request, k app.Sess
 
 	// Exchange the short-lived code for long-term authorization.
 	code := r.URL.Query().Get("code")
-	jwt, err := e.C.OAC.GetAuthorization(code)
+	jwt, err := e.C.OAC.GetAuthorization(code, verifier)
 	if err != nil {
 		e.C.MustRenderErrorEnglish(w, r, errors.Wrap(err, "could not get jwt"))
 		return
 	}
 
 	// Verify the authenticity of the authorization.
-	ek, err := e.C.ESI.GetEvePublicKeys(e.C.F.Context(r))
-	if err != nil {
-		e.C.MustRenderErrorEnglish(w, r, errors.Wrap(err, "could not retrieve EVE public keys"))
-		return
-	}
-	jwtk := ek.JWTKey()
-	if jwtk == nil {
-		e.C.MustRenderErrorEnglish(w, r, errors.New("could not find EVE public jwt key"))
-		return
-	}
-	claims, err := jwtk.ValidateToken([]byte(jwt.AccessToken))
+	claims, err := e.C.ESI.ValidateAccessToken(e.C.F.Context(r), jwt.AccessToken)
 	if err != nil {
 		e.C.MustRenderErrorEnglish(w, r, errors.Wrap(err, "could not validate jwt"))
 		return
 	}
-	err = esi.ValidateEveClaims(claims)
-	if err != nil {
-		e.C.MustRenderErrorEnglish(w, r, errors.Wrap(err, "could not validate jwt issuer"))
-		return
-	}
 
 	// Construct our internal representation of a validated token, and
 	// store it.
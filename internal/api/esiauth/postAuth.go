@@ -46,7 +46,17 @@ func (e *ESIAuth) postAuth(w http.ResponseWriter, r *http.Request, k app.Session
 		return
 	}
 	sessions.SetESIOAuth2State(k, state)
-	u := e.C.OAC.GetURL(state, scopes)
+	var challenge string
+	if e.C.OAC.PKCE {
+		var verifier string
+		verifier, challenge, err = esi.NewPKCE()
+		if err != nil {
+			e.C.MustRenderError(w, r, errors.Wrap(err, "could not generate pkce verifier for oauth2"), langs...)
+			return
+		}
+		sessions.SetESIOAuth2Verifier(k, verifier)
+	}
+	u := e.C.OAC.GetURL(state, challenge, scopes)
 	if err := k.Save(r, w); err != nil {
 		e.C.MustRenderError(w, r, errors.Wrap(err, "could not save session"), langs...)
 		return
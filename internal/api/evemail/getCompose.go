@@ -0,0 +1,46 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package evemail
+
+import (
+	"net/http"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/go-fed/apcore/app"
+	"golang.org/x/text/language"
+)
+
+// getCompose renders the form for writing a mail, prefilled with any
+// recipients and subject in the query.
+func (s *Mail) getCompose(w http.ResponseWriter, r *http.Request, k app.Session, langs []language.Tag) {
+	rc := api.From(r.Context())
+	q := r.URL.Query()
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"mail/compose",
+		rc,
+		map[string]interface{}{
+			"to":      q.Get("to"),
+			"subject": q.Get("subject"),
+			"body":    "",
+			"unknown": []string(nil),
+		},
+		langs...)
+	s.C.MustRender(v)
+}
@@ -0,0 +1,59 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package evemail
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/sessions"
+	"github.com/go-fed/apcore/app"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// getMail renders one of the selected character's mail.
+func (s *Mail) getMail(w http.ResponseWriter, r *http.Request, k app.Session, langs []language.Tag) {
+	rc := api.From(r.Context())
+	id, err := strconv.ParseInt(mux.Vars(r)["mail"], 10, 32)
+	if err != nil {
+		s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	}
+
+	charID := sessions.GetCharacterSelected(k)
+	m, err := s.C.Mail.GetMail(s.C.F.Context(r), charID, int32(id))
+	if err != nil {
+		s.C.MustRenderError(w, r, errors.Wrap(err, "could not get mail"), langs...)
+		return
+	}
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"mail/mail",
+		rc,
+		map[string]interface{}{
+			"mail":         m,
+			"replyTo":      m.Names[m.From],
+			"replySubject": "Re: " + m.Subject,
+		},
+		langs...)
+	s.C.MustRender(v)
+}
@@ -0,0 +1,68 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package evemail
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/sessions"
+	"github.com/go-fed/apcore/app"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// getMailbox renders a page of the selected character's mail, optionally
+// only those with a label.
+func (s *Mail) getMailbox(w http.ResponseWriter, r *http.Request, k app.Session, langs []language.Tag) {
+	rc := api.From(r.Context())
+	var label, before int64
+	var err error
+	if l := r.URL.Query().Get("label"); len(l) > 0 {
+		label, err = strconv.ParseInt(l, 10, 32)
+		if err != nil {
+			s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+	}
+	if b := r.URL.Query().Get("before"); len(b) > 0 {
+		before, err = strconv.ParseInt(b, 10, 32)
+		if err != nil {
+			s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+	}
+
+	charID := sessions.GetCharacterSelected(k)
+	mb, err := s.C.Mail.GetMailbox(s.C.F.Context(r), charID, int32(label), int32(before))
+	if err != nil {
+		s.C.MustRenderError(w, r, errors.Wrap(err, "could not get mailbox"), langs...)
+		return
+	}
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"mail/mailbox",
+		rc,
+		map[string]interface{}{
+			"mailbox": mb,
+		},
+		langs...)
+	s.C.MustRender(v)
+}
@@ -0,0 +1,91 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package evemail
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/services"
+	"github.com/cjslep/dharma/internal/sessions"
+	"github.com/cjslep/dharma/internal/util"
+	"github.com/go-fed/apcore/app"
+	"github.com/mholt/binding"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+type composeRequest struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+func (c *composeRequest) FieldMap(req *http.Request) binding.FieldMap {
+	return binding.FieldMap{
+		&c.To: binding.Field{
+			Form:     "to",
+			Required: true,
+		},
+		&c.Subject: binding.Field{
+			Form:     "subject",
+			Required: true,
+		},
+		&c.Body: binding.Field{
+			Form:     "body",
+			Required: true,
+		},
+	}
+}
+
+// postCompose sends a mail from the selected character. If any recipient
+// cannot be found, the form is shown again so that it may be corrected.
+func (s *Mail) postCompose(w http.ResponseWriter, r *http.Request, k app.Session, langs []language.Tag) {
+	rc := api.From(r.Context())
+	cr := &composeRequest{}
+	errs := binding.Bind(r, cr)
+	if errs.Len() > 0 {
+		s.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	}
+
+	lang := util.GetPreferredLanguage(langs)
+	charID := sessions.GetCharacterSelected(k)
+	err := s.C.Mail.Send(s.C.F.Context(r), charID, cr.To, cr.Subject, cr.Body, lang)
+	if ure, ok := err.(*services.UnknownRecipientsError); ok {
+		v := render.NewHTMLView(
+			w,
+			http.StatusOK,
+			"mail/compose",
+			rc,
+			map[string]interface{}{
+				"to":      cr.To,
+				"subject": cr.Subject,
+				"body":    cr.Body,
+				"unknown": ure.Names,
+			},
+			langs...)
+		s.C.MustRender(v)
+		return
+	} else if err != nil {
+		s.C.MustRenderError(w, r, errors.Wrap(err, "could not send mail"), langs...)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/%s/mail", lang), http.StatusFound)
+}
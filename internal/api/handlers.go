@@ -93,6 +93,14 @@ func MustBeAdmin(ctx *Context, next http.Handler) http.Handler {
 	return enforceLoggedInAsAdmin(ctx)(next)
 }
 
+func MustBeDirector(ctx *Context, next http.Handler) http.Handler {
+	return enforceCharacterIsDirector(ctx)(next)
+}
+
+func MustBeKOSEditor(ctx *Context, next http.Handler) http.Handler {
+	return enforceKOSEditor(ctx)(next)
+}
+
 // TODO: Use this function
 func MustHaveCharacterSelected(ctx *Context, next http.Handler) http.Handler {
 	return enforceCharacterSelected(ctx)(next)
@@ -0,0 +1,74 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package killboard
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// getCharacter renders a page of the killmails involving a character.
+func (k *Killboard) getCharacter(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	rc := api.From(r.Context())
+	id, err := strconv.ParseInt(mux.Vars(r)["character"], 10, 32)
+	if err != nil {
+		k.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	}
+	page := 0
+	if p := r.URL.Query().Get("page"); len(p) > 0 {
+		page, err = strconv.Atoi(p)
+		if err != nil || page < 0 {
+			k.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+	}
+
+	c := k.C.F.Context(r)
+	kms, err := k.C.Killboard.GetCharacter(c, int32(id), page)
+	if err != nil {
+		k.C.MustRenderError(w, r, errors.Wrap(err, "could not get killmails for character"), langs...)
+		return
+	}
+	names, err := k.C.Names.ResolveNames(c, []int32{int32(id)})
+	if err != nil {
+		k.C.MustRenderError(w, r, errors.Wrap(err, "could not get character name"), langs...)
+		return
+	}
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"killboard/character",
+		rc,
+		map[string]interface{}{
+			"characterID":   id,
+			"characterName": names[int32(id)].Name,
+			"killmails":     kms,
+			"prevPage":      page - 1,
+			"nextPage":      page + 1,
+			"hasPrev":       page > 0,
+			"hasNext":       len(kms.Listings) == k.C.Killboard.NPerPage,
+		},
+		langs...)
+	k.C.MustRender(v)
+}
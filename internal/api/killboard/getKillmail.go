@@ -0,0 +1,55 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package killboard
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+func (k *Killboard) getKillmail(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	rc := api.From(r.Context())
+	id, err := strconv.ParseInt(mux.Vars(r)["killmail"], 10, 32)
+	if err != nil {
+		k.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	}
+	km, err := k.C.Killboard.GetKillmail(k.C.F.Context(r), int32(id))
+	if err != nil {
+		k.C.MustRenderError(w, r, errors.Wrap(err, "could not get killmail"), langs...)
+		return
+	} else if km == nil {
+		k.C.MustRender(render.NewNotFoundView(w, rc, langs...))
+		return
+	}
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"killboard/killmail",
+		rc,
+		map[string]interface{}{
+			"killmail": km,
+		},
+		langs...)
+	k.C.MustRender(v)
+}
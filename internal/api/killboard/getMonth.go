@@ -0,0 +1,78 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package killboard
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// getMonth renders the killmails in a month, defaulting to the current month.
+func (k *Killboard) getMonth(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	rc := api.From(r.Context())
+	now := time.Now().UTC()
+	year, month := now.Year(), now.Month()
+	vars := mux.Vars(r)
+	if y, ok := vars["year"]; ok {
+		var err error
+		year, err = strconv.Atoi(y)
+		if err != nil {
+			k.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+		m, err := strconv.Atoi(vars["month"])
+		if err != nil || m < 1 || m > 12 {
+			k.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+		month = time.Month(m)
+	}
+
+	kms, err := k.C.Killboard.GetMonth(k.C.F.Context(r), year, month)
+	if err != nil {
+		k.C.MustRenderError(w, r, errors.Wrap(err, "could not get killmails for month"), langs...)
+		return
+	}
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	prev, next := start.AddDate(0, -1, 0), start.AddDate(0, 1, 0)
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"killboard/month",
+		rc,
+		map[string]interface{}{
+			"month":     start,
+			"killmails": kms,
+			"prevPath":  monthPath(langs[0], prev),
+			"nextPath":  monthPath(langs[0], next),
+			"hasNext":   !next.After(now),
+		},
+		langs...)
+	k.C.MustRender(v)
+}
+
+func monthPath(lang language.Tag, t time.Time) string {
+	return fmt.Sprintf("/%s/killboard/%04d/%02d", lang, t.Year(), t.Month())
+}
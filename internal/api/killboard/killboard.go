@@ -0,0 +1,49 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package killboard
+
+import (
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/go-fed/apcore/app"
+)
+
+type Killboard struct {
+	C *api.Context
+}
+
+func (k *Killboard) Route(r app.Router) {
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/killboard",
+		api.CorpMustBeManaged(k.C,
+			api.MustHaveCharacterSelected(k.C,
+				api.MustHaveLanguageCode(k.getMonth))))
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/killboard/{year:[0-9]{4}}/{month:[0-9]{1,2}}",
+		api.CorpMustBeManaged(k.C,
+			api.MustHaveCharacterSelected(k.C,
+				api.MustHaveLanguageCode(k.getMonth))))
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/killboard/characters/{character:[0-9]+}",
+		api.CorpMustBeManaged(k.C,
+			api.MustHaveCharacterSelected(k.C,
+				api.MustHaveLanguageCode(k.getCharacter))))
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/killboard/kills/{killmail:[0-9]+}",
+		api.CorpMustBeManaged(k.C,
+			api.MustHaveCharacterSelected(k.C,
+				api.MustHaveLanguageCode(k.getKillmail))))
+}
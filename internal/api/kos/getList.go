@@ -0,0 +1,56 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package kos
+
+import (
+	"net/http"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// getList renders the kill on sight entries matching the search query, along
+// with the form to add an entry if the user may edit the list.
+func (k *KOS) getList(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	rc := api.From(r.Context())
+	editor, err := rc.IsKOSEditor()
+	if err != nil {
+		k.C.MustRenderError(w, r, err, langs...)
+		return
+	}
+	q := r.URL.Query().Get("q")
+	es, truncated, err := k.C.KOS.Search(k.C.F.Context(r), q)
+	if err != nil {
+		k.C.MustRenderError(w, r, errors.Wrap(err, "could not search kill on sight list"), langs...)
+		return
+	}
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"kos/list",
+		rc,
+		map[string]interface{}{
+			"query":     q,
+			"entries":   es,
+			"truncated": truncated,
+			"editor":    editor,
+		},
+		langs...)
+	k.C.MustRender(v)
+}
@@ -0,0 +1,56 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package kos
+
+import (
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/go-fed/apcore/app"
+)
+
+type KOS struct {
+	C *api.Context
+}
+
+func (k *KOS) Route(r app.Router) {
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/kos",
+		api.CorpMustBeManaged(k.C,
+			api.MustHaveCharacterSelected(k.C,
+				api.MustHaveLanguageCode(k.getList))))
+	r.NewRoute().Methods("POST").WebOnlyHandler(
+		"/kos",
+		api.CorpMustBeManaged(k.C,
+			api.MustHaveCharacterSelected(k.C,
+				api.MustBeKOSEditor(k.C,
+					api.MustHaveSessionAndLanguageCode(k.C, k.postEntry)))))
+	r.NewRoute().Methods("POST").WebOnlyHandler(
+		"/kos/{entity:[0-9]+}/remove",
+		api.CorpMustBeManaged(k.C,
+			api.MustHaveCharacterSelected(k.C,
+				api.MustBeKOSEditor(k.C,
+					api.MustHaveLanguageCode(k.postRemove)))))
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/kos/check",
+		api.CorpMustBeManaged(k.C,
+			api.MustHaveCharacterSelected(k.C,
+				api.MustHaveLanguageCode(k.getCheck))))
+	r.NewRoute().Methods("POST").WebOnlyHandler(
+		"/kos/check",
+		api.CorpMustBeManaged(k.C,
+			api.MustHaveCharacterSelected(k.C,
+				api.MustHaveLanguageCode(k.postCheck))))
+}
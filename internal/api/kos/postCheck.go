@@ -0,0 +1,66 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package kos
+
+import (
+	"net/http"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/mholt/binding"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+type checkRequest struct {
+	Names string
+}
+
+func (c *checkRequest) FieldMap(req *http.Request) binding.FieldMap {
+	return binding.FieldMap{
+		&c.Names: binding.Field{
+			Form:     "names",
+			Required: true,
+		},
+	}
+}
+
+// postCheck renders which of the pasted names are on the kill on sight list.
+func (k *KOS) postCheck(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	rc := api.From(r.Context())
+	cr := &checkRequest{}
+	if errs := binding.Bind(r, cr); errs.Len() > 0 {
+		k.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	}
+	check, err := k.C.KOS.Check(k.C.F.Context(r), cr.Names, langs[0])
+	if err != nil {
+		k.C.MustRenderError(w, r, errors.Wrap(err, "could not check names against kill on sight list"), langs...)
+		return
+	}
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"kos/check",
+		rc,
+		map[string]interface{}{
+			"names": cr.Names,
+			"check": check,
+		},
+		langs...)
+	k.C.MustRender(v)
+}
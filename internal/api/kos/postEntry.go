@@ -0,0 +1,113 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package kos
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/services"
+	"github.com/cjslep/dharma/internal/sessions"
+	"github.com/cjslep/dharma/internal/util"
+	"github.com/go-fed/apcore/app"
+	"github.com/mholt/binding"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+type entryRequest struct {
+	Name     string
+	Category string
+	Reason   string
+	// Evidence holds links, one per line.
+	Evidence string
+	// Expires is a date, and when empty the entry never expires.
+	Expires string
+}
+
+func (e *entryRequest) FieldMap(req *http.Request) binding.FieldMap {
+	return binding.FieldMap{
+		&e.Name: binding.Field{
+			Form:     "name",
+			Required: true,
+		},
+		&e.Category: binding.Field{
+			Form:     "category",
+			Required: true,
+		},
+		&e.Reason: binding.Field{
+			Form:     "reason",
+			Required: true,
+		},
+		&e.Evidence: binding.Field{
+			Form: "evidence",
+		},
+		&e.Expires: binding.Field{
+			Form: "expires",
+		},
+	}
+}
+
+// postEntry puts a character, corporation, or alliance on the kill on sight
+// list, replacing its entry if it has one.
+func (k *KOS) postEntry(w http.ResponseWriter, r *http.Request, s app.Session, langs []language.Tag) {
+	rc := api.From(r.Context())
+	er := &entryRequest{}
+	if errs := binding.Bind(r, er); errs.Len() > 0 || !services.IsKOSCategory(er.Category) || len(strings.TrimSpace(er.Reason)) == 0 {
+		k.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	}
+	evidence := strings.Split(er.Evidence, "\n")
+	for _, e := range evidence {
+		if e = strings.TrimSpace(e); len(e) == 0 {
+			continue
+		}
+		if u, err := url.Parse(e); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			k.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+	}
+	var expires *time.Time
+	if len(er.Expires) > 0 {
+		t, err := time.Parse("2006-01-02", er.Expires)
+		if err != nil || t.Before(time.Now()) {
+			k.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+		expires = &t
+	}
+	err := k.C.KOS.Add(k.C.F.Context(r), sessions.GetCharacterSelected(s), services.KOSForm{
+		Name:     er.Name,
+		Category: er.Category,
+		Reason:   er.Reason,
+		Evidence: evidence,
+		Expires:  expires,
+	}, langs[0])
+	if err == services.KOSEntityNotFoundError {
+		k.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	} else if err != nil {
+		k.C.MustRenderError(w, r, errors.Wrap(err, "could not add to kill on sight list"), langs...)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/%s/kos", util.GetPreferredLanguage(langs)), http.StatusFound)
+}
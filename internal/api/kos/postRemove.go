@@ -0,0 +1,44 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package kos
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/util"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// postRemove takes an entity off the kill on sight list.
+func (k *KOS) postRemove(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	id, err := strconv.ParseInt(mux.Vars(r)["entity"], 10, 32)
+	if err != nil {
+		k.C.MustRender(render.NewBadRequestView(w, api.From(r.Context()), langs...))
+		return
+	}
+	if err := k.C.KOS.Remove(k.C.F.Context(r), int32(id)); err != nil {
+		k.C.MustRenderError(w, r, errors.Wrap(err, "could not remove from kill on sight list"), langs...)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/%s/kos", util.GetPreferredLanguage(langs)), http.StatusFound)
+}
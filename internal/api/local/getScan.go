@@ -0,0 +1,50 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package local
+
+import (
+	"net/http"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// getScan renders a stored local chat scan, until it expires.
+func (l *Local) getScan(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	rc := api.From(r.Context())
+	scan, err := l.C.Local.Get(l.C.F.Context(r), mux.Vars(r)["scan"])
+	if err != nil {
+		l.C.MustRenderError(w, r, errors.Wrap(err, "could not get local chat scan"), langs...)
+		return
+	} else if scan == nil {
+		l.C.MustRender(render.NewNotFoundView(w, rc, langs...))
+		return
+	}
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"local/result",
+		rc,
+		map[string]interface{}{
+			"scan": scan,
+		},
+		langs...)
+	l.C.MustRender(v)
+}
@@ -0,0 +1,44 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package local
+
+import (
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/go-fed/apcore/app"
+)
+
+type Local struct {
+	C *api.Context
+}
+
+func (l *Local) Route(r app.Router) {
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/local",
+		api.CorpMustBeManaged(l.C,
+			api.MustHaveCharacterSelected(l.C,
+				api.MustHaveLanguageCode(l.getScanForm))))
+	r.NewRoute().Methods("POST").WebOnlyHandler(
+		"/local",
+		api.CorpMustBeManaged(l.C,
+			api.MustHaveCharacterSelected(l.C,
+				api.MustHaveSessionAndLanguageCode(l.C, l.postScan))))
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/local/{scan:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}}",
+		api.CorpMustBeManaged(l.C,
+			api.MustHaveCharacterSelected(l.C,
+				api.MustHaveLanguageCode(l.getScan))))
+}
@@ -0,0 +1,61 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package local
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/sessions"
+	"github.com/cjslep/dharma/internal/util"
+	"github.com/go-fed/apcore/app"
+	"github.com/mholt/binding"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+type scanRequest struct {
+	Names string
+}
+
+func (s *scanRequest) FieldMap(req *http.Request) binding.FieldMap {
+	return binding.FieldMap{
+		&s.Names: binding.Field{
+			Form:     "names",
+			Required: true,
+		},
+	}
+}
+
+// postScan scans the pasted local chat, then shows the stored result so its
+// link can be shared.
+func (l *Local) postScan(w http.ResponseWriter, r *http.Request, k app.Session, langs []language.Tag) {
+	rc := api.From(r.Context())
+	sr := &scanRequest{}
+	if errs := binding.Bind(r, sr); errs.Len() > 0 {
+		l.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	}
+	id, err := l.C.Local.Scan(l.C.F.Context(r), sessions.GetCharacterSelected(k), sr.Names, langs[0])
+	if err != nil {
+		l.C.MustRenderError(w, r, errors.Wrap(err, "could not scan local chat"), langs...)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/%s/local/%s", util.GetPreferredLanguage(langs), id), http.StatusFound)
+}
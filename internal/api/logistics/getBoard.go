@@ -0,0 +1,50 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package logistics
+
+import (
+	"net/http"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/sessions"
+	"github.com/go-fed/apcore/app"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// getBoard renders the haul requests, with the actions the selected character
+// may take on each.
+func (l *Logistics) getBoard(w http.ResponseWriter, r *http.Request, k app.Session, langs []language.Tag) {
+	hs, err := l.C.Logistics.GetBoard(l.C.F.Context(r))
+	if err != nil {
+		l.C.MustRenderError(w, r, errors.Wrap(err, "could not get haul requests"), langs...)
+		return
+	}
+	rc := api.From(r.Context())
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"logistics/board",
+		rc,
+		map[string]interface{}{
+			"requests": hs,
+			"charID":   sessions.GetCharacterSelected(k),
+		},
+		langs...)
+	l.C.MustRender(v)
+}
@@ -0,0 +1,63 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package logistics
+
+import (
+	"fmt"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/util"
+	"github.com/go-fed/apcore/app"
+	"golang.org/x/text/language"
+)
+
+type Logistics struct {
+	C *api.Context
+}
+
+func (l *Logistics) Route(r app.Router) {
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/logistics",
+		api.CorpMustBeManaged(l.C,
+			api.MustHaveCharacterSelected(l.C,
+				api.MustHaveSessionAndLanguageCode(l.C, l.getBoard))))
+	r.NewRoute().Methods("POST").WebOnlyHandler(
+		"/logistics",
+		api.CorpMustBeManaged(l.C,
+			api.MustHaveCharacterSelected(l.C,
+				api.MustHaveSessionAndLanguageCode(l.C, l.postRequest))))
+	r.NewRoute().Methods("POST").WebOnlyHandler(
+		"/logistics/{request:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}}/claim",
+		api.CorpMustBeManaged(l.C,
+			api.MustHaveCharacterSelected(l.C,
+				api.MustHaveSessionAndLanguageCode(l.C, l.postClaim))))
+	r.NewRoute().Methods("POST").WebOnlyHandler(
+		"/logistics/{request:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}}/unclaim",
+		api.CorpMustBeManaged(l.C,
+			api.MustHaveCharacterSelected(l.C,
+				api.MustHaveSessionAndLanguageCode(l.C, l.postUnclaim))))
+	r.NewRoute().Methods("POST").WebOnlyHandler(
+		"/logistics/{request:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}}/cancel",
+		api.CorpMustBeManaged(l.C,
+			api.MustHaveCharacterSelected(l.C,
+				api.MustHaveSessionAndLanguageCode(l.C, l.postCancel))))
+}
+
+// boardPath is the logistics board in the preferred language.
+func boardPath(langs []language.Tag) string {
+	return fmt.Sprintf("/%s/logistics", util.GetPreferredLanguage(langs))
+}
@@ -0,0 +1,47 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package logistics
+
+import (
+	"net/http"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/services"
+	"github.com/cjslep/dharma/internal/sessions"
+	"github.com/go-fed/apcore/app"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// postCancel cancels a haul request the selected character made.
+func (l *Logistics) postCancel(w http.ResponseWriter, r *http.Request, k app.Session, langs []language.Tag) {
+	rc := api.From(r.Context())
+	err := l.C.Logistics.Cancel(l.C.F.Context(r), mux.Vars(r)["request"], sessions.GetCharacterSelected(k))
+	if err == services.HaulRequestNotFoundError {
+		l.C.MustRender(render.NewNotFoundView(w, rc, langs...))
+		return
+	} else if err == services.HaulRequestNotAllowedError {
+		l.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	} else if err != nil {
+		l.C.MustRenderError(w, r, errors.Wrap(err, "could not cancel haul request"), langs...)
+		return
+	}
+	http.Redirect(w, r, boardPath(langs), http.StatusFound)
+}
@@ -0,0 +1,107 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package logistics
+
+import (
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/services"
+	"github.com/cjslep/dharma/internal/sessions"
+	"github.com/go-fed/apcore/app"
+	"github.com/mholt/binding"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+type haulRequest struct {
+	Pickup     string
+	Dropoff    string
+	Volume     float64
+	Collateral float64
+	Reward     float64
+	Notes      string
+}
+
+func (h *haulRequest) FieldMap(req *http.Request) binding.FieldMap {
+	return binding.FieldMap{
+		&h.Pickup: binding.Field{
+			Form:     "pickup",
+			Required: true,
+		},
+		&h.Dropoff: binding.Field{
+			Form:     "dropoff",
+			Required: true,
+		},
+		&h.Volume: binding.Field{
+			Form:     "volume",
+			Required: true,
+		},
+		&h.Collateral: binding.Field{
+			Form: "collateral",
+		},
+		&h.Reward: binding.Field{
+			Form: "reward",
+		},
+		&h.Notes: binding.Field{
+			Form: "notes",
+		},
+	}
+}
+
+// postRequest posts a haul request for the selected character.
+func (l *Logistics) postRequest(w http.ResponseWriter, r *http.Request, k app.Session, langs []language.Tag) {
+	rc := api.From(r.Context())
+	hr := &haulRequest{}
+	if errs := binding.Bind(r, hr); errs.Len() > 0 ||
+		len(strings.TrimSpace(hr.Pickup)) == 0 ||
+		len(strings.TrimSpace(hr.Dropoff)) == 0 ||
+		!isFinite(hr.Volume, hr.Collateral, hr.Reward) ||
+		hr.Volume <= 0 ||
+		hr.Collateral < 0 ||
+		hr.Reward < 0 {
+		l.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+		return
+	}
+	err := l.C.Logistics.Post(l.C.F.Context(r), sessions.GetCharacterSelected(k), services.HaulRequestForm{
+		Pickup:     hr.Pickup,
+		Dropoff:    hr.Dropoff,
+		Volume:     hr.Volume,
+		Collateral: hr.Collateral,
+		Reward:     hr.Reward,
+		Notes:      hr.Notes,
+	}, langs[0])
+	if err != nil {
+		l.C.MustRenderError(w, r, errors.Wrap(err, "could not post haul request"), langs...)
+		return
+	}
+	http.Redirect(w, r, boardPath(langs), http.StatusFound)
+}
+
+// isFinite determines whether none of the numbers are NaN or infinite, which
+// the form's numbers may be parsed as.
+func isFinite(fs ...float64) bool {
+	for _, f := range fs {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return false
+		}
+	}
+	return true
+}
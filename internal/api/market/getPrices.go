@@ -0,0 +1,83 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package market
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cjslep/dharma/internal/api"
+	"github.com/cjslep/dharma/internal/render"
+	"github.com/cjslep/dharma/internal/services"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// getPrices renders the buy, sell, and split prices at a trade hub of the
+// types matching the search query. The hub defaults to the first configured.
+func (m *Market) getPrices(w http.ResponseWriter, r *http.Request, langs []language.Tag) {
+	rc := api.From(r.Context())
+	hubs, err := m.C.Market.GetHubs(m.C.F.Context(r))
+	if err != nil {
+		m.C.MustRenderError(w, r, errors.Wrap(err, "could not get market hubs"), langs...)
+		return
+	}
+	var hub *services.Hub
+	if len(hubs) > 0 {
+		hub = &hubs[0]
+	}
+	if h := r.URL.Query().Get("hub"); len(h) > 0 {
+		id, err := strconv.ParseInt(h, 10, 32)
+		if err != nil {
+			m.C.MustRender(render.NewBadRequestView(w, rc, langs...))
+			return
+		}
+		hub = nil
+		for i := range hubs {
+			if hubs[i].StationID == int32(id) {
+				hub = &hubs[i]
+			}
+		}
+		if hub == nil {
+			m.C.MustRender(render.NewNotFoundView(w, rc, langs...))
+			return
+		}
+	}
+
+	q := r.URL.Query().Get("q")
+	var ps []services.TypePrices
+	if hub != nil {
+		ps, err = m.C.Market.SearchPrices(m.C.F.Context(r), q, hub.StationID, langs[0])
+		if err != nil {
+			m.C.MustRenderError(w, r, errors.Wrap(err, "could not search market prices"), langs...)
+			return
+		}
+	}
+	v := render.NewHTMLView(
+		w,
+		http.StatusOK,
+		"market/prices",
+		rc,
+		map[string]interface{}{
+			"hubs":   hubs,
+			"hub":    hub,
+			"query":  q,
+			"prices": ps,
+		},
+		langs...)
+	m.C.MustRender(v)
+}
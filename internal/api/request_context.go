@@ -124,6 +124,16 @@ func (r *RequestContext) Privileges() (services.Privileges, error) {
 	}
 }
 
+// IsKOSEditor determines whether the user may edit the kill on sight list,
+// which admins always may.
+func (r *RequestContext) IsKOSEditor() (bool, error) {
+	if admin, err := r.IsAdmin(); err != nil || admin {
+		return admin, err
+	}
+	p, err := r.Privileges()
+	return p.KOSEditor, err
+}
+
 func (r *RequestContext) navData(signedIn, isAdmin bool, tag language.Tag, charID int32) map[string]interface{} {
 	m := map[string]interface{}{
 		"signedIn": signedIn,
@@ -133,13 +143,30 @@ func (r *RequestContext) navData(signedIn, isAdmin bool, tag language.Tag, charI
 			"login":              fmt.Sprintf("/%s/login", tag),
 			"logout":             fmt.Sprintf("/%s/logout", tag),
 			"changeCharacter":    fmt.Sprintf("/%s/account/characters", tag),
+			"unlinkCharacter":    fmt.Sprintf("/%s/account/characters/unlink", tag),
 			"profile":            fmt.Sprintf("/%s/account/profile", tag),
 			"settings":           fmt.Sprintf("/%s/account/settings", tag),
 			"forum":              fmt.Sprintf("/%s/forum", tag),
 			"killboard":          fmt.Sprintf("/%s/killboard", tag),
 			"calendar":           fmt.Sprintf("/%s/calendar", tag),
+			"mail":               fmt.Sprintf("/%s/mail", tag),
+			"mailCompose":        fmt.Sprintf("/%s/mail/compose", tag),
+			"members":            fmt.Sprintf("/%s/corp/members", tag),
+			"finance":            fmt.Sprintf("/%s/corp/finance", tag),
+			"assets":             fmt.Sprintf("/%s/corp/assets", tag),
+			"mining":             fmt.Sprintf("/%s/corp/mining", tag),
+			"miningRates":        fmt.Sprintf("/%s/corp/mining/rates", tag),
+			"factionWarfare":     fmt.Sprintf("/%s/corp/fw", tag),
+			"industry":           fmt.Sprintf("/%s/corp/industry", tag),
+			"logistics":          fmt.Sprintf("/%s/logistics", tag),
+			"kos":                fmt.Sprintf("/%s/kos", tag),
+			"kosCheck":           fmt.Sprintf("/%s/kos/check", tag),
+			"kosEditors":         fmt.Sprintf("/%s/corp/members/kos", tag),
+			"local":              fmt.Sprintf("/%s/local", tag),
+			"market":             fmt.Sprintf("/%s/market", tag),
 			"corpSetup":          fmt.Sprintf("/%s/site/setup/corp", tag),
 			"corpSetupSearch":    fmt.Sprintf("/%s/site/setup/corp/search", tag),
+			"esiStatus":          fmt.Sprintf("/%s/site/esi", tag),
 			"beginCharacterAuth": fmt.Sprintf("/%s/esi/auth", tag),
 		},
 		"localizePath": func(s string) (string, error) {
@@ -61,7 +61,7 @@ func (s *Site) postChooseCorpToManage(w http.ResponseWriter, r *http.Request, k
 		return
 	}
 
-	err = s.C.State.ChooseCorporation(aputil.Context{r.Context()}, userID, ccr.CorporationID)
+	err = s.C.State.ChooseCorporation(aputil.Context{r.Context()}, userID, ccr.CorporationID, s.C.Roles)
 	if err != nil && err != services.NotCEOError {
 		s.C.MustRenderError(w, r, errors.Wrap(err, "could not choose corporation"), langs...)
 		return
@@ -49,4 +49,8 @@ func (s *Site) Route(r app.Router) {
 		api.CorpMustNotBeManaged(s.C,
 			api.MustBeAdmin(s.C,
 				api.MustHaveLanguageCode(s.postCorpSetupSearch))))
+	r.NewRoute().Methods("GET").WebOnlyHandler(
+		"/site/esi",
+		api.MustBeAdmin(s.C,
+			api.MustHaveLanguageCode(s.getESIStatus)))
 }
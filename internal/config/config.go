@@ -19,6 +19,7 @@ package config
 type Config struct {
 	ClientID                            string `ini:"dharma_client_id" comment:"The client identifier CCP Games gives your application when registering on the ESI site, to identify your particular software instance."`
 	APIKey                              string `ini:"dharma_api_key" comment:"The secret CCP Games gives your application to verify the authenticity of your software instance."`
+	SSOUsePKCE                          bool   `ini:"dharma_sso_use_pkce" comment:"When true, logs in with EVE Online's single sign on using PKCE instead of the secret, so that dharma_api_key may be left empty. The application registered with CCP Games must allow PKCE. (default: false)"`
 	ESITimeout                          int    `ini:"dharma_esi_timeout" comment:"The timeout in seconds for issuing ESI API requests (default: 60)"`
 	EnableConsoleLogging                bool   `ini:"dharma_debug_console_log" comment:"When true, logs directly to console, which is best used during software development."`
 	LogDir                              string `ini:"dharma_log_directory" comment:"Directory location to write log files to, which can be useful when filing bug reports. (default: ./)"`
@@ -30,11 +31,43 @@ type Config struct {
 	EvePublicKeyPeriodicFetch           int    `ini:"dharma_eve_public_key_fetch_periodic_hours" comment:"Every X hours, fetch the latest public keys from CCP Games. (default: 8)"`
 	EveCachedMediaDefaultExpiryDuration int    `ini:"dharma_eve_cached_media_default_expiry_duration" comment:"If CCP's static serving does not specify a cache duration for media such as images, the default time period to cache the media in hours. (default: 24)"`
 	MediaUploadMaxSizeMB                int    `ini:"dharma_media_max_upload_size_mb" comment:"Maximum size of a single media upload in Megabytes (default: 10)"`
+	LogisticsSyncPeriodicCheck          int    `ini:"dharma_logistics_sync_periodic_minutes" comment:"Every X minutes, fetch the managed corporation's contracts from ESI to find the courier contracts made for haul requests. (default: 15)"`
+	LogisticsShowFinishedDays           int    `ini:"dharma_logistics_show_finished_days" comment:"Number of days a completed or cancelled haul request is still shown on the logistics board. (default: 7)"`
+	StandingsSyncPeriodicCheck          int    `ini:"dharma_standings_sync_periodic_hours" comment:"Every X hours, fetch the contacts of the managed corporation and its alliance from ESI, whose standings are flagged when scanning local chat. (default: 1)"`
+	LocalScanExpiryHours                int    `ini:"dharma_local_scan_expiry_hours" comment:"Number of hours a shared local chat scan can be viewed before it expires. (default: 24)"`
+	ESICacheRetention                   int    `ini:"dharma_esi_cache_retention_hours" comment:"Number of hours to keep an expired ESI response in the database, so that it may be cheaply revalidated with its ETag. (default: 168)"`
+	ESICachePrunePeriodicCheck          int    `ini:"dharma_esi_cache_prune_periodic_hours" comment:"Every X hours, delete the ESI responses that have been expired for longer than the retention period. (default: 24)"`
+	ESIErrorLimitThreshold              int    `ini:"dharma_esi_error_limit_threshold" comment:"When ESI reports this many or fewer errors remaining in its error limit window, pause ESI requests until the window resets. (default: 20)"`
+	ESIErrorLimitMaxWait                int    `ini:"dharma_esi_error_limit_max_wait_sec" comment:"The longest duration in seconds a request will be paused waiting for the ESI error limit to reset, before failing instead. (default: 10)"`
+	EveEntityNameExpiry                 int    `ini:"dharma_eve_entity_name_expiry_hours" comment:"Number of hours to trust a locally stored name of a character, corporation, alliance, or other Eve entity before resolving it with ESI again. (default: 72)"`
+	EveUnknownNameExpiry                int    `ini:"dharma_eve_unknown_name_expiry_minutes" comment:"Number of minutes to remember that a name does not match any Eve entity, or that an ID does not name one, so that repeatedly pasted typos, NPC names, and invalid IDs are not resolved with ESI again. (default: 60)"`
+	ESIMaxConcurrency                   int    `ini:"dharma_esi_max_concurrency" comment:"The maximum number of concurrent ESI requests issued when fetching a list of characters or corporations, such as search results. (default: 10)"`
+	RosterSyncPeriodicCheck             int    `ini:"dharma_roster_sync_periodic_hours" comment:"Every X hours, fetch the managed corporation's member list from ESI and update the roster. (default: 1)"`
+	RosterShowLeftDays                  int    `ini:"dharma_roster_show_left_days" comment:"Number of days a character that left the corporation is still shown on the members page. (default: 30)"`
+	KillmailPeriodicPoll                int    `ini:"dharma_killmail_poll_periodic_minutes" comment:"Every X minutes, fetch the managed corporation's recent killmails from ESI and store the new ones. (default: 15)"`
+	WalletSyncPeriodicCheck             int    `ini:"dharma_wallet_sync_periodic_hours" comment:"Every X hours, fetch the new entries in the managed corporation's wallet journals and transactions from ESI. (default: 1)"`
+	FinanceTaxMonths                    int    `ini:"dharma_finance_tax_months" comment:"Number of months of tax paid per member to show on the finance dashboard. (default: 6)"`
+	FactionWarfarePeriodicSnapshot      int    `ini:"dharma_fw_stats_snapshot_periodic_hours" comment:"Every X hours, fetch the managed corporation's faction warfare statistics from ESI and record them as the snapshot for the day. (default: 6)"`
+	FactionWarfareHistoryDays           int    `ini:"dharma_fw_stats_history_days" comment:"Number of days of faction warfare statistics to show on the faction warfare page. (default: 90)"`
+	CalendarSyncPeriodicCheck           int    `ini:"dharma_calendar_sync_periodic_hours" comment:"Every X hours, fetch the in-game calendar events of each character that granted access to them. (default: 1)"`
+	RolesSyncPeriodicCheck              int    `ini:"dharma_roles_sync_periodic_hours" comment:"Every X hours, fetch the in-game corporation roles of each character that granted access to them. Director-only pages trust the roles fetched last. (default: 1)"`
+	SDEDirectory                        string `ini:"dharma_sde_directory" comment:"Directory where CCP Games' Static Data Export is unzipped, in its YAML format, so types and the universe can be looked up without ESI. Unzip a release here and run the import-sde command to import it; a release already imported is skipped."`
+	MarketHubs                          string `ini:"dharma_market_hubs" comment:"Comma separated trade hubs whose order books are summarized into buy, sell, and split prices, each written as region_id:station_id. (default: 10000002:60003760, which is Jita IV - Moon 4 - Caldari Navy Assembly Plant)"`
+	MarketSyncPeriodicCheck             int    `ini:"dharma_market_sync_periodic_minutes" comment:"Every X minutes, fetch the universe-wide market prices and the order books of the trade hubs from ESI. (default: 60)"`
+	AssetSyncPeriodicCheck              int    `ini:"dharma_asset_sync_periodic_hours" comment:"Every X hours, fetch the managed corporation's assets from ESI and store them as a new snapshot. (default: 6)"`
+	AssetSnapshotRetentionDays          int    `ini:"dharma_asset_snapshot_retention_days" comment:"Number of days asset snapshots are kept before being deleted. Only the latest is browsed. (default: 2)"`
+	IndustrySyncPeriodicCheck           int    `ini:"dharma_industry_sync_periodic_minutes" comment:"Every X minutes, fetch the managed corporation's industry jobs from ESI. (default: 15)"`
+	IndustryShowDeliveredDays           int    `ini:"dharma_industry_show_delivered_days" comment:"Number of days a delivered industry job is still shown on the industry page. (default: 7)"`
+	IndustryNotifyReady                 bool   `ini:"dharma_industry_notify_ready" comment:"When true, the authoritative character sends an in-game mail to members when their industry jobs are ready to be delivered. (default: false)"`
+	IndustryNotifyMaxMails              int    `ini:"dharma_industry_notify_max_mails" comment:"Most in-game mails sent each time industry jobs are fetched, as ESI refuses mail sent too quickly. Members not mailed are mailed by a later fetch. (default: 4)"`
+	MiningSyncPeriodicCheck             int    `ini:"dharma_mining_sync_periodic_hours" comment:"Every X hours, fetch the ledgers of the managed corporation's moon mining observers from ESI. (default: 1)"`
+	MiningTaxPercent                    int    `ini:"dharma_mining_tax_percent" comment:"Percentage of the value of the ore mined at the corporation's observers that members owe, unless a director sets a different rate for them. (default: 10)"`
 
 	NPreview     int `ini:"dharma_length_post_preview" comment:"Number of preview texts to display per tag (default: 3)"`
 	LenPreview   int `ini:"dharma_length_post_preview" comment:"The length of preview text to display (default: 80)"`
 	MaxHTMLDepth int `ini:"dharma_max_html_parsing_depth" comment:"The deepest HTML parsing allowed before abandoning (default: 255)"`
 	NListThreads int `ini:"dharma_n_threads_in_category_pages" comment:"The number of threads to show per page in a forum category (default: 25)"`
+	NKillmails   int `ini:"dharma_n_killmails_in_character_pages" comment:"The number of killmails to show per page on a character's killboard (default: 50)"`
 
 	MailerHost           string `ini:"dharma_mailer_host" comment:"Host name of the SMTP mailer service"`
 	MailerPort           int    `ini:"dharma_mailer_port" comment:"Port of the SMTP mailer service"`
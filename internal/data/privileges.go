@@ -16,12 +16,18 @@
 
 package data
 
-type Privileges struct{}
+type Privileges struct {
+	// KOSEditor permits adding to and removing from the kill on sight
+	// list.
+	KOSEditor bool
+}
 
 func DefaultPrivileges() *Privileges {
 	return &Privileges{}
 }
 
 func DefaultAdminPrivileges() *Privileges {
-	return &Privileges{}
+	return &Privileges{
+		KOSEditor: true,
+	}
 }
@@ -18,6 +18,8 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"image"
 	"math"
@@ -27,6 +29,7 @@ import (
 
 	"github.com/cjslep/dharma/esi"
 	"github.com/cjslep/dharma/internal/data"
+	"github.com/cjslep/dharma/internal/sde"
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/go-fed/apcore/app"
 	"github.com/pkg/errors"
@@ -67,14 +70,21 @@ func (d *DB) SetEvePublicKeys(c context.Context, o esi.OAuthKeysMetadata) error
 	return txb.Do(c)
 }
 
-func (d *DB) GetEvePublicKeys(c context.Context) (*esi.OAuthKeysMetadata, error) {
+// GetRecentEvePublicKeys obtains up to n of the most recently fetched sets of
+// EVE public keys, newest first.
+func (d *DB) GetRecentEvePublicKeys(c context.Context, n int) ([]*esi.OAuthKeysMetadata, error) {
 	// TODO: See if in-memory cache is needed for per-request latency
-	o := &esi.OAuthKeysMetadata{}
+	var ks []*esi.OAuthKeysMetadata
 	txb := d.db.Begin()
-	txb.QueryOneRow(d.pg.GetLatestEvePublicKey(), func(r app.SingleRow) error {
-		return r.Scan(o)
-	})
-	return o, txb.Do(c)
+	txb.Query(d.pg.GetRecentEvePublicKeys(), func(r app.SingleRow) error {
+		o := &esi.OAuthKeysMetadata{}
+		if err := r.Scan(o); err != nil {
+			return err
+		}
+		ks = append(ks, o)
+		return nil
+	}, n)
+	return ks, txb.Do(c)
 }
 
 const (
@@ -98,6 +108,51 @@ func (d *DB) GetEveToken(c context.Context, charID int32) (*esi.Tokens, error) {
 	return t, txb.Do(c)
 }
 
+// GetEveTokenForUser obtains the character's tokens only if the user linked
+// the character. The tokens have a zero CID otherwise.
+func (d *DB) GetEveTokenForUser(c context.Context, userID string, charID int32) (*esi.Tokens, error) {
+	t := &esi.Tokens{}
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.GetEveTokenForUser(), func(r app.SingleRow) error {
+		return r.Scan(t)
+	}, userID, charID)
+	return t, txb.Do(c)
+}
+
+// DeleteEveToken unlinks the character from the user, removing its tokens and
+// the roles that were fetched with them.
+func (d *DB) DeleteEveToken(c context.Context, userID string, charID int32) error {
+	txb := d.db.Begin()
+	txb.ExecOneRow(d.pg.DeleteEveToken(), userID, charID)
+	txb.Exec(d.pg.DeleteCharacterRoles(), charID)
+	return txb.Do(c)
+}
+
+func (d *DB) GetUserForCharacter(c context.Context, charID int32) (string, error) {
+	var userID string
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.GetUserForCharacter(), func(r app.SingleRow) error {
+		return r.Scan(&userID)
+	}, charID)
+	return userID, txb.Do(c)
+}
+
+// GetCharactersWithScope returns the characters whose tokens were granted
+// the scope.
+func (d *DB) GetCharactersWithScope(c context.Context, scope string) (ids []int32, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetCharactersWithScope(), func(r app.SingleRow) error {
+		var id int32
+		if err := r.Scan(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		return nil
+	}, scope)
+	err = txb.Do(c)
+	return
+}
+
 func (d *DB) MarkAllTokensNeedRescope(c context.Context) error {
 	txb := d.db.Begin()
 	txb.Exec(d.pg.MarkAllTokensWithState(), tokenRescopeState)
@@ -382,6 +437,124 @@ func (d *DB) DeleteMedia(c context.Context, id string) error {
 	return txb.Do(c)
 }
 
+var _ esi.CacheStore = &DB{}
+
+func (d *DB) GetESIResponse(c context.Context, key string) (b []byte, found bool, err error) {
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.GetESICacheEntry(), func(r app.SingleRow) error {
+		found = true
+		return r.Scan(&b)
+	}, key)
+	err = txb.Do(c)
+	return
+}
+
+func (d *DB) SetESIResponse(c context.Context, key string, b []byte, expires time.Time) error {
+	txb := d.db.Begin()
+	txb.ExecOneRow(d.pg.SetESICacheEntry(), key, expires, b)
+	return txb.Do(c)
+}
+
+func (d *DB) DeleteESIResponse(c context.Context, key string) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.DeleteESICacheEntry(), key)
+	return txb.Do(c)
+}
+
+func (d *DB) DeleteESIResponsesExpiredBefore(c context.Context, t time.Time) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.DeleteESICacheEntriesExpiredBefore(), t)
+	return txb.Do(c)
+}
+
+func (d *DB) SetEveEntities(c context.Context, es []esi.Entity, expires time.Time) error {
+	txb := d.db.Begin()
+	for _, e := range es {
+		txb.ExecOneRow(d.pg.SetEveEntity(), e.ID, e.Category, e.Name, expires)
+	}
+	return txb.Do(c)
+}
+
+func (d *DB) GetUnexpiredEveEntities(c context.Context, ids []int32) (es []esi.Entity, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetUnexpiredEveEntities(), func(r app.SingleRow) error {
+		var e esi.Entity
+		if err := r.Scan(&e.ID, &e.Category, &e.Name); err != nil {
+			return err
+		}
+		es = append(es, e)
+		return nil
+	}, ids)
+	err = txb.Do(c)
+	return
+}
+
+// GetUnexpiredEveEntitiesByName matches names case-insensitively, so the
+// names must already be lowercase.
+func (d *DB) GetUnexpiredEveEntitiesByName(c context.Context, lowerNames []string) (es []esi.Entity, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetUnexpiredEveEntitiesByName(), func(r app.SingleRow) error {
+		var e esi.Entity
+		if err := r.Scan(&e.ID, &e.Category, &e.Name); err != nil {
+			return err
+		}
+		es = append(es, e)
+		return nil
+	}, lowerNames)
+	err = txb.Do(c)
+	return
+}
+
+// SetEveUnknownNames remembers the lowercase names that match no entity until
+// they expire, forgetting any that already have.
+func (d *DB) SetEveUnknownNames(c context.Context, lowerNames []string, expires time.Time) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.DeleteExpiredEveUnknownNames())
+	for _, name := range lowerNames {
+		txb.ExecOneRow(d.pg.SetEveUnknownName(), name, expires)
+	}
+	return txb.Do(c)
+}
+
+func (d *DB) GetUnexpiredEveUnknownNames(c context.Context, lowerNames []string) (names []string, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetUnexpiredEveUnknownNames(), func(r app.SingleRow) error {
+		var name string
+		if err := r.Scan(&name); err != nil {
+			return err
+		}
+		names = append(names, name)
+		return nil
+	}, lowerNames)
+	err = txb.Do(c)
+	return
+}
+
+// SetEveUnknownIDs remembers the IDs that name no entity until they expire,
+// forgetting any that already have.
+func (d *DB) SetEveUnknownIDs(c context.Context, ids []int32, expires time.Time) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.DeleteExpiredEveUnknownIDs())
+	for _, id := range ids {
+		txb.ExecOneRow(d.pg.SetEveUnknownID(), id, expires)
+	}
+	return txb.Do(c)
+}
+
+func (d *DB) GetUnexpiredEveUnknownIDs(c context.Context, ids []int32) (unknown []int32, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetUnexpiredEveUnknownIDs(), func(r app.SingleRow) error {
+		var id int32
+		if err := r.Scan(&id); err != nil {
+			return err
+		}
+		unknown = append(unknown, id)
+		return nil
+	}, ids)
+	err = txb.Do(c)
+	return
+}
+
 func (d *DB) setApplicationState(c context.Context, k, v string) error {
 	txb := d.db.Begin()
 	txb.ExecOneRow(d.pg.SetApplicationStateKV(), k, v)
@@ -520,3 +693,1236 @@ func stateValueToBool(s string) (bool, error) {
 		return false, errors.Errorf("error parsing bool for state value: %s", s)
 	}
 }
+
+// SyncCorporationMembers records the characters that joined since the last
+// sync, and the ones no longer in the member list as having left.
+func (d *DB) SyncCorporationMembers(c context.Context, charIDs []int32) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.MarkCorporationMembersLeft(), charIDs)
+	txb.Exec(d.pg.InsertCorporationMembersJoined(), charIDs)
+	return txb.Do(c)
+}
+
+type RosterEntry struct {
+	CharacterID int32
+	Joined      time.Time
+	// Left is nil for current members.
+	Left *time.Time
+	// UserID and Username are empty if no account holds the character's
+	// tokens.
+	UserID   string
+	Username string
+}
+
+// GetCorporationRoster obtains the current members, along with those that left
+// after the given time.
+func (d *DB) GetCorporationRoster(c context.Context, leftAfter time.Time) (r []RosterEntry, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetCorporationRoster(), func(row app.SingleRow) error {
+		var e RosterEntry
+		var userID, username sql.NullString
+		var left sql.NullTime
+		if err := row.Scan(&e.CharacterID, &e.Joined, &left, &userID, &username); err != nil {
+			return err
+		}
+		if left.Valid {
+			e.Left = &left.Time
+		}
+		e.UserID = userID.String
+		e.Username = username.String
+		r = append(r, e)
+		return nil
+	}, leftAfter)
+	err = txb.Do(c)
+	return
+}
+
+// GetKnownKillmailIDs returns which of the killmails are already stored.
+func (d *DB) GetKnownKillmailIDs(c context.Context, ids []int32) (known []int32, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetKnownKillmailIDs(), func(r app.SingleRow) error {
+		var id int32
+		if err := r.Scan(&id); err != nil {
+			return err
+		}
+		known = append(known, id)
+		return nil
+	}, ids)
+	err = txb.Do(c)
+	return
+}
+
+// InsertKillmails stores the killmails along with their attackers and the
+// victim's items. The killmails must not already be stored.
+func (d *DB) InsertKillmails(c context.Context, kms []*esi.Killmail) error {
+	txb := d.db.Begin()
+	for _, km := range kms {
+		v := km.Victim
+		txb.ExecOneRow(d.pg.InsertKillmail(), km.ID, km.Hash, km.Time, km.SolarSystemID, km.MoonID, km.WarID, v.CharacterID, v.CorporationID, v.AllianceID, v.FactionID, v.ShipTypeID, v.DamageTaken)
+		for _, a := range km.Attackers {
+			txb.ExecOneRow(d.pg.InsertKillmailAttacker(), km.ID, a.CharacterID, a.CorporationID, a.AllianceID, a.FactionID, a.ShipTypeID, a.WeaponTypeID, a.DamageDone, a.FinalBlow, a.SecurityStatus)
+		}
+		idx := 0
+		for _, i := range v.Items {
+			parent := idx
+			txb.ExecOneRow(d.pg.InsertKillmailItem(), km.ID, idx, nil, i.TypeID, i.Flag, i.Singleton, i.QuantityDestroyed, i.QuantityDropped)
+			idx++
+			for _, n := range i.Items {
+				txb.ExecOneRow(d.pg.InsertKillmailItem(), km.ID, idx, parent, n.TypeID, n.Flag, n.Singleton, n.QuantityDestroyed, n.QuantityDropped)
+				idx++
+			}
+		}
+	}
+	return txb.Do(c)
+}
+
+// KillmailSummary is enough of a killmail to list it on a killboard.
+type KillmailSummary struct {
+	ID                     int32
+	Time                   time.Time
+	SolarSystemID          int32
+	VictimCharacterID      int32
+	VictimCorporationID    int32
+	VictimAllianceID       int32
+	VictimShipTypeID       int32
+	NAttackers             int
+	FinalBlowCharacterID   int32
+	FinalBlowCorporationID int32
+}
+
+func scanKillmailSummary(r app.SingleRow) (k KillmailSummary, err error) {
+	err = r.Scan(&k.ID, &k.Time, &k.SolarSystemID, &k.VictimCharacterID, &k.VictimCorporationID, &k.VictimAllianceID, &k.VictimShipTypeID, &k.NAttackers, &k.FinalBlowCharacterID, &k.FinalBlowCorporationID)
+	return
+}
+
+// GetKillmailSummariesBetween obtains the killmails in [start, end), most
+// recent first.
+func (d *DB) GetKillmailSummariesBetween(c context.Context, start, end time.Time) (ks []KillmailSummary, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetKillmailSummariesBetween(), func(r app.SingleRow) error {
+		k, err := scanKillmailSummary(r)
+		if err != nil {
+			return err
+		}
+		ks = append(ks, k)
+		return nil
+	}, start, end)
+	err = txb.Do(c)
+	return
+}
+
+// GetKillmailSummariesForCharacter obtains the n most recent killmails on the
+// page where the character was either the victim or an attacker.
+func (d *DB) GetKillmailSummariesForCharacter(c context.Context, charID int32, n, page int) (ks []KillmailSummary, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetKillmailSummariesForCharacter(), func(r app.SingleRow) error {
+		k, err := scanKillmailSummary(r)
+		if err != nil {
+			return err
+		}
+		ks = append(ks, k)
+		return nil
+	}, charID, n, n*page)
+	err = txb.Do(c)
+	return
+}
+
+// GetKillmail obtains a stored killmail in full, or nil if it is not stored.
+func (d *DB) GetKillmail(c context.Context, id int32) (km *esi.Killmail, err error) {
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.GetKillmail(), func(r app.SingleRow) error {
+		km = &esi.Killmail{}
+		v := &km.Victim
+		return r.Scan(&km.ID, &km.Hash, &km.Time, &km.SolarSystemID, &km.MoonID, &km.WarID, &v.CharacterID, &v.CorporationID, &v.AllianceID, &v.FactionID, &v.ShipTypeID, &v.DamageTaken)
+	}, id)
+	var as []esi.KillmailAttacker
+	txb.Query(d.pg.GetKillmailAttackers(), func(r app.SingleRow) error {
+		var a esi.KillmailAttacker
+		if err := r.Scan(&a.CharacterID, &a.CorporationID, &a.AllianceID, &a.FactionID, &a.ShipTypeID, &a.WeaponTypeID, &a.DamageDone, &a.FinalBlow, &a.SecurityStatus); err != nil {
+			return err
+		}
+		as = append(as, a)
+		return nil
+	}, id)
+	var items []esi.KillmailItem
+	parentOf := make(map[int]int)
+	txb.Query(d.pg.GetKillmailItems(), func(r app.SingleRow) error {
+		var i esi.KillmailItem
+		var idx int
+		var parent sql.NullInt32
+		if err := r.Scan(&idx, &parent, &i.TypeID, &i.Flag, &i.Singleton, &i.QuantityDestroyed, &i.QuantityDropped); err != nil {
+			return err
+		}
+		if parent.Valid {
+			p, ok := parentOf[int(parent.Int32)]
+			if !ok {
+				return errors.Errorf("killmail %d item %d has unknown parent %d", id, idx, parent.Int32)
+			}
+			items[p].Items = append(items[p].Items, i)
+		} else {
+			parentOf[idx] = len(items)
+			items = append(items, i)
+		}
+		return nil
+	}, id)
+	if err = txb.Do(c); err != nil || km == nil {
+		return nil, err
+	}
+	km.Attackers = as
+	km.Victim.Items = items
+	return
+}
+
+// GetLatestWalletIDs returns the greatest journal and transaction IDs stored
+// for the wallet division, or zero if there are none.
+func (d *DB) GetLatestWalletIDs(c context.Context, division int32) (journalID, transactionID int64, err error) {
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.GetLatestWalletJournalID(), func(r app.SingleRow) error {
+		return r.Scan(&journalID)
+	}, division)
+	txb.QueryOneRow(d.pg.GetLatestWalletTransactionID(), func(r app.SingleRow) error {
+		return r.Scan(&transactionID)
+	}, division)
+	err = txb.Do(c)
+	return
+}
+
+// InsertWalletHistory stores the wallet division's journal entries and
+// transactions, ignoring any already stored.
+func (d *DB) InsertWalletHistory(c context.Context, division int32, js []esi.JournalEntry, ts []esi.Transaction) error {
+	txb := d.db.Begin()
+	for _, j := range js {
+		txb.Exec(d.pg.InsertWalletJournalEntry(), division, j.ID, j.Date, j.RefType, j.Amount, j.Balance, j.FirstPartyID, j.SecondPartyID, j.ContextID, j.ContextIDType, j.Description, j.Reason, j.Tax, j.TaxReceiverID)
+	}
+	for _, t := range ts {
+		txb.Exec(d.pg.InsertWalletTransaction(), division, t.ID, t.Date, t.TypeID, t.Quantity, t.UnitPrice, t.ClientID, t.LocationID, t.IsBuy, t.JournalRefID)
+	}
+	return txb.Do(c)
+}
+
+type RefTypeIncome struct {
+	RefType string
+	Amount  float64
+}
+
+// GetWalletIncomeByRefType sums the income into all wallet divisions since
+// the given time for each kind of journal entry.
+func (d *DB) GetWalletIncomeByRefType(c context.Context, since time.Time) (is []RefTypeIncome, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetWalletIncomeByRefType(), func(r app.SingleRow) error {
+		var i RefTypeIncome
+		if err := r.Scan(&i.RefType, &i.Amount); err != nil {
+			return err
+		}
+		is = append(is, i)
+		return nil
+	}, since)
+	err = txb.Do(c)
+	return
+}
+
+type MemberTax struct {
+	Month       time.Time
+	CharacterID int32
+	Amount      float64
+}
+
+// GetWalletTaxByMember sums, for each month since the given time, the income
+// of the given kinds of journal entries that involved a current or former
+// member of the corporation.
+func (d *DB) GetWalletTaxByMember(c context.Context, since time.Time, refTypes []string) (ts []MemberTax, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetWalletTaxByMember(), func(r app.SingleRow) error {
+		var t MemberTax
+		if err := r.Scan(&t.Month, &t.CharacterID, &t.Amount); err != nil {
+			return err
+		}
+		ts = append(ts, t)
+		return nil
+	}, since, refTypes)
+	err = txb.Do(c)
+	return
+}
+
+// FactionWarfareSnapshot is the corporation's faction warfare statistics as
+// of a day.
+type FactionWarfareSnapshot struct {
+	Date time.Time
+	esi.FactionWarfareStats
+}
+
+// SetFactionWarfareStats stores the statistics as the snapshot for the day,
+// replacing any taken earlier that day.
+func (d *DB) SetFactionWarfareStats(c context.Context, day time.Time, s *esi.FactionWarfareStats) error {
+	txb := d.db.Begin()
+	txb.ExecOneRow(d.pg.SetFactionWarfareStats(), day, s.FactionID, s.EnlistedOn, s.Pilots, s.KillsYesterday, s.KillsLastWeek, s.KillsTotal, s.VPYesterday, s.VPLastWeek, s.VPTotal)
+	return txb.Do(c)
+}
+
+// GetFactionWarfareStatsSince obtains the daily snapshots since the day,
+// oldest first.
+func (d *DB) GetFactionWarfareStatsSince(c context.Context, day time.Time) (ss []FactionWarfareSnapshot, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetFactionWarfareStatsSince(), func(r app.SingleRow) error {
+		var s FactionWarfareSnapshot
+		if err := r.Scan(&s.Date, &s.FactionID, &s.EnlistedOn, &s.Pilots, &s.KillsYesterday, &s.KillsLastWeek, &s.KillsTotal, &s.VPYesterday, &s.VPLastWeek, &s.VPTotal); err != nil {
+			return err
+		}
+		ss = append(ss, s)
+		return nil
+	}, day)
+	err = txb.Do(c)
+	return
+}
+
+// GetKnownCalendarEventIDs returns which of the calendar events are already
+// stored.
+func (d *DB) GetKnownCalendarEventIDs(c context.Context, ids []int32) (known []int32, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetKnownCalendarEventIDs(), func(r app.SingleRow) error {
+		var id int32
+		if err := r.Scan(&id); err != nil {
+			return err
+		}
+		known = append(known, id)
+		return nil
+	}, ids)
+	err = txb.Do(c)
+	return
+}
+
+// SetCalendarEvents stores the character's calendar events and responses.
+// Events with details replace any stored ones, while the rest must already be
+// stored and only have their summary updated.
+func (d *DB) SetCalendarEvents(c context.Context, charID int32, detailed, summaries []esi.CalendarEvent) error {
+	txb := d.db.Begin()
+	for _, e := range detailed {
+		txb.ExecOneRow(d.pg.SetCalendarEvent(), e.ID, e.Date, int32(e.Duration/time.Minute), e.Title, e.Importance, e.OwnerID, e.OwnerName, e.OwnerType, e.Text)
+	}
+	for _, e := range summaries {
+		txb.ExecOneRow(d.pg.UpdateCalendarEventSummary(), e.ID, e.Date, e.Title, e.Importance)
+	}
+	for _, es := range [][]esi.CalendarEvent{detailed, summaries} {
+		for _, e := range es {
+			txb.ExecOneRow(d.pg.SetCalendarResponse(), e.ID, charID, e.Response)
+		}
+	}
+	return txb.Do(c)
+}
+
+// SetCalendarResponse records the character's response to a stored event.
+func (d *DB) SetCalendarResponse(c context.Context, eventID, charID int32, response string) error {
+	txb := d.db.Begin()
+	txb.ExecOneRow(d.pg.SetCalendarResponse(), eventID, charID, response)
+	return txb.Do(c)
+}
+
+// HasCalendarResponse determines whether the character was invited to the
+// event, as of the last time their calendar was synced.
+func (d *DB) HasCalendarResponse(c context.Context, eventID, charID int32) (has bool, err error) {
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.HasCalendarResponse(), func(r app.SingleRow) error {
+		has = true
+		return nil
+	}, eventID, charID)
+	err = txb.Do(c)
+	return
+}
+
+// CalendarEventSummary is a stored calendar event along with a tally of the
+// responses to it.
+type CalendarEventSummary struct {
+	esi.CalendarEvent
+	NAccepted  int
+	NTentative int
+	NDeclined  int
+}
+
+// GetCalendarEventsOwnedBySince obtains the events owned by the corporation,
+// alliance, or other owner that start after the given time, soonest first.
+// Each event's Response is the given character's, if any.
+func (d *DB) GetCalendarEventsOwnedBySince(c context.Context, ownerID int32, since time.Time, charID int32) (es []CalendarEventSummary, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetCalendarEventsOwnedBySince(), func(r app.SingleRow) error {
+		var e CalendarEventSummary
+		var minutes int32
+		if err := r.Scan(&e.ID, &e.Date, &minutes, &e.Title, &e.Importance, &e.OwnerID, &e.OwnerName, &e.OwnerType, &e.Text, &e.NAccepted, &e.NTentative, &e.NDeclined, &e.Response); err != nil {
+			return err
+		}
+		e.Duration = time.Duration(minutes) * time.Minute
+		es = append(es, e)
+		return nil
+	}, ownerID, since, charID)
+	err = txb.Do(c)
+	return
+}
+
+// CharacterRoles are the roles a character held in their corporation when
+// last fetched.
+type CharacterRoles struct {
+	CharacterID   int32
+	CorporationID int32
+	// CEO is whether the character ran their corporation.
+	CEO     bool
+	Roles   []string
+	Updated time.Time
+}
+
+// IsDirector determines whether the character was the CEO or a director.
+func (r CharacterRoles) IsDirector() bool {
+	return r.CEO || r.HasRole(esi.RoleDirector)
+}
+
+// HasRole determines whether the character held the role.
+func (r CharacterRoles) HasRole(role string) bool {
+	for _, h := range r.Roles {
+		if h == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *DB) SetCharacterRoles(c context.Context, r CharacterRoles) error {
+	b, err := json.Marshal(r.Roles)
+	if err != nil {
+		return err
+	}
+	txb := d.db.Begin()
+	txb.ExecOneRow(d.pg.SetCharacterRoles(), r.CharacterID, r.CorporationID, r.CEO, b)
+	return txb.Do(c)
+}
+
+// GetCharacterRoles obtains the character's stored roles, which have a zero
+// Updated time if they were never fetched.
+func (d *DB) GetCharacterRoles(c context.Context, charID int32) (r CharacterRoles, err error) {
+	r.CharacterID = charID
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.GetCharacterRoles(), func(row app.SingleRow) error {
+		var b []byte
+		if err := row.Scan(&r.CorporationID, &r.CEO, &b, &r.Updated); err != nil {
+			return err
+		}
+		return json.Unmarshal(b, &r.Roles)
+	}, charID)
+	err = txb.Do(c)
+	return
+}
+
+// GetSDEBuild obtains the release of the Static Data Export that was last
+// imported, which has a zero Number if none has been.
+func (d *DB) GetSDEBuild(c context.Context) (b sde.Build, err error) {
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.GetSDEBuild(), func(r app.SingleRow) error {
+		return r.Scan(&b.Number, &b.ReleaseDate)
+	})
+	err = txb.Do(c)
+	return
+}
+
+// ClearSDE removes all of the imported Static Data Export, and the build it
+// was, so that a new release can be inserted.
+func (d *DB) ClearSDE(c context.Context) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.DeleteSDEBuild())
+	txb.Exec(d.pg.DeleteSDETypes())
+	txb.Exec(d.pg.DeleteSDEGroups())
+	txb.Exec(d.pg.DeleteSDEMarketGroups())
+	txb.Exec(d.pg.DeleteSDERegions())
+	txb.Exec(d.pg.DeleteSDESolarSystems())
+	txb.Exec(d.pg.DeleteSDEStargates())
+	return txb.Do(c)
+}
+
+func (d *DB) InsertSDETypes(c context.Context, ts []sde.Type) error {
+	txb := d.db.Begin()
+	for _, t := range ts {
+		txb.Exec(d.pg.InsertSDEType(), t.ID, t.GroupID, t.MarketGroupID, t.Name, t.Volume, t.Published)
+	}
+	return txb.Do(c)
+}
+
+func (d *DB) InsertSDEGroups(c context.Context, gs []sde.Group) error {
+	txb := d.db.Begin()
+	for _, g := range gs {
+		txb.Exec(d.pg.InsertSDEGroup(), g.ID, g.CategoryID, g.Name, g.Published)
+	}
+	return txb.Do(c)
+}
+
+func (d *DB) InsertSDEMarketGroups(c context.Context, mgs []sde.MarketGroup) error {
+	txb := d.db.Begin()
+	for _, mg := range mgs {
+		txb.Exec(d.pg.InsertSDEMarketGroup(), mg.ID, mg.ParentID, mg.Name, mg.HasTypes)
+	}
+	return txb.Do(c)
+}
+
+func (d *DB) InsertSDERegions(c context.Context, rs []sde.Region) error {
+	txb := d.db.Begin()
+	for _, r := range rs {
+		txb.Exec(d.pg.InsertSDERegion(), r.ID, r.Name)
+	}
+	return txb.Do(c)
+}
+
+func (d *DB) InsertSDESolarSystems(c context.Context, ss []sde.SolarSystem) error {
+	txb := d.db.Begin()
+	for _, s := range ss {
+		txb.Exec(d.pg.InsertSDESolarSystem(), s.ID, s.ConstellationID, s.RegionID, s.Name, s.Security)
+	}
+	return txb.Do(c)
+}
+
+func (d *DB) InsertSDEStargates(c context.Context, sgs []sde.Stargate) error {
+	txb := d.db.Begin()
+	for _, sg := range sgs {
+		txb.Exec(d.pg.InsertSDEStargate(), sg.ID, sg.SolarSystemID, sg.DestinationID, sg.DestinationSolarSystemID)
+	}
+	return txb.Do(c)
+}
+
+// SetSDEBuild records the release of the Static Data Export that was imported.
+func (d *DB) SetSDEBuild(c context.Context, b sde.Build) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.SetSDEBuild(), b.Number, b.ReleaseDate)
+	return txb.Do(c)
+}
+
+func (d *DB) GetSDETypes(c context.Context, ids []int32) (m map[int32]sde.Type, err error) {
+	m = make(map[int32]sde.Type, len(ids))
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetSDETypes(), func(r app.SingleRow) error {
+		var t sde.Type
+		if err := r.Scan(&t.ID, &t.GroupID, &t.MarketGroupID, &t.Name, &t.Volume, &t.Published); err != nil {
+			return err
+		}
+		m[t.ID] = t
+		return nil
+	}, ids)
+	err = txb.Do(c)
+	return
+}
+
+func (d *DB) GetSDEGroups(c context.Context, ids []int32) (m map[int32]sde.Group, err error) {
+	m = make(map[int32]sde.Group, len(ids))
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetSDEGroups(), func(r app.SingleRow) error {
+		var g sde.Group
+		if err := r.Scan(&g.ID, &g.CategoryID, &g.Name, &g.Published); err != nil {
+			return err
+		}
+		m[g.ID] = g
+		return nil
+	}, ids)
+	err = txb.Do(c)
+	return
+}
+
+func (d *DB) GetSDEMarketGroups(c context.Context, ids []int32) (m map[int32]sde.MarketGroup, err error) {
+	m = make(map[int32]sde.MarketGroup, len(ids))
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetSDEMarketGroups(), func(r app.SingleRow) error {
+		var mg sde.MarketGroup
+		if err := r.Scan(&mg.ID, &mg.ParentID, &mg.Name, &mg.HasTypes); err != nil {
+			return err
+		}
+		m[mg.ID] = mg
+		return nil
+	}, ids)
+	err = txb.Do(c)
+	return
+}
+
+func (d *DB) GetSDERegions(c context.Context, ids []int32) (m map[int32]sde.Region, err error) {
+	m = make(map[int32]sde.Region, len(ids))
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetSDERegions(), func(r app.SingleRow) error {
+		var rg sde.Region
+		if err := r.Scan(&rg.ID, &rg.Name); err != nil {
+			return err
+		}
+		m[rg.ID] = rg
+		return nil
+	}, ids)
+	err = txb.Do(c)
+	return
+}
+
+func (d *DB) GetSDESolarSystems(c context.Context, ids []int32) (m map[int32]sde.SolarSystem, err error) {
+	m = make(map[int32]sde.SolarSystem, len(ids))
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetSDESolarSystems(), func(r app.SingleRow) error {
+		var s sde.SolarSystem
+		if err := r.Scan(&s.ID, &s.ConstellationID, &s.RegionID, &s.Name, &s.Security); err != nil {
+			return err
+		}
+		m[s.ID] = s
+		return nil
+	}, ids)
+	err = txb.Do(c)
+	return
+}
+
+// GetSDESolarSystemByName finds the solar system by its English name, ignoring
+// case. The returned solar system has a zero ID if there is none.
+func (d *DB) GetSDESolarSystemByName(c context.Context, name string) (s sde.SolarSystem, err error) {
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.GetSDESolarSystemByName(), func(r app.SingleRow) error {
+		return r.Scan(&s.ID, &s.ConstellationID, &s.RegionID, &s.Name, &s.Security)
+	}, name)
+	err = txb.Do(c)
+	return
+}
+
+// SearchSDEMarketTypes finds up to n published types sold on the market whose
+// English name contains the query, shortest names first.
+func (d *DB) SearchSDEMarketTypes(c context.Context, query string, n int) (ts []sde.Type, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.SearchSDEMarketTypes(), func(r app.SingleRow) error {
+		var t sde.Type
+		if err := r.Scan(&t.ID, &t.GroupID, &t.MarketGroupID, &t.Name, &t.Volume, &t.Published); err != nil {
+			return err
+		}
+		ts = append(ts, t)
+		return nil
+	}, query, n)
+	err = txb.Do(c)
+	return
+}
+
+func (d *DB) GetSDEStargatesInSolarSystem(c context.Context, systemID int32) (sgs []sde.Stargate, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetSDEStargatesInSolarSystem(), func(r app.SingleRow) error {
+		var sg sde.Stargate
+		if err := r.Scan(&sg.ID, &sg.SolarSystemID, &sg.DestinationID, &sg.DestinationSolarSystemID); err != nil {
+			return err
+		}
+		sgs = append(sgs, sg)
+		return nil
+	}, systemID)
+	err = txb.Do(c)
+	return
+}
+
+func (d *DB) SetMarketPrices(c context.Context, ps []esi.MarketPrice) error {
+	txb := d.db.Begin()
+	for _, p := range ps {
+		txb.Exec(d.pg.SetMarketPrice(), p.TypeID, p.AdjustedPrice, p.AveragePrice)
+	}
+	return txb.Do(c)
+}
+
+func (d *DB) GetMarketPrices(c context.Context, typeIDs []int32) (m map[int32]esi.MarketPrice, err error) {
+	m = make(map[int32]esi.MarketPrice, len(typeIDs))
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetMarketPrices(), func(r app.SingleRow) error {
+		var p esi.MarketPrice
+		if err := r.Scan(&p.TypeID, &p.AdjustedPrice, &p.AveragePrice); err != nil {
+			return err
+		}
+		m[p.TypeID] = p
+		return nil
+	}, typeIDs)
+	err = txb.Do(c)
+	return
+}
+
+// HubPrice is what a type buys and sells for at a trade hub, summarized from
+// its order book.
+type HubPrice struct {
+	LocationID int64
+	TypeID     int32
+	Buy        float64
+	Sell       float64
+	BuyVolume  int64
+	SellVolume int64
+	Updated    time.Time
+}
+
+// Split is the price halfway between buying and selling. When only one side
+// of the order book has orders, it is that side's price.
+func (h HubPrice) Split() float64 {
+	if h.BuyVolume == 0 {
+		return h.Sell
+	} else if h.SellVolume == 0 {
+		return h.Buy
+	}
+	return (h.Buy + h.Sell) / 2
+}
+
+// ReplaceHubPrices replaces all of the trade hub's prices.
+func (d *DB) ReplaceHubPrices(c context.Context, locationID int64, hps []HubPrice) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.DeleteMarketHubPrices(), locationID)
+	for _, h := range hps {
+		txb.Exec(d.pg.InsertMarketHubPrice(), locationID, h.TypeID, h.Buy, h.Sell, h.BuyVolume, h.SellVolume)
+	}
+	return txb.Do(c)
+}
+
+func (d *DB) GetHubPrices(c context.Context, locationID int64, typeIDs []int32) (m map[int32]HubPrice, err error) {
+	m = make(map[int32]HubPrice, len(typeIDs))
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetMarketHubPrices(), func(r app.SingleRow) error {
+		h := HubPrice{LocationID: locationID}
+		if err := r.Scan(&h.TypeID, &h.Buy, &h.Sell, &h.BuyVolume, &h.SellVolume, &h.Updated); err != nil {
+			return err
+		}
+		m[h.TypeID] = h
+		return nil
+	}, locationID, typeIDs)
+	err = txb.Do(c)
+	return
+}
+
+// CorporationAsset is an item the corporation owned at the time of a snapshot,
+// placed in the station, structure, or solar system ultimately holding it.
+type CorporationAsset struct {
+	ItemID       int64
+	TypeID       int32
+	Quantity     int32
+	LocationFlag string
+	// LocationID is the station, structure, or solar system holding the
+	// item, even when it is inside a container.
+	LocationID   int64
+	LocationType string
+	// Division is the corporation hangar division from 1 to 7, or zero if
+	// the item is not in a corporation hangar.
+	Division int32
+	// ContainerID is the container or ship the item is inside of, or zero
+	// if the item is directly in the hangar or in space.
+	ContainerID     int64
+	ContainerTypeID int32
+	ContainerName   string
+	// Name is what a player named the item, if anything.
+	Name            string
+	IsSingleton     bool
+	IsBlueprintCopy bool
+}
+
+// AssetLocation is a place holding some of the corporation's assets.
+type AssetLocation struct {
+	LocationID   int64
+	LocationType string
+	NAssets      int
+}
+
+// nAssetsPerInsert is the most assets inserted by one statement, well under
+// postgres' limit of 65535 parameters.
+const nAssetsPerInsert = 1000
+
+// InsertCorporationAssetSnapshot stores the assets as the snapshot at the time,
+// and deletes the snapshots from before the retention time.
+func (d *DB) InsertCorporationAssetSnapshot(c context.Context, t time.Time, as []CorporationAsset, retention time.Time) error {
+	txb := d.db.Begin()
+	for len(as) > 0 {
+		n := len(as)
+		if n > nAssetsPerInsert {
+			n = nAssetsPerInsert
+		}
+		args := make([]interface{}, 0, 1+n*nCorporationAssetColumns)
+		args = append(args, t)
+		for _, a := range as[:n] {
+			args = append(args, a.ItemID, a.TypeID, a.Quantity, a.LocationFlag, a.LocationID, a.LocationType, a.Division, a.ContainerID, a.ContainerTypeID, a.ContainerName, a.Name, a.IsSingleton, a.IsBlueprintCopy)
+		}
+		txb.Exec(d.pg.InsertCorporationAssets(n), args...)
+		as = as[n:]
+	}
+	txb.Exec(d.pg.DeleteCorporationAssetsBefore(), retention)
+	return txb.Do(c)
+}
+
+// GetLatestCorporationAssetSnapshot obtains the time of the latest snapshot of
+// the corporation's assets, which is zero if there is none.
+func (d *DB) GetLatestCorporationAssetSnapshot(c context.Context) (t time.Time, err error) {
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.GetLatestCorporationAssetSnapshot(), func(r app.SingleRow) error {
+		return r.Scan(&t)
+	})
+	err = txb.Do(c)
+	return
+}
+
+// GetCorporationAssetLocations obtains the places holding assets in the
+// snapshot, those with the most assets first.
+func (d *DB) GetCorporationAssetLocations(c context.Context, snapshot time.Time) (ls []AssetLocation, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetCorporationAssetLocations(), func(r app.SingleRow) error {
+		var l AssetLocation
+		if err := r.Scan(&l.LocationID, &l.LocationType, &l.NAssets); err != nil {
+			return err
+		}
+		ls = append(ls, l)
+		return nil
+	}, snapshot)
+	err = txb.Do(c)
+	return
+}
+
+// SearchCorporationAssets finds up to n assets in the snapshot. A zero
+// locationID or division matches any, and a non-empty query must be contained
+// in the English type name or the item's name.
+func (d *DB) SearchCorporationAssets(c context.Context, snapshot time.Time, locationID int64, division int32, query string, n int) (as []CorporationAsset, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.SearchCorporationAssets(), func(r app.SingleRow) error {
+		var a CorporationAsset
+		if err := r.Scan(&a.ItemID, &a.TypeID, &a.Quantity, &a.LocationFlag, &a.LocationID, &a.LocationType, &a.Division, &a.ContainerID, &a.ContainerTypeID, &a.ContainerName, &a.Name, &a.IsSingleton, &a.IsBlueprintCopy); err != nil {
+			return err
+		}
+		as = append(as, a)
+		return nil
+	}, snapshot, locationID, division, query, n)
+	err = txb.Do(c)
+	return
+}
+
+// UpsertIndustryJobs stores new industry jobs and updates the progress of
+// those already stored.
+func (d *DB) UpsertIndustryJobs(c context.Context, js []esi.IndustryJob) error {
+	txb := d.db.Begin()
+	for _, j := range js {
+		txb.Exec(d.pg.UpsertIndustryJob(), j.ID, j.ActivityID, j.Status, j.InstallerID, j.FacilityID, j.LocationID, j.BlueprintID, j.BlueprintTypeID, j.BlueprintLocationID, j.OutputLocationID, j.ProductTypeID, j.Runs, j.LicensedRuns, j.SuccessfulRuns, j.Probability, j.Cost, j.Duration, j.StartDate, j.EndDate, j.PauseDate, j.CompletedDate, j.CompletedCharacterID)
+	}
+	return txb.Do(c)
+}
+
+// GetIndustryJobs obtains the jobs that are not yet delivered, along with
+// those delivered after the given time, ending soonest first.
+func (d *DB) GetIndustryJobs(c context.Context, deliveredAfter time.Time) (js []esi.IndustryJob, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetIndustryJobs(), func(r app.SingleRow) error {
+		j, err := scanIndustryJob(r)
+		if err != nil {
+			return err
+		}
+		js = append(js, j)
+		return nil
+	}, deliveredAfter)
+	err = txb.Do(c)
+	return
+}
+
+// GetUnnotifiedReadyIndustryJobs obtains the jobs ready to be delivered whose
+// installers were not yet told, ignoring those that ended before the given
+// time.
+func (d *DB) GetUnnotifiedReadyIndustryJobs(c context.Context, now, endedAfter time.Time) (js []esi.IndustryJob, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetUnnotifiedReadyIndustryJobs(), func(r app.SingleRow) error {
+		j, err := scanIndustryJob(r)
+		if err != nil {
+			return err
+		}
+		js = append(js, j)
+		return nil
+	}, now, endedAfter)
+	err = txb.Do(c)
+	return
+}
+
+// MarkIndustryJobsNotified records that the installers of the jobs were told
+// they are ready.
+func (d *DB) MarkIndustryJobsNotified(c context.Context, ids []int32) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.MarkIndustryJobsNotified(), ids)
+	return txb.Do(c)
+}
+
+func scanIndustryJob(r app.SingleRow) (j esi.IndustryJob, err error) {
+	err = r.Scan(&j.ID, &j.ActivityID, &j.Status, &j.InstallerID, &j.FacilityID, &j.LocationID, &j.BlueprintID, &j.BlueprintTypeID, &j.BlueprintLocationID, &j.OutputLocationID, &j.ProductTypeID, &j.Runs, &j.LicensedRuns, &j.SuccessfulRuns, &j.Probability, &j.Cost, &j.Duration, &j.StartDate, &j.EndDate, &j.PauseDate, &j.CompletedDate, &j.CompletedCharacterID)
+	return
+}
+
+// UpsertMiningLedger stores what was mined at the observer, updating the
+// quantities of days already stored. A character who changed corporations
+// during a day has a row for each corporation.
+func (d *DB) UpsertMiningLedger(c context.Context, observerID int64, es []esi.MiningLedgerEntry) error {
+	txb := d.db.Begin()
+	for _, e := range es {
+		txb.Exec(d.pg.UpsertMiningLedgerEntry(), observerID, e.CharacterID, e.RecordedCorporationID, e.TypeID, e.Day, e.Quantity)
+	}
+	return txb.Do(c)
+}
+
+// MiningTotal is the quantity of a type of ore a character mined.
+type MiningTotal struct {
+	CharacterID int32
+	TypeID      int32
+	Quantity    int64
+	// Value is the ore priced as of each day it was mined.
+	Value float64
+}
+
+// GetMiningTotals obtains the ore each character mined at any observer on the
+// days in [start, end).
+func (d *DB) GetMiningTotals(c context.Context, start, end time.Time) (ts []MiningTotal, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetMiningTotals(), func(r app.SingleRow) error {
+		var t MiningTotal
+		if err := r.Scan(&t.CharacterID, &t.TypeID, &t.Quantity, &t.Value); err != nil {
+			return err
+		}
+		ts = append(ts, t)
+		return nil
+	}, start, end)
+	err = txb.Do(c)
+	return
+}
+
+// MiningOrePrice is what a type of ore was worth on a day it was mined. The
+// StationID is the trade hub it was priced at, which is zero for its
+// universe-wide average price.
+type MiningOrePrice struct {
+	Day       time.Time
+	TypeID    int32
+	StationID int32
+	Price     float64
+}
+
+// InsertMiningOrePrices stores the prices, keeping any already stored for the
+// same day and type so that ore is billed as it was priced when mined.
+func (d *DB) InsertMiningOrePrices(c context.Context, ps []MiningOrePrice) error {
+	txb := d.db.Begin()
+	for _, p := range ps {
+		txb.Exec(d.pg.InsertMiningOrePrice(), p.Day, p.TypeID, p.StationID, p.Price)
+	}
+	return txb.Do(c)
+}
+
+// GetMiningOrePriceStation obtains the trade hub ore mined on the days in
+// [start, end) was last priced at, which is zero if only universe-wide prices
+// were used.
+func (d *DB) GetMiningOrePriceStation(c context.Context, start, end time.Time) (id int32, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetMiningOrePriceStation(), func(r app.SingleRow) error {
+		return r.Scan(&id)
+	}, start, end)
+	err = txb.Do(c)
+	return
+}
+
+// SetMiningTaxRate sets the percentage of the value of the ore they mine that
+// the character owes, instead of the default.
+func (d *DB) SetMiningTaxRate(c context.Context, charID int32, rate float64) error {
+	txb := d.db.Begin()
+	txb.ExecOneRow(d.pg.SetMiningTaxRate(), charID, rate)
+	return txb.Do(c)
+}
+
+// DeleteMiningTaxRate returns the character to the default mining tax rate.
+func (d *DB) DeleteMiningTaxRate(c context.Context, charID int32) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.DeleteMiningTaxRate(), charID)
+	return txb.Do(c)
+}
+
+// GetMiningTaxRates obtains the characters whose mining tax rate is not the
+// default.
+func (d *DB) GetMiningTaxRates(c context.Context) (m map[int32]float64, err error) {
+	m = make(map[int32]float64)
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetMiningTaxRates(), func(r app.SingleRow) error {
+		var id int32
+		var rate float64
+		if err := r.Scan(&id, &rate); err != nil {
+			return err
+		}
+		m[id] = rate
+		return nil
+	})
+	err = txb.Do(c)
+	return
+}
+
+// The statuses of a haul request.
+const (
+	HaulOpen       = "open"
+	HaulClaimed    = "claimed"
+	HaulContracted = "contracted"
+	HaulCompleted  = "completed"
+	HaulCancelled  = "cancelled"
+)
+
+// HaulRequest is a member asking for their items to be hauled, which a hauler
+// claims and then delivers with a courier contract.
+type HaulRequest struct {
+	ID          string
+	Created     time.Time
+	RequesterID int32
+	Pickup      string
+	// PickupLocationID is the station, or zero if Pickup is not one.
+	PickupLocationID int64
+	Dropoff          string
+	// DropoffLocationID is the station, or zero if Dropoff is not one.
+	DropoffLocationID int64
+	Volume            float64
+	Collateral        float64
+	Reward            float64
+	Notes             string
+	Status            string
+	// HaulerID is zero until the request is claimed.
+	HaulerID int32
+	// ContractID is zero until a courier contract is found for the
+	// request.
+	ContractID     int32
+	ContractStatus string
+	Updated        time.Time
+}
+
+func scanHaulRequest(r app.SingleRow) (h HaulRequest, err error) {
+	err = r.Scan(&h.ID, &h.Created, &h.RequesterID, &h.Pickup, &h.PickupLocationID, &h.Dropoff, &h.DropoffLocationID, &h.Volume, &h.Collateral, &h.Reward, &h.Notes, &h.Status, &h.HaulerID, &h.ContractID, &h.ContractStatus, &h.Updated)
+	return
+}
+
+// InsertHaulRequest stores a new open haul request.
+func (d *DB) InsertHaulRequest(c context.Context, h HaulRequest) error {
+	txb := d.db.Begin()
+	txb.ExecOneRow(d.pg.InsertHaulRequest(), h.Created, h.RequesterID, h.Pickup, h.PickupLocationID, h.Dropoff, h.DropoffLocationID, h.Volume, h.Collateral, h.Reward, h.Notes)
+	return txb.Do(c)
+}
+
+// GetHaulRequest obtains the haul request, which is nil if it does not exist.
+func (d *DB) GetHaulRequest(c context.Context, id string) (h *HaulRequest, err error) {
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.GetHaulRequest(), func(r app.SingleRow) error {
+		hr, err := scanHaulRequest(r)
+		h = &hr
+		return err
+	}, id)
+	err = txb.Do(c)
+	return
+}
+
+// GetHaulRequests obtains the unfinished haul requests, along with those
+// finished after the given time, newest first.
+func (d *DB) GetHaulRequests(c context.Context, finishedAfter time.Time) (hs []HaulRequest, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetHaulRequests(), func(r app.SingleRow) error {
+		h, err := scanHaulRequest(r)
+		if err != nil {
+			return err
+		}
+		hs = append(hs, h)
+		return nil
+	}, finishedAfter)
+	err = txb.Do(c)
+	return
+}
+
+// GetUnfinishedHaulRequests obtains the haul requests that are neither
+// completed nor cancelled, oldest first.
+func (d *DB) GetUnfinishedHaulRequests(c context.Context) (hs []HaulRequest, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetUnfinishedHaulRequests(), func(r app.SingleRow) error {
+		h, err := scanHaulRequest(r)
+		if err != nil {
+			return err
+		}
+		hs = append(hs, h)
+		return nil
+	})
+	err = txb.Do(c)
+	return
+}
+
+// GetHaulRequestContractIDs obtains the courier contracts already found for
+// haul requests.
+func (d *DB) GetHaulRequestContractIDs(c context.Context) (m map[int32]bool, err error) {
+	m = make(map[int32]bool)
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetHaulRequestContractIDs(), func(r app.SingleRow) error {
+		var id int32
+		if err := r.Scan(&id); err != nil {
+			return err
+		}
+		m[id] = true
+		return nil
+	})
+	err = txb.Do(c)
+	return
+}
+
+// SetHaulRequestHauler moves the haul request from one status to another
+// along with its hauler, failing if it is no longer in the from status.
+func (d *DB) SetHaulRequestHauler(c context.Context, id, from, to string, haulerID int32) error {
+	txb := d.db.Begin()
+	txb.ExecOneRow(d.pg.SetHaulRequestHauler(), id, from, to, haulerID, time.Now())
+	return txb.Do(c)
+}
+
+// SetHaulRequestContract records the courier contract found for the haul
+// request and how it is progressing. The request is left alone if it is no
+// longer in the from status, as it was changed since it was read.
+func (d *DB) SetHaulRequestContract(c context.Context, id, from, to string, haulerID, contractID int32, contractStatus string) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.SetHaulRequestContract(), id, from, to, haulerID, contractID, contractStatus, time.Now())
+	return txb.Do(c)
+}
+
+// KOSEntry is a character, corporation, or alliance that members are to kill
+// on sight.
+type KOSEntry struct {
+	EntityID int32
+	// Category is the esi category of the entity.
+	Category string
+	Name     string
+	Reason   string
+	// Evidence holds links, one per line.
+	Evidence string
+	AddedBy  int32
+	Created  time.Time
+	Updated  time.Time
+	// Expires is nil if the entry never expires.
+	Expires *time.Time
+}
+
+func scanKOSEntry(r app.SingleRow) (k KOSEntry, err error) {
+	var expires sql.NullTime
+	err = r.Scan(&k.EntityID, &k.Category, &k.Name, &k.Reason, &k.Evidence, &k.AddedBy, &k.Created, &k.Updated, &expires)
+	if expires.Valid {
+		k.Expires = &expires.Time
+	}
+	return
+}
+
+// UpsertKOSEntry adds the entity to the kill on sight list, replacing any
+// entry it already has.
+func (d *DB) UpsertKOSEntry(c context.Context, k KOSEntry) error {
+	var expires sql.NullTime
+	if k.Expires != nil {
+		expires = sql.NullTime{Time: *k.Expires, Valid: true}
+	}
+	txb := d.db.Begin()
+	txb.ExecOneRow(d.pg.UpsertKOSEntry(), k.EntityID, k.Category, k.Name, k.Reason, k.Evidence, k.AddedBy, time.Now(), expires)
+	return txb.Do(c)
+}
+
+func (d *DB) DeleteKOSEntry(c context.Context, entityID int32) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.DeleteKOSEntry(), entityID)
+	return txb.Do(c)
+}
+
+// SearchKOSEntries obtains up to n unexpired entries whose name or reason
+// contains the query, ignoring case. An empty query matches every entry.
+func (d *DB) SearchKOSEntries(c context.Context, query string, n int) (ks []KOSEntry, err error) {
+	txb := d.db.Begin()
+	txb.Query(d.pg.SearchKOSEntries(), func(r app.SingleRow) error {
+		k, err := scanKOSEntry(r)
+		if err != nil {
+			return err
+		}
+		ks = append(ks, k)
+		return nil
+	}, time.Now(), query, n)
+	err = txb.Do(c)
+	return
+}
+
+// GetKOSEntries obtains the unexpired entries of the entities, keyed by their
+// IDs.
+func (d *DB) GetKOSEntries(c context.Context, entityIDs []int32) (m map[int32]KOSEntry, err error) {
+	m = make(map[int32]KOSEntry)
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetKOSEntries(), func(r app.SingleRow) error {
+		k, err := scanKOSEntry(r)
+		if err != nil {
+			return err
+		}
+		m[k.EntityID] = k
+		return nil
+	}, time.Now(), entityIDs)
+	err = txb.Do(c)
+	return
+}
+
+// GetKOSEditors obtains the IDs of the users privileged to edit the kill on
+// sight list.
+func (d *DB) GetKOSEditors(c context.Context) (ids map[string]bool, err error) {
+	ids = make(map[string]bool)
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetKOSEditors(), func(r app.SingleRow) error {
+		var id string
+		if err := r.Scan(&id); err != nil {
+			return err
+		}
+		ids[id] = true
+		return nil
+	})
+	err = txb.Do(c)
+	return
+}
+
+// The sources of contacts, whose standings are towards other entities.
+const (
+	CorporationContacts = "corporation"
+	AllianceContacts    = "alliance"
+)
+
+// SetContacts replaces the contacts of the source.
+func (d *DB) SetContacts(c context.Context, source string, cs []esi.Contact) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.DeleteContacts(), source)
+	for _, ct := range cs {
+		txb.Exec(d.pg.InsertContact(), source, ct.ID, ct.Type, ct.Standing)
+	}
+	return txb.Do(c)
+}
+
+// GetStandings obtains the standings towards the entities that have them,
+// keyed by their IDs. The corporation's standing is preferred over the
+// alliance's.
+func (d *DB) GetStandings(c context.Context, ids []int32) (m map[int32]float64, err error) {
+	m = make(map[int32]float64)
+	txb := d.db.Begin()
+	txb.Query(d.pg.GetStandings(), func(r app.SingleRow) error {
+		var id int32
+		var standing float64
+		if err := r.Scan(&id, &standing); err != nil {
+			return err
+		}
+		m[id] = standing
+		return nil
+	}, ids)
+	err = txb.Do(c)
+	return
+}
+
+// LocalScan is a stored result of checking names pasted from local chat, so
+// that it may be shared until it expires.
+type LocalScan struct {
+	ID        string
+	Created   time.Time
+	CreatedBy int32
+	Expires   time.Time
+	// Result is JSON.
+	Result []byte
+}
+
+// InsertLocalScan stores the scan, returning its new ID.
+func (d *DB) InsertLocalScan(c context.Context, s LocalScan) (string, error) {
+	var id string
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.InsertLocalScan(), func(r app.SingleRow) error {
+		return r.Scan(&id)
+	}, s.Created, s.CreatedBy, s.Expires, s.Result)
+	return id, txb.Do(c)
+}
+
+// GetLocalScan obtains the scan, which is nil if it does not exist or has
+// expired.
+func (d *DB) GetLocalScan(c context.Context, id string) (s *LocalScan, err error) {
+	txb := d.db.Begin()
+	txb.QueryOneRow(d.pg.GetLocalScan(), func(r app.SingleRow) error {
+		s = &LocalScan{}
+		return r.Scan(&s.ID, &s.Created, &s.CreatedBy, &s.Expires, &s.Result)
+	}, id, time.Now())
+	err = txb.Do(c)
+	return
+}
+
+func (d *DB) DeleteExpiredLocalScans(c context.Context) error {
+	txb := d.db.Begin()
+	txb.Exec(d.pg.DeleteExpiredLocalScans(), time.Now())
+	return txb.Do(c)
+}
@@ -18,6 +18,8 @@ package db
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/go-fed/apcore/app"
 )
@@ -42,6 +44,49 @@ func CreateTables(c context.Context, db app.Database, schema string) error {
 	tx.Exec(p.CreateUserSupplementTableV0())
 	tx.Exec(p.CreateEveMediaDataTableV0())
 	tx.Exec(p.CreateMediaDataTableV0())
+	tx.Exec(p.CreateESICacheTableV0())
+	tx.Exec(p.CreateEveEntitiesTableV0())
+	tx.Exec(p.CreateEveEntitiesNameIndexV0())
+	tx.Exec(p.CreateEveUnknownNamesTableV0())
+	tx.Exec(p.CreateEveUnknownIDsTableV0())
+	tx.Exec(p.CreateCorporationMembersTableV0())
+	tx.Exec(p.CreateCorporationMembersCurrentIndexV0())
+	tx.Exec(p.CreateKillmailsTableV0())
+	tx.Exec(p.CreateKillmailsTimeIndexV0())
+	tx.Exec(p.CreateKillmailAttackersTableV0())
+	tx.Exec(p.CreateKillmailAttackersCharacterIndexV0())
+	tx.Exec(p.CreateKillmailItemsTableV0())
+	tx.Exec(p.CreateWalletJournalTableV0())
+	tx.Exec(p.CreateWalletJournalDateIndexV0())
+	tx.Exec(p.CreateWalletTransactionsTableV0())
+	tx.Exec(p.CreateFactionWarfareStatsTableV0())
+	tx.Exec(p.CreateCalendarEventsTableV0())
+	tx.Exec(p.CreateCalendarResponsesTableV0())
+	tx.Exec(p.CreateCharacterRolesTableV0())
+	tx.Exec(p.CreateSDEBuildTableV0())
+	tx.Exec(p.CreateSDETypesTableV0())
+	tx.Exec(p.CreateSDEGroupsTableV0())
+	tx.Exec(p.CreateSDEMarketGroupsTableV0())
+	tx.Exec(p.CreateSDERegionsTableV0())
+	tx.Exec(p.CreateSDESolarSystemsTableV0())
+	tx.Exec(p.CreateSDESolarSystemsNameIndexV0())
+	tx.Exec(p.CreateSDEStargatesTableV0())
+	tx.Exec(p.CreateSDEStargatesSolarSystemIndexV0())
+	tx.Exec(p.CreateMarketPricesTableV0())
+	tx.Exec(p.CreateMarketHubPricesTableV0())
+	tx.Exec(p.CreateCorporationAssetsTableV0())
+	tx.Exec(p.CreateCorporationAssetsSnapshotIndexV0())
+	tx.Exec(p.CreateIndustryJobsTableV0())
+	tx.Exec(p.CreateIndustryJobsStatusIndexV0())
+	tx.Exec(p.CreateMiningLedgerTableV0())
+	tx.Exec(p.CreateMiningLedgerDayIndexV0())
+	tx.Exec(p.CreateMiningTaxRatesTableV0())
+	tx.Exec(p.CreateMiningOrePricesTableV0())
+	tx.Exec(p.CreateHaulRequestsTableV0())
+	tx.Exec(p.CreateHaulRequestsStatusIndexV0())
+	tx.Exec(p.CreateKOSTableV0())
+	tx.Exec(p.CreateContactsTableV0())
+	tx.Exec(p.CreateLocalScansTableV0())
 	return tx.Do(c)
 }
 
@@ -66,10 +111,10 @@ VALUES
 ON CONFLICT (hash) DO NOTHING;`
 }
 
-func (p postgres) GetLatestEvePublicKey() string {
+func (p postgres) GetRecentEvePublicKeys() string {
 	return `SELECT keys FROM ` + p.schema + `dharma_eve_public_keys
 ORDER BY create_time DESC NULLS LAST
-LIMIT 1;`
+LIMIT $1;`
 }
 
 // EVE Online Tokens Table
@@ -100,11 +145,31 @@ func (p postgres) GetEveToken() string {
 WHERE character_id = $1;`
 }
 
+func (p postgres) GetEveTokenForUser() string {
+	return `SELECT tokens FROM ` + p.schema + `dharma_eve_tokens
+WHERE user_id = $1 AND character_id = $2;`
+}
+
+func (p postgres) DeleteEveToken() string {
+	return `DELETE FROM ` + p.schema + `dharma_eve_tokens
+WHERE user_id = $1 AND character_id = $2;`
+}
+
+func (p postgres) GetUserForCharacter() string {
+	return `SELECT user_id FROM ` + p.schema + `dharma_eve_tokens
+WHERE character_id = $1;`
+}
+
 func (p postgres) GetExpiringEveTokensWithin() string {
 	return `SELECT user_id, tokens FROM ` + p.schema + `dharma_eve_tokens
 WHERE (tokens->access_expires)::timestamp < current_timestamp + interval '$1'`
 }
 
+func (p postgres) GetCharactersWithScope() string {
+	return `SELECT character_id FROM ` + p.schema + `dharma_eve_tokens
+WHERE tokens->'scopes' ? $1;`
+}
+
 func (p postgres) MarkAllTokensWithState() string {
 	return `UPDATE ` + p.schema + `dharma_eve_tokens
 (status) VALUES ($1)`
@@ -253,3 +318,1284 @@ WHERE id = $1;`
 func (p postgres) DeleteMedia() string {
 	return `DELETE FROM ` + p.schema + `dharma_media WHERE id = $1;`
 }
+
+// ESI Response Cache Table
+
+func (p postgres) CreateESICacheTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_esi_cache
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  create_time timestamp with time zone DEFAULT current_timestamp,
+  cache_key text UNIQUE NOT NULL,
+  expires_time timestamp with time zone NOT NULL,
+  response bytea NOT NULL
+);`
+}
+
+func (p postgres) SetESICacheEntry() string {
+	return `INSERT INTO ` + p.schema + `dharma_esi_cache
+(cache_key, expires_time, response)
+VALUES
+($1, $2, $3)
+ON CONFLICT (cache_key) DO UPDATE
+SET expires_time = EXCLUDED.expires_time, response = EXCLUDED.response;`
+}
+
+func (p postgres) GetESICacheEntry() string {
+	return `SELECT response FROM ` + p.schema + `dharma_esi_cache
+WHERE cache_key = $1;`
+}
+
+func (p postgres) DeleteESICacheEntry() string {
+	return `DELETE FROM ` + p.schema + `dharma_esi_cache WHERE cache_key = $1;`
+}
+
+func (p postgres) DeleteESICacheEntriesExpiredBefore() string {
+	return `DELETE FROM ` + p.schema + `dharma_esi_cache WHERE expires_time < $1;`
+}
+
+// EVE Online Entity Names Table
+
+func (p postgres) CreateEveEntitiesTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_eve_entities
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  create_time timestamp with time zone DEFAULT current_timestamp,
+  entity_id integer UNIQUE NOT NULL,
+  category text NOT NULL,
+  name text NOT NULL,
+  expires_time timestamp with time zone NOT NULL
+);`
+}
+
+func (p postgres) CreateEveEntitiesNameIndexV0() string {
+	return `
+CREATE INDEX IF NOT EXISTS dharma_eve_entities_lower_name_idx ON ` + p.schema + `dharma_eve_entities (lower(name));`
+}
+
+func (p postgres) SetEveEntity() string {
+	return `INSERT INTO ` + p.schema + `dharma_eve_entities
+(entity_id, category, name, expires_time)
+VALUES
+($1, $2, $3, $4)
+ON CONFLICT (entity_id) DO UPDATE
+SET category = EXCLUDED.category, name = EXCLUDED.name, expires_time = EXCLUDED.expires_time;`
+}
+
+func (p postgres) GetUnexpiredEveEntities() string {
+	return `SELECT entity_id, category, name FROM ` + p.schema + `dharma_eve_entities
+WHERE entity_id = ANY($1) AND expires_time > current_timestamp;`
+}
+
+func (p postgres) GetUnexpiredEveEntitiesByName() string {
+	return `SELECT entity_id, category, name FROM ` + p.schema + `dharma_eve_entities
+WHERE lower(name) = ANY($1) AND expires_time > current_timestamp;`
+}
+
+// EVE Online Unknown Names Table
+
+func (p postgres) CreateEveUnknownNamesTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_eve_unknown_names
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  create_time timestamp with time zone DEFAULT current_timestamp,
+  name text UNIQUE NOT NULL,
+  expires_time timestamp with time zone NOT NULL
+);`
+}
+
+func (p postgres) SetEveUnknownName() string {
+	return `INSERT INTO ` + p.schema + `dharma_eve_unknown_names
+(name, expires_time)
+VALUES
+($1, $2)
+ON CONFLICT (name) DO UPDATE
+SET expires_time = EXCLUDED.expires_time;`
+}
+
+func (p postgres) GetUnexpiredEveUnknownNames() string {
+	return `SELECT name FROM ` + p.schema + `dharma_eve_unknown_names
+WHERE name = ANY($1) AND expires_time > current_timestamp;`
+}
+
+func (p postgres) DeleteExpiredEveUnknownNames() string {
+	return `DELETE FROM ` + p.schema + `dharma_eve_unknown_names WHERE expires_time <= current_timestamp;`
+}
+
+// EVE Online Unknown IDs Table
+
+func (p postgres) CreateEveUnknownIDsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_eve_unknown_ids
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  create_time timestamp with time zone DEFAULT current_timestamp,
+  entity_id integer UNIQUE NOT NULL,
+  expires_time timestamp with time zone NOT NULL
+);`
+}
+
+func (p postgres) SetEveUnknownID() string {
+	return `INSERT INTO ` + p.schema + `dharma_eve_unknown_ids
+(entity_id, expires_time)
+VALUES
+($1, $2)
+ON CONFLICT (entity_id) DO UPDATE
+SET expires_time = EXCLUDED.expires_time;`
+}
+
+func (p postgres) GetUnexpiredEveUnknownIDs() string {
+	return `SELECT entity_id FROM ` + p.schema + `dharma_eve_unknown_ids
+WHERE entity_id = ANY($1) AND expires_time > current_timestamp;`
+}
+
+func (p postgres) DeleteExpiredEveUnknownIDs() string {
+	return `DELETE FROM ` + p.schema + `dharma_eve_unknown_ids WHERE expires_time <= current_timestamp;`
+}
+
+// Corporation Members Table
+
+func (p postgres) CreateCorporationMembersTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_corporation_members
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  character_id integer NOT NULL,
+  join_time timestamp with time zone NOT NULL DEFAULT current_timestamp,
+  leave_time timestamp with time zone
+);`
+}
+
+func (p postgres) CreateCorporationMembersCurrentIndexV0() string {
+	return `
+CREATE UNIQUE INDEX IF NOT EXISTS dharma_corporation_members_current_idx ON ` + p.schema + `dharma_corporation_members (character_id)
+WHERE leave_time IS NULL;`
+}
+
+func (p postgres) MarkCorporationMembersLeft() string {
+	return `UPDATE ` + p.schema + `dharma_corporation_members
+SET leave_time = current_timestamp
+WHERE leave_time IS NULL AND NOT (character_id = ANY($1));`
+}
+
+func (p postgres) InsertCorporationMembersJoined() string {
+	return `INSERT INTO ` + p.schema + `dharma_corporation_members
+(character_id)
+SELECT m FROM unnest($1::integer[]) AS m
+WHERE NOT EXISTS (
+  SELECT 1 FROM ` + p.schema + `dharma_corporation_members
+  WHERE character_id = m AND leave_time IS NULL
+);`
+}
+
+func (p postgres) GetCorporationRoster() string {
+	return `SELECT m.character_id, m.join_time, m.leave_time, t.user_id, u.actor->>'preferredUsername'
+FROM ` + p.schema + `dharma_corporation_members AS m
+LEFT JOIN ` + p.schema + `dharma_eve_tokens AS t ON t.character_id = m.character_id
+LEFT JOIN ` + p.schema + `users AS u ON u.id = t.user_id
+WHERE m.leave_time IS NULL OR m.leave_time > $1
+ORDER BY m.leave_time IS NOT NULL, m.join_time DESC;`
+}
+
+// Killmails Tables
+
+func (p postgres) CreateKillmailsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_killmails
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  create_time timestamp with time zone DEFAULT current_timestamp,
+  killmail_id integer UNIQUE NOT NULL,
+  killmail_hash text NOT NULL,
+  killmail_time timestamp with time zone NOT NULL,
+  solar_system_id integer NOT NULL,
+  moon_id integer NOT NULL,
+  war_id integer NOT NULL,
+  victim_character_id integer NOT NULL,
+  victim_corporation_id integer NOT NULL,
+  victim_alliance_id integer NOT NULL,
+  victim_faction_id integer NOT NULL,
+  victim_ship_type_id integer NOT NULL,
+  victim_damage_taken integer NOT NULL
+);`
+}
+
+func (p postgres) CreateKillmailsTimeIndexV0() string {
+	return `
+CREATE INDEX IF NOT EXISTS dharma_killmails_time_idx ON ` + p.schema + `dharma_killmails (killmail_time);`
+}
+
+func (p postgres) CreateKillmailAttackersTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_killmail_attackers
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  killmail_id integer NOT NULL REFERENCES ` + p.schema + `dharma_killmails (killmail_id) ON DELETE CASCADE,
+  character_id integer NOT NULL,
+  corporation_id integer NOT NULL,
+  alliance_id integer NOT NULL,
+  faction_id integer NOT NULL,
+  ship_type_id integer NOT NULL,
+  weapon_type_id integer NOT NULL,
+  damage_done integer NOT NULL,
+  final_blow boolean NOT NULL,
+  security_status real NOT NULL
+);`
+}
+
+func (p postgres) CreateKillmailAttackersCharacterIndexV0() string {
+	return `
+CREATE INDEX IF NOT EXISTS dharma_killmail_attackers_character_idx ON ` + p.schema + `dharma_killmail_attackers (character_id);`
+}
+
+func (p postgres) CreateKillmailItemsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_killmail_items
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  killmail_id integer NOT NULL REFERENCES ` + p.schema + `dharma_killmails (killmail_id) ON DELETE CASCADE,
+  item_index integer NOT NULL,
+  parent_index integer,
+  item_type_id integer NOT NULL,
+  flag integer NOT NULL,
+  singleton integer NOT NULL,
+  quantity_destroyed bigint NOT NULL,
+  quantity_dropped bigint NOT NULL
+);`
+}
+
+func (p postgres) InsertKillmail() string {
+	return `INSERT INTO ` + p.schema + `dharma_killmails
+(killmail_id, killmail_hash, killmail_time, solar_system_id, moon_id, war_id, victim_character_id, victim_corporation_id, victim_alliance_id, victim_faction_id, victim_ship_type_id, victim_damage_taken)
+VALUES
+($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);`
+}
+
+func (p postgres) InsertKillmailAttacker() string {
+	return `INSERT INTO ` + p.schema + `dharma_killmail_attackers
+(killmail_id, character_id, corporation_id, alliance_id, faction_id, ship_type_id, weapon_type_id, damage_done, final_blow, security_status)
+VALUES
+($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);`
+}
+
+func (p postgres) InsertKillmailItem() string {
+	return `INSERT INTO ` + p.schema + `dharma_killmail_items
+(killmail_id, item_index, parent_index, item_type_id, flag, singleton, quantity_destroyed, quantity_dropped)
+VALUES
+($1, $2, $3, $4, $5, $6, $7, $8);`
+}
+
+func (p postgres) GetKnownKillmailIDs() string {
+	return `SELECT killmail_id FROM ` + p.schema + `dharma_killmails
+WHERE killmail_id = ANY($1);`
+}
+
+func (p postgres) killmailSummaryColumns() string {
+	return `k.killmail_id, k.killmail_time, k.solar_system_id, k.victim_character_id, k.victim_corporation_id, k.victim_alliance_id, k.victim_ship_type_id,
+(SELECT count(*) FROM ` + p.schema + `dharma_killmail_attackers AS a WHERE a.killmail_id = k.killmail_id),
+COALESCE((SELECT a.character_id FROM ` + p.schema + `dharma_killmail_attackers AS a WHERE a.killmail_id = k.killmail_id AND a.final_blow LIMIT 1), 0),
+COALESCE((SELECT a.corporation_id FROM ` + p.schema + `dharma_killmail_attackers AS a WHERE a.killmail_id = k.killmail_id AND a.final_blow LIMIT 1), 0)`
+}
+
+func (p postgres) GetKillmailSummariesBetween() string {
+	return `SELECT ` + p.killmailSummaryColumns() + `
+FROM ` + p.schema + `dharma_killmails AS k
+WHERE k.killmail_time >= $1 AND k.killmail_time < $2
+ORDER BY k.killmail_time DESC;`
+}
+
+func (p postgres) GetKillmailSummariesForCharacter() string {
+	return `SELECT ` + p.killmailSummaryColumns() + `
+FROM ` + p.schema + `dharma_killmails AS k
+WHERE k.victim_character_id = $1 OR EXISTS (
+  SELECT 1 FROM ` + p.schema + `dharma_killmail_attackers AS a
+  WHERE a.killmail_id = k.killmail_id AND a.character_id = $1
+)
+ORDER BY k.killmail_time DESC
+LIMIT $2 OFFSET $3;`
+}
+
+func (p postgres) GetKillmail() string {
+	return `SELECT killmail_id, killmail_hash, killmail_time, solar_system_id, moon_id, war_id, victim_character_id, victim_corporation_id, victim_alliance_id, victim_faction_id, victim_ship_type_id, victim_damage_taken
+FROM ` + p.schema + `dharma_killmails
+WHERE killmail_id = $1;`
+}
+
+func (p postgres) GetKillmailAttackers() string {
+	return `SELECT character_id, corporation_id, alliance_id, faction_id, ship_type_id, weapon_type_id, damage_done, final_blow, security_status
+FROM ` + p.schema + `dharma_killmail_attackers
+WHERE killmail_id = $1
+ORDER BY final_blow DESC, damage_done DESC;`
+}
+
+func (p postgres) GetKillmailItems() string {
+	return `SELECT item_index, parent_index, item_type_id, flag, singleton, quantity_destroyed, quantity_dropped
+FROM ` + p.schema + `dharma_killmail_items
+WHERE killmail_id = $1
+ORDER BY item_index;`
+}
+
+// Corporation Wallet Tables
+
+func (p postgres) CreateWalletJournalTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_wallet_journal
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  division integer NOT NULL,
+  journal_id bigint NOT NULL,
+  journal_time timestamp with time zone NOT NULL,
+  ref_type text NOT NULL,
+  amount double precision NOT NULL,
+  balance double precision NOT NULL,
+  first_party_id integer NOT NULL,
+  second_party_id integer NOT NULL,
+  context_id bigint NOT NULL,
+  context_id_type text NOT NULL,
+  description text NOT NULL,
+  reason text NOT NULL,
+  tax double precision NOT NULL,
+  tax_receiver_id integer NOT NULL,
+  UNIQUE (division, journal_id)
+);`
+}
+
+func (p postgres) CreateWalletJournalDateIndexV0() string {
+	return `
+CREATE INDEX IF NOT EXISTS dharma_wallet_journal_time_idx ON ` + p.schema + `dharma_wallet_journal (journal_time);`
+}
+
+func (p postgres) CreateWalletTransactionsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_wallet_transactions
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  division integer NOT NULL,
+  transaction_id bigint NOT NULL,
+  transaction_time timestamp with time zone NOT NULL,
+  type_id integer NOT NULL,
+  quantity integer NOT NULL,
+  unit_price double precision NOT NULL,
+  client_id integer NOT NULL,
+  location_id bigint NOT NULL,
+  is_buy boolean NOT NULL,
+  journal_ref_id bigint NOT NULL,
+  UNIQUE (division, transaction_id)
+);`
+}
+
+func (p postgres) GetLatestWalletJournalID() string {
+	return `SELECT COALESCE(max(journal_id), 0) FROM ` + p.schema + `dharma_wallet_journal
+WHERE division = $1;`
+}
+
+func (p postgres) InsertWalletJournalEntry() string {
+	return `INSERT INTO ` + p.schema + `dharma_wallet_journal
+(division, journal_id, journal_time, ref_type, amount, balance, first_party_id, second_party_id, context_id, context_id_type, description, reason, tax, tax_receiver_id)
+VALUES
+($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+ON CONFLICT (division, journal_id) DO NOTHING;`
+}
+
+func (p postgres) GetLatestWalletTransactionID() string {
+	return `SELECT COALESCE(max(transaction_id), 0) FROM ` + p.schema + `dharma_wallet_transactions
+WHERE division = $1;`
+}
+
+func (p postgres) InsertWalletTransaction() string {
+	return `INSERT INTO ` + p.schema + `dharma_wallet_transactions
+(division, transaction_id, transaction_time, type_id, quantity, unit_price, client_id, location_id, is_buy, journal_ref_id)
+VALUES
+($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (division, transaction_id) DO NOTHING;`
+}
+
+func (p postgres) GetWalletIncomeByRefType() string {
+	return `SELECT ref_type, sum(amount) FROM ` + p.schema + `dharma_wallet_journal
+WHERE amount > 0 AND journal_time >= $1
+GROUP BY ref_type
+ORDER BY sum(amount) DESC;`
+}
+
+func (p postgres) GetWalletTaxByMember() string {
+	return `SELECT date_trunc('month', j.journal_time AT TIME ZONE 'UTC'), m.character_id, sum(j.amount)
+FROM ` + p.schema + `dharma_wallet_journal AS j
+JOIN (SELECT DISTINCT character_id FROM ` + p.schema + `dharma_corporation_members) AS m
+  ON m.character_id = j.first_party_id OR m.character_id = j.second_party_id
+WHERE j.amount > 0 AND j.journal_time >= $1 AND j.ref_type = ANY($2)
+GROUP BY 1, 2
+ORDER BY 1 DESC, 3 DESC;`
+}
+
+// Faction Warfare Statistics Table
+
+func (p postgres) CreateFactionWarfareStatsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_fw_stats
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  snapshot_date date UNIQUE NOT NULL,
+  faction_id integer NOT NULL,
+  enlisted_time timestamp with time zone NOT NULL,
+  pilots integer NOT NULL,
+  kills_yesterday integer NOT NULL,
+  kills_last_week integer NOT NULL,
+  kills_total integer NOT NULL,
+  vp_yesterday integer NOT NULL,
+  vp_last_week integer NOT NULL,
+  vp_total integer NOT NULL
+);`
+}
+
+func (p postgres) SetFactionWarfareStats() string {
+	return `INSERT INTO ` + p.schema + `dharma_fw_stats
+(snapshot_date, faction_id, enlisted_time, pilots, kills_yesterday, kills_last_week, kills_total, vp_yesterday, vp_last_week, vp_total)
+VALUES
+($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (snapshot_date) DO UPDATE
+SET faction_id = EXCLUDED.faction_id, enlisted_time = EXCLUDED.enlisted_time, pilots = EXCLUDED.pilots, kills_yesterday = EXCLUDED.kills_yesterday, kills_last_week = EXCLUDED.kills_last_week, kills_total = EXCLUDED.kills_total, vp_yesterday = EXCLUDED.vp_yesterday, vp_last_week = EXCLUDED.vp_last_week, vp_total = EXCLUDED.vp_total;`
+}
+
+func (p postgres) GetFactionWarfareStatsSince() string {
+	return `SELECT snapshot_date, faction_id, enlisted_time, pilots, kills_yesterday, kills_last_week, kills_total, vp_yesterday, vp_last_week, vp_total
+FROM ` + p.schema + `dharma_fw_stats
+WHERE snapshot_date >= $1
+ORDER BY snapshot_date;`
+}
+
+// Calendar Tables
+
+func (p postgres) CreateCalendarEventsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_calendar_events
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  event_id integer UNIQUE NOT NULL,
+  event_time timestamp with time zone NOT NULL,
+  duration_minutes integer NOT NULL,
+  title text NOT NULL,
+  importance integer NOT NULL,
+  owner_id integer NOT NULL,
+  owner_name text NOT NULL,
+  owner_type text NOT NULL,
+  text text NOT NULL,
+  update_time timestamp with time zone NOT NULL DEFAULT current_timestamp
+);`
+}
+
+func (p postgres) CreateCalendarResponsesTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_calendar_responses
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  event_id integer NOT NULL REFERENCES ` + p.schema + `dharma_calendar_events (event_id) ON DELETE CASCADE,
+  character_id integer NOT NULL,
+  response text NOT NULL,
+  update_time timestamp with time zone NOT NULL DEFAULT current_timestamp,
+  UNIQUE (event_id, character_id)
+);`
+}
+
+func (p postgres) GetKnownCalendarEventIDs() string {
+	return `SELECT event_id FROM ` + p.schema + `dharma_calendar_events
+WHERE event_id = ANY($1);`
+}
+
+func (p postgres) SetCalendarEvent() string {
+	return `INSERT INTO ` + p.schema + `dharma_calendar_events
+(event_id, event_time, duration_minutes, title, importance, owner_id, owner_name, owner_type, text)
+VALUES
+($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (event_id) DO UPDATE
+SET event_time = EXCLUDED.event_time, duration_minutes = EXCLUDED.duration_minutes, title = EXCLUDED.title, importance = EXCLUDED.importance, owner_id = EXCLUDED.owner_id, owner_name = EXCLUDED.owner_name, owner_type = EXCLUDED.owner_type, text = EXCLUDED.text, update_time = current_timestamp;`
+}
+
+func (p postgres) UpdateCalendarEventSummary() string {
+	return `UPDATE ` + p.schema + `dharma_calendar_events
+SET event_time = $2, title = $3, importance = $4, update_time = current_timestamp
+WHERE event_id = $1;`
+}
+
+func (p postgres) SetCalendarResponse() string {
+	return `INSERT INTO ` + p.schema + `dharma_calendar_responses
+(event_id, character_id, response)
+VALUES
+($1, $2, $3)
+ON CONFLICT (event_id, character_id) DO UPDATE
+SET response = EXCLUDED.response, update_time = current_timestamp;`
+}
+
+func (p postgres) GetCalendarEventsOwnedBySince() string {
+	return `SELECT e.event_id, e.event_time, e.duration_minutes, e.title, e.importance, e.owner_id, e.owner_name, e.owner_type, e.text,
+count(*) FILTER (WHERE r.response = 'accepted'),
+count(*) FILTER (WHERE r.response = 'tentative'),
+count(*) FILTER (WHERE r.response = 'declined'),
+COALESCE(max(r.response) FILTER (WHERE r.character_id = $3), '')
+FROM ` + p.schema + `dharma_calendar_events AS e
+LEFT JOIN ` + p.schema + `dharma_calendar_responses AS r ON r.event_id = e.event_id
+WHERE e.owner_id = $1 AND e.event_time >= $2
+GROUP BY e.id
+ORDER BY e.event_time;`
+}
+
+func (p postgres) HasCalendarResponse() string {
+	return `SELECT 1 FROM ` + p.schema + `dharma_calendar_responses
+WHERE event_id = $1 AND character_id = $2;`
+}
+
+// Character Roles Table
+
+func (p postgres) CreateCharacterRolesTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_character_roles
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  character_id integer UNIQUE NOT NULL,
+  corporation_id integer NOT NULL,
+  ceo boolean NOT NULL DEFAULT false,
+  roles jsonb NOT NULL,
+  update_time timestamp with time zone NOT NULL DEFAULT current_timestamp
+);`
+}
+
+func (p postgres) SetCharacterRoles() string {
+	return `INSERT INTO ` + p.schema + `dharma_character_roles
+(character_id, corporation_id, ceo, roles)
+VALUES
+($1, $2, $3, $4)
+ON CONFLICT (character_id) DO UPDATE
+SET corporation_id = EXCLUDED.corporation_id, ceo = EXCLUDED.ceo, roles = EXCLUDED.roles, update_time = current_timestamp;`
+}
+
+func (p postgres) DeleteCharacterRoles() string {
+	return `DELETE FROM ` + p.schema + `dharma_character_roles
+WHERE character_id = $1;`
+}
+
+func (p postgres) GetCharacterRoles() string {
+	return `SELECT corporation_id, ceo, roles, update_time FROM ` + p.schema + `dharma_character_roles
+WHERE character_id = $1;`
+}
+
+// Static Data Export Tables
+
+func (p postgres) CreateSDEBuildTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_sde_build
+(
+  id boolean PRIMARY KEY DEFAULT TRUE CHECK (id),
+  build_number bigint NOT NULL,
+  release_time timestamp with time zone NOT NULL,
+  import_time timestamp with time zone NOT NULL DEFAULT current_timestamp
+);`
+}
+
+func (p postgres) CreateSDETypesTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_sde_types
+(
+  type_id integer PRIMARY KEY,
+  group_id integer NOT NULL,
+  market_group_id integer,
+  names jsonb NOT NULL,
+  volume double precision NOT NULL,
+  published boolean NOT NULL
+);`
+}
+
+func (p postgres) CreateSDEGroupsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_sde_groups
+(
+  group_id integer PRIMARY KEY,
+  category_id integer NOT NULL,
+  names jsonb NOT NULL,
+  published boolean NOT NULL
+);`
+}
+
+func (p postgres) CreateSDEMarketGroupsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_sde_market_groups
+(
+  market_group_id integer PRIMARY KEY,
+  parent_group_id integer,
+  names jsonb NOT NULL,
+  has_types boolean NOT NULL
+);`
+}
+
+func (p postgres) CreateSDERegionsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_sde_regions
+(
+  region_id integer PRIMARY KEY,
+  names jsonb NOT NULL
+);`
+}
+
+func (p postgres) CreateSDESolarSystemsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_sde_solar_systems
+(
+  solar_system_id integer PRIMARY KEY,
+  constellation_id integer NOT NULL,
+  region_id integer NOT NULL,
+  names jsonb NOT NULL,
+  security double precision NOT NULL
+);`
+}
+
+func (p postgres) CreateSDESolarSystemsNameIndexV0() string {
+	return `
+CREATE INDEX IF NOT EXISTS dharma_sde_solar_systems_name_idx ON ` + p.schema + `dharma_sde_solar_systems
+(lower(names->>'en'));`
+}
+
+func (p postgres) CreateSDEStargatesTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_sde_stargates
+(
+  stargate_id integer PRIMARY KEY,
+  solar_system_id integer NOT NULL,
+  destination_id integer NOT NULL,
+  destination_solar_system_id integer NOT NULL
+);`
+}
+
+func (p postgres) CreateSDEStargatesSolarSystemIndexV0() string {
+	return `
+CREATE INDEX IF NOT EXISTS dharma_sde_stargates_solar_system_idx ON ` + p.schema + `dharma_sde_stargates
+(solar_system_id);`
+}
+
+func (p postgres) SetSDEBuild() string {
+	return `INSERT INTO ` + p.schema + `dharma_sde_build
+(build_number, release_time)
+VALUES
+($1, $2)
+ON CONFLICT (id) DO UPDATE
+SET build_number = EXCLUDED.build_number, release_time = EXCLUDED.release_time, import_time = current_timestamp;`
+}
+
+func (p postgres) GetSDEBuild() string {
+	return `SELECT build_number, release_time FROM ` + p.schema + `dharma_sde_build;`
+}
+
+func (p postgres) DeleteSDEBuild() string {
+	return `DELETE FROM ` + p.schema + `dharma_sde_build;`
+}
+
+func (p postgres) DeleteSDETypes() string {
+	return `DELETE FROM ` + p.schema + `dharma_sde_types;`
+}
+
+func (p postgres) DeleteSDEGroups() string {
+	return `DELETE FROM ` + p.schema + `dharma_sde_groups;`
+}
+
+func (p postgres) DeleteSDEMarketGroups() string {
+	return `DELETE FROM ` + p.schema + `dharma_sde_market_groups;`
+}
+
+func (p postgres) DeleteSDERegions() string {
+	return `DELETE FROM ` + p.schema + `dharma_sde_regions;`
+}
+
+func (p postgres) DeleteSDESolarSystems() string {
+	return `DELETE FROM ` + p.schema + `dharma_sde_solar_systems;`
+}
+
+func (p postgres) DeleteSDEStargates() string {
+	return `DELETE FROM ` + p.schema + `dharma_sde_stargates;`
+}
+
+func (p postgres) InsertSDEType() string {
+	return `INSERT INTO ` + p.schema + `dharma_sde_types
+(type_id, group_id, market_group_id, names, volume, published)
+VALUES
+($1, $2, NULLIF($3, 0), $4, $5, $6);`
+}
+
+func (p postgres) InsertSDEGroup() string {
+	return `INSERT INTO ` + p.schema + `dharma_sde_groups
+(group_id, category_id, names, published)
+VALUES
+($1, $2, $3, $4);`
+}
+
+func (p postgres) InsertSDEMarketGroup() string {
+	return `INSERT INTO ` + p.schema + `dharma_sde_market_groups
+(market_group_id, parent_group_id, names, has_types)
+VALUES
+($1, NULLIF($2, 0), $3, $4);`
+}
+
+func (p postgres) InsertSDERegion() string {
+	return `INSERT INTO ` + p.schema + `dharma_sde_regions
+(region_id, names)
+VALUES
+($1, $2);`
+}
+
+func (p postgres) InsertSDESolarSystem() string {
+	return `INSERT INTO ` + p.schema + `dharma_sde_solar_systems
+(solar_system_id, constellation_id, region_id, names, security)
+VALUES
+($1, $2, $3, $4, $5);`
+}
+
+func (p postgres) InsertSDEStargate() string {
+	return `INSERT INTO ` + p.schema + `dharma_sde_stargates
+(stargate_id, solar_system_id, destination_id, destination_solar_system_id)
+VALUES
+($1, $2, $3, $4);`
+}
+
+func (p postgres) GetSDETypes() string {
+	return `SELECT type_id, group_id, COALESCE(market_group_id, 0), names, volume, published FROM ` + p.schema + `dharma_sde_types
+WHERE type_id = ANY($1);`
+}
+
+func (p postgres) GetSDEGroups() string {
+	return `SELECT group_id, category_id, names, published FROM ` + p.schema + `dharma_sde_groups
+WHERE group_id = ANY($1);`
+}
+
+func (p postgres) GetSDEMarketGroups() string {
+	return `SELECT market_group_id, COALESCE(parent_group_id, 0), names, has_types FROM ` + p.schema + `dharma_sde_market_groups
+WHERE market_group_id = ANY($1);`
+}
+
+func (p postgres) GetSDERegions() string {
+	return `SELECT region_id, names FROM ` + p.schema + `dharma_sde_regions
+WHERE region_id = ANY($1);`
+}
+
+func (p postgres) GetSDESolarSystems() string {
+	return `SELECT solar_system_id, constellation_id, region_id, names, security FROM ` + p.schema + `dharma_sde_solar_systems
+WHERE solar_system_id = ANY($1);`
+}
+
+func (p postgres) GetSDESolarSystemByName() string {
+	return `SELECT solar_system_id, constellation_id, region_id, names, security FROM ` + p.schema + `dharma_sde_solar_systems
+WHERE lower(names->>'en') = lower($1);`
+}
+
+func (p postgres) SearchSDEMarketTypes() string {
+	return `SELECT type_id, group_id, COALESCE(market_group_id, 0), names, volume, published FROM ` + p.schema + `dharma_sde_types
+WHERE published AND market_group_id IS NOT NULL AND names->>'en' ILIKE '%' || $1 || '%'
+ORDER BY length(names->>'en'), names->>'en'
+LIMIT $2;`
+}
+
+func (p postgres) GetSDEStargatesInSolarSystem() string {
+	return `SELECT stargate_id, solar_system_id, destination_id, destination_solar_system_id FROM ` + p.schema + `dharma_sde_stargates
+WHERE solar_system_id = $1;`
+}
+
+// Market Prices Tables
+
+func (p postgres) CreateMarketPricesTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_market_prices
+(
+  type_id integer PRIMARY KEY,
+  adjusted_price double precision NOT NULL,
+  average_price double precision NOT NULL,
+  update_time timestamp with time zone NOT NULL DEFAULT current_timestamp
+);`
+}
+
+func (p postgres) CreateMarketHubPricesTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_market_hub_prices
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  location_id bigint NOT NULL,
+  type_id integer NOT NULL,
+  buy_price double precision NOT NULL,
+  sell_price double precision NOT NULL,
+  buy_volume bigint NOT NULL,
+  sell_volume bigint NOT NULL,
+  update_time timestamp with time zone NOT NULL DEFAULT current_timestamp,
+  UNIQUE (location_id, type_id)
+);`
+}
+
+func (p postgres) SetMarketPrice() string {
+	return `INSERT INTO ` + p.schema + `dharma_market_prices
+(type_id, adjusted_price, average_price)
+VALUES
+($1, $2, $3)
+ON CONFLICT (type_id) DO UPDATE
+SET adjusted_price = EXCLUDED.adjusted_price, average_price = EXCLUDED.average_price, update_time = current_timestamp;`
+}
+
+func (p postgres) GetMarketPrices() string {
+	return `SELECT type_id, adjusted_price, average_price FROM ` + p.schema + `dharma_market_prices
+WHERE type_id = ANY($1);`
+}
+
+func (p postgres) DeleteMarketHubPrices() string {
+	return `DELETE FROM ` + p.schema + `dharma_market_hub_prices
+WHERE location_id = $1;`
+}
+
+func (p postgres) InsertMarketHubPrice() string {
+	return `INSERT INTO ` + p.schema + `dharma_market_hub_prices
+(location_id, type_id, buy_price, sell_price, buy_volume, sell_volume)
+VALUES
+($1, $2, $3, $4, $5, $6);`
+}
+
+func (p postgres) GetMarketHubPrices() string {
+	return `SELECT type_id, buy_price, sell_price, buy_volume, sell_volume, update_time FROM ` + p.schema + `dharma_market_hub_prices
+WHERE location_id = $1 AND type_id = ANY($2);`
+}
+
+// Corporation Assets Table
+
+func (p postgres) CreateCorporationAssetsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_corporation_assets
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  snapshot_time timestamp with time zone NOT NULL,
+  item_id bigint NOT NULL,
+  type_id integer NOT NULL,
+  quantity integer NOT NULL,
+  location_flag text NOT NULL,
+  location_id bigint NOT NULL,
+  location_type text NOT NULL,
+  division integer NOT NULL,
+  container_id bigint NOT NULL,
+  container_type_id integer NOT NULL,
+  container_name text NOT NULL,
+  name text NOT NULL,
+  is_singleton boolean NOT NULL,
+  is_blueprint_copy boolean NOT NULL,
+  UNIQUE (snapshot_time, item_id)
+);`
+}
+
+func (p postgres) CreateCorporationAssetsSnapshotIndexV0() string {
+	return `
+CREATE INDEX IF NOT EXISTS dharma_corporation_assets_snapshot_location_idx ON ` + p.schema + `dharma_corporation_assets
+(snapshot_time, location_id);`
+}
+
+// nCorporationAssetColumns is the number of parameters of each asset inserted.
+const nCorporationAssetColumns = 13
+
+// InsertCorporationAssets inserts n assets at once. The snapshot time is $1,
+// followed by the columns of each asset in turn.
+func (p postgres) InsertCorporationAssets(n int) string {
+	var b strings.Builder
+	b.WriteString(`INSERT INTO ` + p.schema + `dharma_corporation_assets
+(snapshot_time, item_id, type_id, quantity, location_flag, location_id, location_type, division, container_id, container_type_id, container_name, name, is_singleton, is_blueprint_copy)
+VALUES
+`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		b.WriteString("($1")
+		for j := 0; j < nCorporationAssetColumns; j++ {
+			fmt.Fprintf(&b, ", $%d", 2+i*nCorporationAssetColumns+j)
+		}
+		b.WriteString(")")
+	}
+	b.WriteString(";")
+	return b.String()
+}
+
+func (p postgres) DeleteCorporationAssetsBefore() string {
+	return `DELETE FROM ` + p.schema + `dharma_corporation_assets
+WHERE snapshot_time < $1;`
+}
+
+func (p postgres) GetLatestCorporationAssetSnapshot() string {
+	return `SELECT snapshot_time FROM ` + p.schema + `dharma_corporation_assets
+ORDER BY snapshot_time DESC
+LIMIT 1;`
+}
+
+func (p postgres) GetCorporationAssetLocations() string {
+	return `SELECT location_id, location_type, count(*) FROM ` + p.schema + `dharma_corporation_assets
+WHERE snapshot_time = $1
+GROUP BY location_id, location_type
+ORDER BY count(*) DESC;`
+}
+
+func (p postgres) SearchCorporationAssets() string {
+	return `SELECT a.item_id, a.type_id, a.quantity, a.location_flag, a.location_id, a.location_type, a.division, a.container_id, a.container_type_id, a.container_name, a.name, a.is_singleton, a.is_blueprint_copy
+FROM ` + p.schema + `dharma_corporation_assets AS a
+LEFT JOIN ` + p.schema + `dharma_sde_types AS t ON t.type_id = a.type_id
+WHERE a.snapshot_time = $1
+AND ($2::bigint = 0 OR a.location_id = $2)
+AND ($3 = 0 OR a.division = $3)
+AND ($4 = '' OR t.names->>'en' ILIKE '%' || $4 || '%' OR a.name ILIKE '%' || $4 || '%')
+ORDER BY a.location_id, a.division, a.container_id, a.type_id
+LIMIT $5;`
+}
+
+// Industry Jobs Table
+
+func (p postgres) CreateIndustryJobsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_industry_jobs
+(
+  job_id integer PRIMARY KEY,
+  activity_id integer NOT NULL,
+  status text NOT NULL,
+  installer_id integer NOT NULL,
+  facility_id bigint NOT NULL,
+  location_id bigint NOT NULL,
+  blueprint_id bigint NOT NULL,
+  blueprint_type_id integer NOT NULL,
+  blueprint_location_id bigint NOT NULL,
+  output_location_id bigint NOT NULL,
+  product_type_id integer NOT NULL,
+  runs integer NOT NULL,
+  licensed_runs integer NOT NULL,
+  successful_runs integer NOT NULL,
+  probability real NOT NULL,
+  cost double precision NOT NULL,
+  duration integer NOT NULL,
+  start_date timestamp with time zone NOT NULL,
+  end_date timestamp with time zone NOT NULL,
+  pause_date timestamp with time zone NOT NULL,
+  completed_date timestamp with time zone NOT NULL,
+  completed_character_id integer NOT NULL,
+  notified boolean NOT NULL DEFAULT false
+);`
+}
+
+func (p postgres) CreateIndustryJobsStatusIndexV0() string {
+	return `
+CREATE INDEX IF NOT EXISTS dharma_industry_jobs_status_idx ON ` + p.schema + `dharma_industry_jobs
+(status, end_date);`
+}
+
+func (p postgres) UpsertIndustryJob() string {
+	return `INSERT INTO ` + p.schema + `dharma_industry_jobs
+(job_id, activity_id, status, installer_id, facility_id, location_id, blueprint_id, blueprint_type_id, blueprint_location_id, output_location_id, product_type_id, runs, licensed_runs, successful_runs, probability, cost, duration, start_date, end_date, pause_date, completed_date, completed_character_id)
+VALUES
+($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
+ON CONFLICT (job_id) DO UPDATE
+SET status = EXCLUDED.status, successful_runs = EXCLUDED.successful_runs, end_date = EXCLUDED.end_date, pause_date = EXCLUDED.pause_date, completed_date = EXCLUDED.completed_date, completed_character_id = EXCLUDED.completed_character_id;`
+}
+
+func (p postgres) GetIndustryJobs() string {
+	return `SELECT job_id, activity_id, status, installer_id, facility_id, location_id, blueprint_id, blueprint_type_id, blueprint_location_id, output_location_id, product_type_id, runs, licensed_runs, successful_runs, probability, cost, duration, start_date, end_date, pause_date, completed_date, completed_character_id FROM ` + p.schema + `dharma_industry_jobs
+WHERE status IN ('active', 'paused', 'ready')
+OR (status = 'delivered' AND completed_date >= $1)
+ORDER BY end_date;`
+}
+
+func (p postgres) GetUnnotifiedReadyIndustryJobs() string {
+	return `SELECT job_id, activity_id, status, installer_id, facility_id, location_id, blueprint_id, blueprint_type_id, blueprint_location_id, output_location_id, product_type_id, runs, licensed_runs, successful_runs, probability, cost, duration, start_date, end_date, pause_date, completed_date, completed_character_id FROM ` + p.schema + `dharma_industry_jobs
+WHERE status IN ('active', 'ready')
+AND end_date <= $1
+AND end_date >= $2
+AND NOT notified
+ORDER BY end_date;`
+}
+
+func (p postgres) MarkIndustryJobsNotified() string {
+	return `UPDATE ` + p.schema + `dharma_industry_jobs
+SET notified = true
+WHERE job_id = ANY($1);`
+}
+
+// Mining Ledger Table
+
+func (p postgres) CreateMiningLedgerTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_mining_ledger
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  observer_id bigint NOT NULL,
+  character_id integer NOT NULL,
+  recorded_corporation_id integer NOT NULL,
+  type_id integer NOT NULL,
+  day date NOT NULL,
+  quantity bigint NOT NULL,
+  UNIQUE (observer_id, character_id, recorded_corporation_id, type_id, day)
+);`
+}
+
+func (p postgres) CreateMiningLedgerDayIndexV0() string {
+	return `
+CREATE INDEX IF NOT EXISTS dharma_mining_ledger_day_idx ON ` + p.schema + `dharma_mining_ledger
+(day);`
+}
+
+func (p postgres) UpsertMiningLedgerEntry() string {
+	return `INSERT INTO ` + p.schema + `dharma_mining_ledger
+(observer_id, character_id, recorded_corporation_id, type_id, day, quantity)
+VALUES
+($1, $2, $3, $4, $5, $6)
+ON CONFLICT (observer_id, character_id, recorded_corporation_id, type_id, day) DO UPDATE
+SET quantity = EXCLUDED.quantity;`
+}
+
+func (p postgres) GetMiningTotals() string {
+	return `SELECT l.character_id, l.type_id, sum(l.quantity)::bigint, coalesce(sum(l.quantity * o.price), 0)
+FROM ` + p.schema + `dharma_mining_ledger AS l
+LEFT JOIN ` + p.schema + `dharma_mining_ore_prices AS o ON o.day = l.day AND o.type_id = l.type_id
+WHERE l.day >= $1 AND l.day < $2
+GROUP BY l.character_id, l.type_id;`
+}
+
+// Mining Ore Prices Table
+
+func (p postgres) CreateMiningOrePricesTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_mining_ore_prices
+(
+  day date NOT NULL,
+  type_id integer NOT NULL,
+  station_id integer NOT NULL,
+  price double precision NOT NULL,
+  PRIMARY KEY (day, type_id)
+);`
+}
+
+func (p postgres) InsertMiningOrePrice() string {
+	return `INSERT INTO ` + p.schema + `dharma_mining_ore_prices
+(day, type_id, station_id, price)
+VALUES
+($1, $2, $3, $4)
+ON CONFLICT (day, type_id) DO NOTHING;`
+}
+
+func (p postgres) GetMiningOrePriceStation() string {
+	return `SELECT station_id FROM ` + p.schema + `dharma_mining_ore_prices
+WHERE day >= $1 AND day < $2 AND station_id <> 0
+ORDER BY day DESC
+LIMIT 1;`
+}
+
+// Mining Tax Rates Table
+
+func (p postgres) CreateMiningTaxRatesTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_mining_tax_rates
+(
+  character_id integer PRIMARY KEY,
+  rate double precision NOT NULL
+);`
+}
+
+func (p postgres) SetMiningTaxRate() string {
+	return `INSERT INTO ` + p.schema + `dharma_mining_tax_rates
+(character_id, rate)
+VALUES
+($1, $2)
+ON CONFLICT (character_id) DO UPDATE
+SET rate = EXCLUDED.rate;`
+}
+
+func (p postgres) DeleteMiningTaxRate() string {
+	return `DELETE FROM ` + p.schema + `dharma_mining_tax_rates
+WHERE character_id = $1;`
+}
+
+func (p postgres) GetMiningTaxRates() string {
+	return `SELECT character_id, rate FROM ` + p.schema + `dharma_mining_tax_rates;`
+}
+
+// Haul Requests Table
+
+func (p postgres) CreateHaulRequestsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_haul_requests
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  created timestamp with time zone NOT NULL,
+  requester_id integer NOT NULL,
+  pickup text NOT NULL,
+  pickup_location_id bigint NOT NULL,
+  dropoff text NOT NULL,
+  dropoff_location_id bigint NOT NULL,
+  volume double precision NOT NULL,
+  collateral double precision NOT NULL,
+  reward double precision NOT NULL,
+  notes text NOT NULL,
+  status text NOT NULL,
+  hauler_id integer NOT NULL,
+  contract_id integer NOT NULL,
+  contract_status text NOT NULL,
+  updated timestamp with time zone NOT NULL
+);`
+}
+
+func (p postgres) CreateHaulRequestsStatusIndexV0() string {
+	return `
+CREATE INDEX IF NOT EXISTS dharma_haul_requests_status_idx ON ` + p.schema + `dharma_haul_requests
+(status, updated);`
+}
+
+func (p postgres) InsertHaulRequest() string {
+	return `INSERT INTO ` + p.schema + `dharma_haul_requests
+(created, requester_id, pickup, pickup_location_id, dropoff, dropoff_location_id, volume, collateral, reward, notes, status, hauler_id, contract_id, contract_status, updated)
+VALUES
+($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 'open', 0, 0, '', $1);`
+}
+
+func (p postgres) GetHaulRequest() string {
+	return `SELECT id, created, requester_id, pickup, pickup_location_id, dropoff, dropoff_location_id, volume, collateral, reward, notes, status, hauler_id, contract_id, contract_status, updated FROM ` + p.schema + `dharma_haul_requests
+WHERE id = $1;`
+}
+
+func (p postgres) GetHaulRequests() string {
+	return `SELECT id, created, requester_id, pickup, pickup_location_id, dropoff, dropoff_location_id, volume, collateral, reward, notes, status, hauler_id, contract_id, contract_status, updated FROM ` + p.schema + `dharma_haul_requests
+WHERE status IN ('open', 'claimed', 'contracted')
+OR updated >= $1
+ORDER BY created DESC;`
+}
+
+func (p postgres) GetUnfinishedHaulRequests() string {
+	return `SELECT id, created, requester_id, pickup, pickup_location_id, dropoff, dropoff_location_id, volume, collateral, reward, notes, status, hauler_id, contract_id, contract_status, updated FROM ` + p.schema + `dharma_haul_requests
+WHERE status IN ('open', 'claimed', 'contracted')
+ORDER BY created;`
+}
+
+func (p postgres) GetHaulRequestContractIDs() string {
+	return `SELECT contract_id FROM ` + p.schema + `dharma_haul_requests
+WHERE contract_id <> 0;`
+}
+
+func (p postgres) SetHaulRequestHauler() string {
+	return `UPDATE ` + p.schema + `dharma_haul_requests
+SET status = $3, hauler_id = $4, updated = $5
+WHERE id = $1 AND status = $2;`
+}
+
+func (p postgres) SetHaulRequestContract() string {
+	return `UPDATE ` + p.schema + `dharma_haul_requests
+SET status = $3, hauler_id = $4, contract_id = $5, contract_status = $6, updated = $7
+WHERE id = $1 AND status = $2;`
+}
+
+// Kill On Sight Table
+
+func (p postgres) CreateKOSTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_kos
+(
+  entity_id integer PRIMARY KEY,
+  category text NOT NULL,
+  name text NOT NULL,
+  reason text NOT NULL,
+  evidence text NOT NULL,
+  added_by integer NOT NULL,
+  created timestamp with time zone NOT NULL,
+  updated timestamp with time zone NOT NULL,
+  expires timestamp with time zone
+);`
+}
+
+func (p postgres) UpsertKOSEntry() string {
+	return `INSERT INTO ` + p.schema + `dharma_kos
+(entity_id, category, name, reason, evidence, added_by, created, updated, expires)
+VALUES
+($1, $2, $3, $4, $5, $6, $7, $7, $8)
+ON CONFLICT (entity_id) DO UPDATE
+SET category = EXCLUDED.category, name = EXCLUDED.name, reason = EXCLUDED.reason, evidence = EXCLUDED.evidence, added_by = EXCLUDED.added_by, updated = EXCLUDED.updated, expires = EXCLUDED.expires;`
+}
+
+func (p postgres) DeleteKOSEntry() string {
+	return `DELETE FROM ` + p.schema + `dharma_kos
+WHERE entity_id = $1;`
+}
+
+func (p postgres) SearchKOSEntries() string {
+	return `SELECT entity_id, category, name, reason, evidence, added_by, created, updated, expires FROM ` + p.schema + `dharma_kos
+WHERE (expires IS NULL OR expires > $1)
+AND ($2 = '' OR name ILIKE '%' || $2 || '%' OR reason ILIKE '%' || $2 || '%')
+ORDER BY name
+LIMIT $3;`
+}
+
+func (p postgres) GetKOSEntries() string {
+	return `SELECT entity_id, category, name, reason, evidence, added_by, created, updated, expires FROM ` + p.schema + `dharma_kos
+WHERE (expires IS NULL OR expires > $1)
+AND entity_id = ANY($2);`
+}
+
+func (p postgres) GetKOSEditors() string {
+	return `SELECT id FROM ` + p.schema + `users
+WHERE privileges->'Payload'->>'KOSEditor' = 'true';`
+}
+
+// Contacts Table
+
+func (p postgres) CreateContactsTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_contacts
+(
+  source text NOT NULL,
+  contact_id integer NOT NULL,
+  contact_type text NOT NULL,
+  standing double precision NOT NULL,
+  PRIMARY KEY (source, contact_id)
+);`
+}
+
+func (p postgres) DeleteContacts() string {
+	return `DELETE FROM ` + p.schema + `dharma_contacts
+WHERE source = $1;`
+}
+
+func (p postgres) InsertContact() string {
+	return `INSERT INTO ` + p.schema + `dharma_contacts
+(source, contact_id, contact_type, standing)
+VALUES
+($1, $2, $3, $4)
+ON CONFLICT (source, contact_id) DO UPDATE
+SET contact_type = EXCLUDED.contact_type, standing = EXCLUDED.standing;`
+}
+
+func (p postgres) GetStandings() string {
+	return `SELECT DISTINCT ON (contact_id) contact_id, standing FROM ` + p.schema + `dharma_contacts
+WHERE contact_id = ANY($1)
+ORDER BY contact_id, source = 'alliance';`
+}
+
+// Local Scans Table
+
+func (p postgres) CreateLocalScansTableV0() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + p.schema + `dharma_local_scans
+(
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  created timestamp with time zone NOT NULL,
+  created_by integer NOT NULL,
+  expires timestamp with time zone NOT NULL,
+  result jsonb NOT NULL
+);`
+}
+
+func (p postgres) InsertLocalScan() string {
+	return `INSERT INTO ` + p.schema + `dharma_local_scans
+(created, created_by, expires, result)
+VALUES
+($1, $2, $3, $4)
+RETURNING id;`
+}
+
+func (p postgres) GetLocalScan() string {
+	return `SELECT id, created, created_by, expires, result FROM ` + p.schema + `dharma_local_scans
+WHERE id = $1 AND expires > $2;`
+}
+
+func (p postgres) DeleteExpiredLocalScans() string {
+	return `DELETE FROM ` + p.schema + `dharma_local_scans
+WHERE expires <= $1;`
+}
@@ -19,6 +19,7 @@ package features
 import (
 	"sort"
 
+	"github.com/cjslep/dharma/esi"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/pkg/errors"
 	"golang.org/x/text/language"
@@ -90,7 +91,7 @@ func (e *Engine) ValidateFeatureIDs(ids []string) error {
 	return nil
 }
 
-func (e *Engine) DiffScopes(currentIDs, enableIDs, disableIDs []string, langs ...language.Tag) (added, removed []Scope, err error) {
+func (e *Engine) DiffScopes(currentIDs, enableIDs, disableIDs []string, langs ...language.Tag) (added, removed []esi.Scope, err error) {
 	// 0. Validate inputs are well-formed
 	em := make(map[string]bool, len(enableIDs))
 	for _, id := range enableIDs {
@@ -147,8 +148,8 @@ func (e *Engine) DiffScopes(currentIDs, enableIDs, disableIDs []string, langs ..
 	// 3. Diff scopes
 	cs := List(current).Scopes()
 	ns := List(next).Scopes()
-	csm := make(map[Scope]bool, len(cs))
-	nsm := make(map[Scope]bool, len(ns))
+	csm := make(map[esi.Scope]bool, len(cs))
+	nsm := make(map[esi.Scope]bool, len(ns))
 	for _, v := range cs {
 		csm[v] = true
 	}
@@ -19,6 +19,7 @@ package features
 import (
 	"sort"
 
+	"github.com/cjslep/dharma/esi"
 	"github.com/cjslep/dharma/internal/util"
 	"github.com/cjslep/dharma/locales"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
@@ -45,21 +46,49 @@ func allLocalizedFeatures(b *i18n.Bundle, langs ...string) ([]Feature, error) {
 			Description: util.MustPropagateString(m.FeatureCoreCorporationDescription, &err),
 			Scopes: []ScopeExplanation{
 				{
-					Scope:       "esi-corporations.read_corporation_membership.v1",
+					Scope:       esi.ReadCorporationMembershipScope,
 					Explanation: util.MustPropagateString(m.FeatureCoreCorporationReadCorporationMembershipScopeExplanation, &err),
 				},
 				{
-					Scope:       "esi-corporations.read_fw_stats.v1",
+					Scope:       esi.ReadFactionWarfareStatsScope,
 					Explanation: util.MustPropagateString(m.FeatureCoreCorporationReadFactionWarfareStatsScopeExplanation, &err),
 				},
 				{
-					Scope:       "esi-killmails.read_corporation_killmails.v1",
+					Scope:       esi.ReadCorporationKillmailsScope,
 					Explanation: util.MustPropagateString(m.FeatureCoreCorporationReadCorporationKillmailsScopeExplanation, &err),
 				},
 				{
-					Scope:       "esi-wallet.read_corporation_wallets.v1",
+					Scope:       esi.ReadCorporationWalletsScope,
 					Explanation: util.MustPropagateString(m.FeatureCoreCorporationReadCorporationWalletsScopeExplanation, &err),
 				},
+				{
+					Scope:       esi.ReadCorporationRolesScope,
+					Explanation: util.MustPropagateString(m.FeatureCoreCorporationReadCorporationRolesScopeExplanation, &err),
+				},
+				{
+					Scope:       esi.ReadCorporationAssetsScope,
+					Explanation: util.MustPropagateString(m.FeatureCoreCorporationReadCorporationAssetsScopeExplanation, &err),
+				},
+				{
+					Scope:       esi.ReadCorporationIndustryJobsScope,
+					Explanation: util.MustPropagateString(m.FeatureCoreCorporationReadCorporationIndustryJobsScopeExplanation, &err),
+				},
+				{
+					Scope:       esi.ReadCorporationMiningScope,
+					Explanation: util.MustPropagateString(m.FeatureCoreCorporationReadCorporationMiningScopeExplanation, &err),
+				},
+				{
+					Scope:       esi.ReadCorporationContractsScope,
+					Explanation: util.MustPropagateString(m.FeatureCoreCorporationReadCorporationContractsScopeExplanation, &err),
+				},
+				{
+					Scope:       esi.ReadCorporationContactsScope,
+					Explanation: util.MustPropagateString(m.FeatureCoreCorporationReadCorporationContactsScopeExplanation, &err),
+				},
+				{
+					Scope:       esi.ReadAllianceContactsScope,
+					Explanation: util.MustPropagateString(m.FeatureCoreCorporationReadAllianceContactsScopeExplanation, &err),
+				},
 			},
 			Required: true,
 		},
@@ -69,11 +98,11 @@ func allLocalizedFeatures(b *i18n.Bundle, langs ...string) ([]Feature, error) {
 			Description: util.MustPropagateString(m.FeatureCoreCalendarDescription, &err),
 			Scopes: []ScopeExplanation{
 				{
-					Scope:       "esi-calendar.read_calendar_events.v1",
+					Scope:       esi.ReadCalendarEventsScope,
 					Explanation: util.MustPropagateString(m.FeatureCoreReadCalendarEventsScopeExplanation, &err),
 				},
 				{
-					Scope:       "esi-calendar.respond_calendar_events.v1",
+					Scope:       esi.RespondCalendarEventsScope,
 					Explanation: util.MustPropagateString(m.FeatureCoreRespondCalendarEventsScopeExplanation, &err),
 				},
 			},
@@ -85,15 +114,15 @@ func allLocalizedFeatures(b *i18n.Bundle, langs ...string) ([]Feature, error) {
 			Description: util.MustPropagateString(m.FeatureCoreMailDescription, &err),
 			Scopes: []ScopeExplanation{
 				{
-					Scope:       "esi-mail.read_mail.v1",
+					Scope:       esi.ReadMailScope,
 					Explanation: util.MustPropagateString(m.FeatureCoreReadMailScopeExplanation, &err),
 				},
 				{
-					Scope:       "esi-mail.send_mail.v1",
+					Scope:       esi.SendMailScope,
 					Explanation: util.MustPropagateString(m.FeatureCoreSendMailScopeExplanation, &err),
 				},
 				{
-					Scope:       "esi-mail.organize_mail.v1",
+					Scope:       esi.OrganizeMailScope,
 					Explanation: util.MustPropagateString(m.FeatureCoreOrganizeMailScopeExplanation, &err),
 				},
 			},
@@ -111,7 +140,7 @@ type Feature struct {
 }
 
 type ScopeExplanation struct {
-	Scope       Scope
+	Scope       esi.Scope
 	Explanation string
 }
 
@@ -127,16 +156,16 @@ func (l List) IDs() []string {
 	return s
 }
 
-func (l List) Scopes() []Scope {
+func (l List) Scopes() []esi.Scope {
 	// Deduplicate scopes
-	m := make(map[Scope]bool, len(l))
+	m := make(map[esi.Scope]bool, len(l))
 	for i := range l {
 		for _, se := range l[i].Scopes {
 			m[se.Scope] = true
 		}
 	}
 	// Put into slice
-	s := make([]Scope, 0, len(m))
+	s := make([]esi.Scope, 0, len(m))
 	for k := range m {
 		s = append(s, k)
 	}
@@ -147,7 +176,7 @@ func (l List) Scopes() []Scope {
 
 func (l List) ScopeExplanations() []ScopeExplanations {
 	// Deduplicate scopes
-	m := make(map[Scope][]string, len(l))
+	m := make(map[esi.Scope][]string, len(l))
 	for i := range l {
 		for _, se := range l[i].Scopes {
 			if x, ok := m[se.Scope]; ok {
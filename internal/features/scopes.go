@@ -16,9 +16,11 @@
 
 package features
 
-type Scope string
+import (
+	"github.com/cjslep/dharma/esi"
+)
 
-type Scopes []Scope
+type Scopes []esi.Scope
 
 func (s Scopes) Len() int {
 	return len(s)
@@ -33,7 +35,7 @@ func (s Scopes) Swap(i, j int) {
 }
 
 type ScopeExplanations struct {
-	Scope        Scope
+	Scope        esi.Scope
 	Explanations []string
 }
 
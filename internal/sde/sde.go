@@ -0,0 +1,392 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package sde imports CCP Games' Static Data Export, the data about types and the
+// universe that does not change between game releases.
+package sde
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v2"
+)
+
+// The YAML files of the Static Data Export that are imported, found at the top
+// level of its unzipped directory.
+const (
+	buildFile        = "_sde.yaml"
+	typesFile        = "types.yaml"
+	groupsFile       = "groups.yaml"
+	marketGroupsFile = "marketGroups.yaml"
+	regionsFile      = "mapRegions.yaml"
+	solarSystemsFile = "mapSolarSystems.yaml"
+	stargatesFile    = "mapStargates.yaml"
+)
+
+// Names are an entity's localized names keyed by language code, such as "en".
+type Names map[string]string
+
+var _ driver.Valuer = Names{}
+var _ sql.Scanner = &Names{}
+
+func (n Names) Value() (driver.Value, error) {
+	return json.Marshal(n)
+}
+
+func (n *Names) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return errors.New("failed to assert scan src to []byte type")
+	}
+	return json.Unmarshal(b, n)
+}
+
+// Get obtains the name in the language, falling back to English when there is
+// no translation.
+func (n Names) Get(tag language.Tag) string {
+	base, _ := tag.Base()
+	if s := n[base.String()]; len(s) > 0 {
+		return s
+	}
+	return n["en"]
+}
+
+type Type struct {
+	ID            int32
+	GroupID       int32
+	MarketGroupID int32 // Zero if the type cannot be sold on the market
+	Name          Names
+	Volume        float64
+	Published     bool
+}
+
+type Group struct {
+	ID         int32
+	CategoryID int32
+	Name       Names
+	Published  bool
+}
+
+type MarketGroup struct {
+	ID       int32
+	ParentID int32 // Zero for top-level market groups
+	Name     Names
+	HasTypes bool
+}
+
+type Region struct {
+	ID   int32
+	Name Names
+}
+
+type SolarSystem struct {
+	ID              int32
+	ConstellationID int32
+	RegionID        int32
+	Name            Names
+	Security        float64
+}
+
+// Stargate is one side of a jump connection between two solar systems.
+type Stargate struct {
+	ID                       int32
+	SolarSystemID            int32
+	DestinationID            int32
+	DestinationSolarSystemID int32
+}
+
+// Build identifies a release of the Static Data Export.
+type Build struct {
+	Number      int64     `yaml:"buildNumber"`
+	ReleaseDate time.Time `yaml:"releaseDate"`
+}
+
+// importBatchSize is how many entries of a table are decoded before they are
+// handed to an Importer, bounding how much of the export is held in memory.
+const importBatchSize = 1000
+
+// Importer stores the Static Data Export as Import decodes it.
+type Importer interface {
+	// ClearSDE removes the previously imported Static Data Export.
+	ClearSDE(c context.Context) error
+	InsertSDETypes(c context.Context, ts []Type) error
+	InsertSDEGroups(c context.Context, gs []Group) error
+	InsertSDEMarketGroups(c context.Context, mgs []MarketGroup) error
+	InsertSDERegions(c context.Context, rs []Region) error
+	InsertSDESolarSystems(c context.Context, ss []SolarSystem) error
+	InsertSDEStargates(c context.Context, sgs []Stargate) error
+	// SetSDEBuild records the release that was imported.
+	SetSDEBuild(c context.Context, b Build) error
+}
+
+// ReadBuild determines which release of the Static Data Export is unzipped in
+// the directory, without loading all of it.
+func ReadBuild(dir string) (b Build, err error) {
+	err = readYAML(dir, buildFile, &b)
+	if err == nil && b.Number == 0 {
+		err = errors.Errorf("no build number in %s", filepath.Join(dir, buildFile))
+	}
+	return
+}
+
+// Import replaces the Static Data Export stored by the Importer with the one
+// unzipped in the directory. Its files are decoded one entry at a time, as the
+// largest are hundreds of megabytes, and handed over in batches so that only a
+// batch is held in memory. The build is recorded last, so an import that fails
+// part way through is retried in full.
+func Import(c context.Context, dir string, to Importer) error {
+	b, err := ReadBuild(dir)
+	if err != nil {
+		return err
+	}
+	if err := to.ClearSDE(c); err != nil {
+		return err
+	}
+
+	var ts []Type
+	err = streamYAML(dir, typesFile, func(e []byte) error {
+		var m map[int32]struct {
+			GroupID       int32   `yaml:"groupID"`
+			MarketGroupID int32   `yaml:"marketGroupID"`
+			Name          Names   `yaml:"name"`
+			Volume        float64 `yaml:"volume"`
+			Published     bool    `yaml:"published"`
+		}
+		if err := yaml.Unmarshal(e, &m); err != nil {
+			return err
+		}
+		for id, t := range m {
+			ts = append(ts, Type{id, t.GroupID, t.MarketGroupID, t.Name, t.Volume, t.Published})
+		}
+		if len(ts) < importBatchSize {
+			return nil
+		}
+		err := to.InsertSDETypes(c, ts)
+		ts = ts[:0]
+		return err
+	})
+	if err == nil && len(ts) > 0 {
+		err = to.InsertSDETypes(c, ts)
+	}
+	if err != nil {
+		return err
+	}
+
+	var gs []Group
+	err = streamYAML(dir, groupsFile, func(e []byte) error {
+		var m map[int32]struct {
+			CategoryID int32 `yaml:"categoryID"`
+			Name       Names `yaml:"name"`
+			Published  bool  `yaml:"published"`
+		}
+		if err := yaml.Unmarshal(e, &m); err != nil {
+			return err
+		}
+		for id, g := range m {
+			gs = append(gs, Group{id, g.CategoryID, g.Name, g.Published})
+		}
+		if len(gs) < importBatchSize {
+			return nil
+		}
+		err := to.InsertSDEGroups(c, gs)
+		gs = gs[:0]
+		return err
+	})
+	if err == nil && len(gs) > 0 {
+		err = to.InsertSDEGroups(c, gs)
+	}
+	if err != nil {
+		return err
+	}
+
+	var mgs []MarketGroup
+	err = streamYAML(dir, marketGroupsFile, func(e []byte) error {
+		var m map[int32]struct {
+			ParentGroupID int32 `yaml:"parentGroupID"`
+			Name          Names `yaml:"name"`
+			HasTypes      bool  `yaml:"hasTypes"`
+		}
+		if err := yaml.Unmarshal(e, &m); err != nil {
+			return err
+		}
+		for id, mg := range m {
+			mgs = append(mgs, MarketGroup{id, mg.ParentGroupID, mg.Name, mg.HasTypes})
+		}
+		if len(mgs) < importBatchSize {
+			return nil
+		}
+		err := to.InsertSDEMarketGroups(c, mgs)
+		mgs = mgs[:0]
+		return err
+	})
+	if err == nil && len(mgs) > 0 {
+		err = to.InsertSDEMarketGroups(c, mgs)
+	}
+	if err != nil {
+		return err
+	}
+
+	var rs []Region
+	err = streamYAML(dir, regionsFile, func(e []byte) error {
+		var m map[int32]struct {
+			Name Names `yaml:"name"`
+		}
+		if err := yaml.Unmarshal(e, &m); err != nil {
+			return err
+		}
+		for id, r := range m {
+			rs = append(rs, Region{id, r.Name})
+		}
+		if len(rs) < importBatchSize {
+			return nil
+		}
+		err := to.InsertSDERegions(c, rs)
+		rs = rs[:0]
+		return err
+	})
+	if err == nil && len(rs) > 0 {
+		err = to.InsertSDERegions(c, rs)
+	}
+	if err != nil {
+		return err
+	}
+
+	var ss []SolarSystem
+	err = streamYAML(dir, solarSystemsFile, func(e []byte) error {
+		var m map[int32]struct {
+			ConstellationID int32   `yaml:"constellationID"`
+			RegionID        int32   `yaml:"regionID"`
+			Name            Names   `yaml:"name"`
+			SecurityStatus  float64 `yaml:"securityStatus"`
+		}
+		if err := yaml.Unmarshal(e, &m); err != nil {
+			return err
+		}
+		for id, s := range m {
+			ss = append(ss, SolarSystem{id, s.ConstellationID, s.RegionID, s.Name, s.SecurityStatus})
+		}
+		if len(ss) < importBatchSize {
+			return nil
+		}
+		err := to.InsertSDESolarSystems(c, ss)
+		ss = ss[:0]
+		return err
+	})
+	if err == nil && len(ss) > 0 {
+		err = to.InsertSDESolarSystems(c, ss)
+	}
+	if err != nil {
+		return err
+	}
+
+	var sgs []Stargate
+	err = streamYAML(dir, stargatesFile, func(e []byte) error {
+		var m map[int32]struct {
+			SolarSystemID int32 `yaml:"solarSystemID"`
+			Destination   struct {
+				SolarSystemID int32 `yaml:"solarSystemID"`
+				StargateID    int32 `yaml:"stargateID"`
+			} `yaml:"destination"`
+		}
+		if err := yaml.Unmarshal(e, &m); err != nil {
+			return err
+		}
+		for id, sg := range m {
+			sgs = append(sgs, Stargate{id, sg.SolarSystemID, sg.Destination.StargateID, sg.Destination.SolarSystemID})
+		}
+		if len(sgs) < importBatchSize {
+			return nil
+		}
+		err := to.InsertSDEStargates(c, sgs)
+		sgs = sgs[:0]
+		return err
+	})
+	if err == nil && len(sgs) > 0 {
+		err = to.InsertSDEStargates(c, sgs)
+	}
+	if err != nil {
+		return err
+	}
+	return to.SetSDEBuild(c, b)
+}
+
+func readYAML(dir, name string, v interface{}) error {
+	path := filepath.Join(dir, name)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(yaml.Unmarshal(b, v), "could not parse %s", path)
+}
+
+// streamYAML splits a file whose top level is a mapping into its entries, and
+// calls fn with each entry as a YAML document mapping only its own key.
+func streamYAML(dir, name string, fn func(entry []byte) error) error {
+	path := filepath.Join(dir, name)
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var entry []byte
+	flush := func() error {
+		if len(entry) == 0 {
+			return nil
+		}
+		err := fn(entry)
+		entry = entry[:0]
+		return errors.Wrapf(err, "could not parse %s", path)
+	}
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			// An unindented key begins the next entry.
+			if isTopLevelKey(line) {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			entry = append(entry, line...)
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	return flush()
+}
+
+func isTopLevelKey(line []byte) bool {
+	switch line[0] {
+	case ' ', '\t', '\r', '\n', '#', '-':
+		return false
+	default:
+		return true
+	}
+}
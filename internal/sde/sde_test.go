@@ -0,0 +1,198 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sde
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// recorder is an Importer that keeps what it is given.
+type recorder struct {
+	cleared      bool
+	batches      []int
+	build        Build
+	types        []Type
+	groups       []Group
+	marketGroups []MarketGroup
+	regions      []Region
+	solarSystems []SolarSystem
+	stargates    []Stargate
+}
+
+func (r *recorder) ClearSDE(c context.Context) error {
+	r.cleared = true
+	return nil
+}
+
+func (r *recorder) InsertSDETypes(c context.Context, ts []Type) error {
+	r.batches = append(r.batches, len(ts))
+	r.types = append(r.types, ts...)
+	return nil
+}
+
+func (r *recorder) InsertSDEGroups(c context.Context, gs []Group) error {
+	r.groups = append(r.groups, gs...)
+	return nil
+}
+
+func (r *recorder) InsertSDEMarketGroups(c context.Context, mgs []MarketGroup) error {
+	r.marketGroups = append(r.marketGroups, mgs...)
+	return nil
+}
+
+func (r *recorder) InsertSDERegions(c context.Context, rs []Region) error {
+	r.regions = append(r.regions, rs...)
+	return nil
+}
+
+func (r *recorder) InsertSDESolarSystems(c context.Context, ss []SolarSystem) error {
+	r.solarSystems = append(r.solarSystems, ss...)
+	return nil
+}
+
+func (r *recorder) InsertSDEStargates(c context.Context, sgs []Stargate) error {
+	r.stargates = append(r.stargates, sgs...)
+	return nil
+}
+
+func (r *recorder) SetSDEBuild(c context.Context, b Build) error {
+	r.build = b
+	return nil
+}
+
+func writeExport(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, s := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(s), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestImportDecodesEachEntry(t *testing.T) {
+	dir := writeExport(t, map[string]string{
+		buildFile: "buildNumber: 2967142\nreleaseDate: 2025-06-10T11:00:00Z\n",
+		typesFile: `# Comments and blank lines belong to no entry.
+18:
+    groupID: 450
+    marketGroupID: 516
+    name:
+        de: Plagioclase
+        en: Plagioclase
+
+    published: true
+    volume: 0.35
+34:
+    groupID: 18
+    name:
+        en: Tritanium
+    published: true
+    volume: 0.01
+`,
+		groupsFile:       "450:\n    categoryID: 25\n    name:\n        en: Plagioclase\n    published: true\n",
+		marketGroupsFile: "516:\n    hasTypes: true\n    name:\n        en: Plagioclase\n    parentGroupID: 54\n",
+		regionsFile:      "10000002:\n    name:\n        en: The Forge\n",
+		solarSystemsFile: "30000142:\n    constellationID: 20000020\n    name:\n        en: Jita\n    regionID: 10000002\n    securityStatus: 0.9459\n",
+		stargatesFile:    "50001248:\n    destination:\n        solarSystemID: 30000144\n        stargateID: 50001249\n    solarSystemID: 30000142\n",
+	})
+	e := &recorder{}
+	if err := Import(context.Background(), dir, e); err != nil {
+		t.Fatal(err)
+	}
+	if !e.cleared {
+		t.Error("previous import was not cleared")
+	}
+	if e.build.Number != 2967142 {
+		t.Errorf("build %d, want 2967142", e.build.Number)
+	}
+	if len(e.types) != 2 {
+		t.Fatalf("%d types, want 2", len(e.types))
+	}
+	types := map[int32]Type{}
+	for _, ty := range e.types {
+		types[ty.ID] = ty
+	}
+	if ty := types[18]; ty.GroupID != 450 || ty.MarketGroupID != 516 || ty.Name["de"] != "Plagioclase" || ty.Volume != 0.35 || !ty.Published {
+		t.Errorf("type 18 decoded as %+v", ty)
+	}
+	if ty := types[34]; ty.GroupID != 18 || ty.MarketGroupID != 0 || ty.Name["en"] != "Tritanium" {
+		t.Errorf("type 34 decoded as %+v", ty)
+	}
+	if len(e.groups) != 1 || e.groups[0].CategoryID != 25 {
+		t.Errorf("groups decoded as %+v", e.groups)
+	}
+	if len(e.marketGroups) != 1 || e.marketGroups[0].ParentID != 54 || !e.marketGroups[0].HasTypes {
+		t.Errorf("market groups decoded as %+v", e.marketGroups)
+	}
+	if len(e.regions) != 1 || e.regions[0].Name["en"] != "The Forge" {
+		t.Errorf("regions decoded as %+v", e.regions)
+	}
+	if len(e.solarSystems) != 1 || e.solarSystems[0].RegionID != 10000002 || e.solarSystems[0].Security != 0.9459 {
+		t.Errorf("solar systems decoded as %+v", e.solarSystems)
+	}
+	if len(e.stargates) != 1 || e.stargates[0].DestinationID != 50001249 || e.stargates[0].DestinationSolarSystemID != 30000144 {
+		t.Errorf("stargates decoded as %+v", e.stargates)
+	}
+}
+
+func TestImportInsertsInBatches(t *testing.T) {
+	var types strings.Builder
+	n := 2*importBatchSize + 1
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&types, "%d:\n    groupID: 18\n    volume: 0.01\n", i)
+	}
+	dir := writeExport(t, map[string]string{
+		buildFile:        "buildNumber: 1\n",
+		typesFile:        types.String(),
+		groupsFile:       "",
+		marketGroupsFile: "",
+		regionsFile:      "",
+		solarSystemsFile: "",
+		stargatesFile:    "",
+	})
+	e := &recorder{}
+	if err := Import(context.Background(), dir, e); err != nil {
+		t.Fatal(err)
+	}
+	if len(e.types) != n {
+		t.Fatalf("%d types, want %d", len(e.types), n)
+	}
+	if want := []int{importBatchSize, importBatchSize, 1}; fmt.Sprint(e.batches) != fmt.Sprint(want) {
+		t.Errorf("types inserted in batches of %v, want %v", e.batches, want)
+	}
+}
+
+func TestImportReportsTheBrokenFile(t *testing.T) {
+	dir := writeExport(t, map[string]string{
+		buildFile: "buildNumber: 1\n",
+		typesFile: "18:\n    groupID: [\n",
+	})
+	e := &recorder{}
+	if err := Import(context.Background(), dir, e); err == nil {
+		t.Fatal("imported a broken types file")
+	}
+	if e.build.Number != 0 {
+		t.Error("recorded the build of a failed import")
+	}
+}
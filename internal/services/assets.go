@@ -0,0 +1,265 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/async"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/rs/zerolog"
+	"golang.org/x/text/language"
+)
+
+const (
+	// officeLocationFlag marks the office that holds a corporation's
+	// hangars in a station.
+	officeLocationFlag = "OfficeFolder"
+	// hangarLocationFlagPrefix begins the location flags of the seven
+	// corporation hangar divisions, "CorpSAG1" through "CorpSAG7".
+	hangarLocationFlagPrefix = "CorpSAG"
+	// nAssetResults is the most assets shown when browsing.
+	nAssetResults = 500
+)
+
+// Assets takes periodic snapshots of the corporation's assets, so directors
+// can browse what it owns and where.
+type Assets struct {
+	DB           *db.DB
+	ESI          *ESI
+	SDE          *SDE
+	Names        *Names
+	L            *zerolog.Logger
+	PeriodicSync time.Duration
+	Retention    time.Duration
+}
+
+func (a *Assets) GoPeriodicallySnapshotAssets(m *async.Messenger) {
+	m.NowAndPeriodically(a.PeriodicSync, a.snapshotAssets, a.L)
+}
+
+// snapshotAssets fetches the corporation's assets using the authoritative
+// character's token, and stores them placed in their stations, structures, and
+// solar systems.
+func (a *Assets) snapshotAssets(c context.Context) error {
+	corpID, err := a.DB.GetCorporationManaged(c)
+	if err != nil || corpID == 0 {
+		return err
+	}
+	charID, err := a.DB.GetAuthoritativeCharacter(c)
+	if err != nil || charID == 0 {
+		return err
+	}
+	ac := a.ESI.AuthClient()
+	as, err := ac.CorporationAssets(c, charID, corpID)
+	if err != nil {
+		return err
+	}
+	names, err := ac.CorporationAssetNames(c, charID, corpID, containerIDs(as))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	return a.DB.InsertCorporationAssetSnapshot(c, now, resolveAssets(as, names), now.Add(-a.Retention))
+}
+
+// containerIDs finds the assets that other assets are inside of, other than
+// offices, since only those may have been named by a player.
+func containerIDs(as []esi.Asset) []int64 {
+	byID := make(map[int64]esi.Asset, len(as))
+	for _, a := range as {
+		byID[a.ItemID] = a
+	}
+	seen := make(map[int64]bool)
+	var ids []int64
+	for _, a := range as {
+		p, ok := byID[a.LocationID]
+		if !ok || !p.IsSingleton || p.LocationFlag == officeLocationFlag || seen[p.ItemID] {
+			continue
+		}
+		seen[p.ItemID] = true
+		ids = append(ids, p.ItemID)
+	}
+	return ids
+}
+
+// resolveAssets places every asset, other than offices, in the station,
+// structure, or solar system ultimately holding it, along with the hangar
+// division and container it is in.
+func resolveAssets(as []esi.Asset, names map[int64]string) []db.CorporationAsset {
+	byID := make(map[int64]esi.Asset, len(as))
+	for _, a := range as {
+		byID[a.ItemID] = a
+	}
+	out := make([]db.CorporationAsset, 0, len(as))
+	for _, a := range as {
+		if a.LocationFlag == officeLocationFlag {
+			continue
+		}
+		ca := db.CorporationAsset{
+			ItemID:          a.ItemID,
+			TypeID:          a.TypeID,
+			Quantity:        a.Quantity,
+			LocationFlag:    a.LocationFlag,
+			Name:            names[a.ItemID],
+			IsSingleton:     a.IsSingleton,
+			IsBlueprintCopy: a.IsBlueprintCopy,
+		}
+		cur := a
+		// Bound the walk in case ESI ever reports a cycle.
+		for depth := 0; depth < len(as); depth++ {
+			if ca.Division == 0 {
+				ca.Division = hangarDivision(cur.LocationFlag)
+			}
+			p, ok := byID[cur.LocationID]
+			if !ok {
+				break
+			}
+			if ca.ContainerID == 0 && p.LocationFlag != officeLocationFlag {
+				ca.ContainerID = p.ItemID
+				ca.ContainerTypeID = p.TypeID
+				ca.ContainerName = names[p.ItemID]
+			}
+			cur = p
+		}
+		ca.LocationID = cur.LocationID
+		ca.LocationType = cur.LocationType
+		out = append(out, ca)
+	}
+	return out
+}
+
+// hangarDivision determines the corporation hangar division of the location
+// flag, which is zero if it is not a corporation hangar.
+func hangarDivision(flag string) int32 {
+	if !strings.HasPrefix(flag, hangarLocationFlagPrefix) {
+		return 0
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(flag, hangarLocationFlagPrefix))
+	if err != nil || n < 1 || n > esi.NHangarDivisions {
+		return 0
+	}
+	return int32(n)
+}
+
+// isNamedLocation determines whether the location is a station or solar
+// system, whose name ESI resolves.
+func isNamedLocation(l db.AssetLocation) bool {
+	return l.LocationID <= math.MaxInt32 &&
+		(l.LocationType == esi.AssetLocationStation || l.LocationType == esi.AssetLocationSolarSystem)
+}
+
+// AssetFilter narrows the assets being browsed. Zero values match everything.
+type AssetFilter struct {
+	LocationID int64
+	Division   int32
+	Query      string
+}
+
+type AssetLocation struct {
+	db.AssetLocation
+	// Name is empty if the location could not be named, such as for
+	// player-owned structures.
+	Name string
+}
+
+type Asset struct {
+	db.CorporationAsset
+	TypeName          string
+	ContainerTypeName string
+	LocationName      string
+}
+
+type AssetBrowser struct {
+	// Snapshot is zero if the assets were never fetched.
+	Snapshot  time.Time
+	Locations []AssetLocation
+	Assets    []Asset
+	// Truncated is true when more assets match than are shown.
+	Truncated bool
+}
+
+// Browse obtains the assets in the latest snapshot that match the filter,
+// along with every location holding assets.
+func (a *Assets) Browse(c context.Context, f AssetFilter, lang language.Tag) (*AssetBrowser, error) {
+	b := &AssetBrowser{}
+	var err error
+	if b.Snapshot, err = a.DB.GetLatestCorporationAssetSnapshot(c); err != nil || b.Snapshot.IsZero() {
+		return b, err
+	}
+	ls, err := a.DB.GetCorporationAssetLocations(c, b.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	as, err := a.DB.SearchCorporationAssets(c, b.Snapshot, f.LocationID, f.Division, strings.TrimSpace(f.Query), nAssetResults+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(as) > nAssetResults {
+		as = as[:nAssetResults]
+		b.Truncated = true
+	}
+
+	// Only stations and solar systems have names ESI resolves. Structures
+	// and other locations, such as asset safety, are left unnamed.
+	var locIDs []int32
+	for _, l := range ls {
+		if isNamedLocation(l) {
+			locIDs = append(locIDs, int32(l.LocationID))
+		}
+	}
+	es, err := a.Names.ResolveNames(c, locIDs)
+	if err != nil {
+		return nil, err
+	}
+	b.Locations = make([]AssetLocation, len(ls))
+	locNames := make(map[int64]string, len(ls))
+	for i, l := range ls {
+		b.Locations[i] = AssetLocation{AssetLocation: l}
+		if isNamedLocation(l) {
+			b.Locations[i].Name = es[int32(l.LocationID)].Name
+		}
+		locNames[l.LocationID] = b.Locations[i].Name
+	}
+
+	typeIDs := make([]int32, 0, len(as))
+	for _, x := range as {
+		typeIDs = append(typeIDs, x.TypeID)
+		if x.ContainerTypeID != 0 {
+			typeIDs = append(typeIDs, x.ContainerTypeID)
+		}
+	}
+	ts, err := a.SDE.Types(c, uniqueIDs(typeIDs))
+	if err != nil {
+		return nil, err
+	}
+	b.Assets = make([]Asset, len(as))
+	for i, x := range as {
+		b.Assets[i] = Asset{
+			CorporationAsset:  x,
+			TypeName:          ts[x.TypeID].Name.Get(lang),
+			ContainerTypeName: ts[x.ContainerTypeID].Name.Get(lang),
+			LocationName:      locNames[x.LocationID],
+		}
+	}
+	return b, nil
+}
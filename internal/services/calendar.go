@@ -0,0 +1,119 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/async"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/cjslep/dharma/internal/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+var (
+	NotInvitedError = errors.New("cannot respond to calendar event: character is not invited")
+)
+
+type Calendar struct {
+	DB           *db.DB
+	ESI          *ESI
+	L            *zerolog.Logger
+	PeriodicSync time.Duration
+}
+
+func (k *Calendar) GoPeriodicallySyncCalendars(m *async.Messenger) {
+	m.NowAndPeriodically(k.PeriodicSync, k.syncCalendars, k.L)
+}
+
+// syncCalendars fetches the upcoming events on the in-game calendar of every
+// character that granted access to it.
+func (k *Calendar) syncCalendars(c context.Context) error {
+	ids, err := k.DB.GetCharactersWithScope(c, string(esi.ReadCalendarEventsScope))
+	if err != nil {
+		return err
+	}
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		errs[i] = k.syncCalendar(c, id)
+	}
+	return util.ToErrors(errs)
+}
+
+// syncCalendar fetches the details of the character's events that are not yet
+// stored, and updates the rest.
+func (k *Calendar) syncCalendar(c context.Context, charID int32) error {
+	ac := k.ESI.AuthClient()
+	es, err := ac.CalendarEvents(c, charID)
+	if err != nil {
+		return err
+	} else if len(es) == 0 {
+		return nil
+	}
+	ids := make([]int32, len(es))
+	for i, e := range es {
+		ids[i] = e.ID
+	}
+	known, err := k.DB.GetKnownCalendarEventIDs(c, ids)
+	if err != nil {
+		return err
+	}
+	isKnown := make(map[int32]bool, len(known))
+	for _, id := range known {
+		isKnown[id] = true
+	}
+	var detailed, summaries []esi.CalendarEvent
+	for _, e := range es {
+		if isKnown[e.ID] {
+			summaries = append(summaries, e)
+			continue
+		}
+		d, err := ac.CalendarEvent(c, charID, e.ID)
+		if err != nil {
+			return err
+		}
+		detailed = append(detailed, *d)
+	}
+	return k.DB.SetCalendarEvents(c, charID, detailed, summaries)
+}
+
+// GetUpcomingCorporationEvents obtains the managed corporation's events from
+// the start of today onwards, along with the character's responses to them.
+func (k *Calendar) GetUpcomingCorporationEvents(c context.Context, charID int32) ([]db.CalendarEventSummary, error) {
+	corpID, err := k.DB.GetCorporationManaged(c)
+	if err != nil {
+		return nil, err
+	}
+	return k.DB.GetCalendarEventsOwnedBySince(c, corpID, today(), charID)
+}
+
+// Respond sets the character's response to the event in game, and records it
+// locally so it is shown right away.
+func (k *Calendar) Respond(c context.Context, charID, eventID int32, response string) error {
+	if invited, err := k.DB.HasCalendarResponse(c, eventID, charID); err != nil {
+		return err
+	} else if !invited {
+		return NotInvitedError
+	}
+	if err := k.ESI.AuthClient().RespondToCalendarEvent(c, charID, eventID, response); err != nil {
+		return err
+	}
+	return k.DB.SetCalendarResponse(c, eventID, charID, response)
+}
@@ -19,17 +19,41 @@ package services
 import (
 	"context"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cjslep/dharma/esi"
 	"github.com/cjslep/dharma/internal/async"
 	"github.com/cjslep/dharma/internal/db"
 	dutil "github.com/cjslep/dharma/internal/util"
+	"github.com/pascaldekloe/jwt"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"golang.org/x/text/language"
 )
 
+// tokenRefreshMargin is how soon before expiring that an access token is
+// refreshed before being used.
+const tokenRefreshMargin = 2 * time.Minute
+
+// nTokenLocks is how many locks the refreshing of characters' tokens is spread
+// over.
+const nTokenLocks = 64
+
+// nKeySetsKept is how many of the most recently fetched sets of EVE public keys
+// are used to validate tokens, so a rotated-out key is still honored.
+const nKeySetsKept = 2
+
+var _ esi.TokenSource = &ESI{}
+
+var (
+	NotUsersCharacterError = errors.New("character is not linked to the user")
+	// AuthoritativeCharacterError is returned when unlinking the character
+	// that the corporation is synced through, which an admin must first
+	// replace by choosing the corporation again with another character.
+	AuthoritativeCharacterError = errors.New("character is the corporation's authoritative character")
+)
+
 type ESI struct {
 	DB               *db.DB
 	OAC              *esi.OAuth2Client
@@ -37,6 +61,27 @@ type ESI struct {
 	ESIClient        *esi.Client
 	PeriodicRefresh  time.Duration
 	PeriodicKeyFetch time.Duration
+	PeriodicPrune    time.Duration
+	CacheRetention   time.Duration
+	ErrorLimiter     *esi.ErrorLimiter
+	TokenLocks       *TokenLocks
+}
+
+// TokenLocks serializes refreshing a character's tokens, so that concurrent
+// refreshes do not each spend the same refresh token. It is shared by all ESI
+// services.
+type TokenLocks struct {
+	mu [nTokenLocks]sync.Mutex
+}
+
+func (t *TokenLocks) lock(charID int32) func() {
+	mu := &t.mu[uint32(charID)%nTokenLocks]
+	mu.Lock()
+	return mu.Unlock
+}
+
+func (e *ESI) ErrorLimitStatus() esi.ErrorLimitStatus {
+	return e.ErrorLimiter.Status()
 }
 
 func (e *ESI) GoPeriodicallyFetchEvePublicKeys(m *async.Messenger) {
@@ -44,15 +89,50 @@ func (e *ESI) GoPeriodicallyFetchEvePublicKeys(m *async.Messenger) {
 }
 
 func (e *ESI) fetchEveOnlineKeys(c context.Context) error {
-	keys, err := esi.FetchEveOnlineKeys()
+	keys, err := e.OAC.FetchEveOnlineKeys()
 	if err != nil {
 		return err
 	}
 	return e.DB.SetEvePublicKeys(c, *keys)
 }
 
-func (e *ESI) GetEvePublicKeys(c context.Context) (*esi.OAuthKeysMetadata, error) {
-	return e.DB.GetEvePublicKeys(c)
+func (e *ESI) GoPeriodicallyPruneESICache(m *async.Messenger) {
+	m.Periodically(e.PeriodicPrune, e.pruneESICache, e.L)
+}
+
+// pruneESICache removes cached ESI responses that have been stale for so long
+// that revalidating them with their ETag is unlikely to be worthwhile.
+func (e *ESI) pruneESICache(c context.Context) error {
+	return e.DB.DeleteESIResponsesExpiredBefore(c, time.Now().Add(-e.CacheRetention))
+}
+
+// ValidateAccessToken verifies the signature and claims of an access token
+// issued by the single sign on. The current and previous key sets are tried so
+// that tokens keep validating while the keys rotate. A token signed by a key
+// that is in neither set causes the keys to be fetched again before giving up.
+func (e *ESI) ValidateAccessToken(c context.Context, access string) (*jwt.Claims, error) {
+	ks, err := e.DB.GetRecentEvePublicKeys(c, nKeySetsKept)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := esi.ValidateToken([]byte(access), ks...)
+	if _, ok := err.(*esi.UnknownKeyIDError); ok {
+		if ferr := e.fetchEveOnlineKeys(c); ferr != nil {
+			return nil, errors.Wrap(ferr, "could not refetch EVE public keys for unknown key id")
+		}
+		ks, err = e.DB.GetRecentEvePublicKeys(c, nKeySetsKept)
+		if err != nil {
+			return nil, err
+		}
+		claims, err = esi.ValidateToken([]byte(access), ks...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := esi.ValidateEveClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
 }
 
 func (e *ESI) SetEveTokens(c context.Context, userID string, t *esi.Tokens) error {
@@ -63,6 +143,35 @@ func (e *ESI) GetEveToken(c context.Context, charID int32) (*esi.Tokens, error)
 	return e.DB.GetEveToken(c, charID)
 }
 
+// Token obtains the character's tokens, refreshing them if the access token is
+// about to expire or if the granted scopes were never recorded.
+func (e *ESI) Token(c context.Context, charID int32) (*esi.Tokens, error) {
+	t, err := e.DB.GetEveToken(c, charID)
+	if err != nil {
+		return nil, err
+	}
+	if t.CID == 0 {
+		return nil, errors.Errorf("no esi tokens for character id: %d", charID)
+	}
+	if !needsRefresh(t, tokenRefreshMargin) {
+		return t, nil
+	}
+	userID, err := e.DB.GetUserForCharacter(c, charID)
+	if err != nil {
+		return nil, err
+	}
+	return e.refreshTokenWithin(c, userID, charID, tokenRefreshMargin)
+}
+
+func needsRefresh(t *esi.Tokens, within time.Duration) bool {
+	return t.Scopes == nil || !time.Now().Add(within).Before(t.AccessExpires)
+}
+
+// AuthClient issues ESI calls authenticated as members' characters.
+func (e *ESI) AuthClient() *esi.AuthClient {
+	return e.ESIClient.Authenticated(e)
+}
+
 func (e *ESI) GoPeriodicallyRefreshAllTokens(m *async.Messenger) {
 	m.Periodically(e.PeriodicRefresh, e.refreshAllTokens, e.L)
 }
@@ -72,49 +181,55 @@ func (e *ESI) refreshAllTokens(c context.Context) error {
 	if err != nil {
 		return err
 	}
-	errs := make([]error, 0, len(uts))
+	errs := make([]error, len(uts))
 	for i, ut := range uts {
-		errs[i] = e.refreshToken(c, ut.UserID, ut.T.Refresh)
+		_, errs[i] = e.refreshTokenWithin(c, ut.UserID, int32(ut.T.CID), e.PeriodicRefresh)
 	}
 	return dutil.ToErrors(errs)
 }
 
-func (e *ESI) refreshToken(c context.Context, userID string, refresh string) error {
-	// Do the refresh
-	jwt, err := e.OAC.GetRefresh(refresh)
+// refreshTokenWithin refreshes the character's tokens if its access token
+// expires within the duration. The tokens are read again once any other
+// refresh of them is done, as that refresh spent the refresh token.
+func (e *ESI) refreshTokenWithin(c context.Context, userID string, charID int32, within time.Duration) (*esi.Tokens, error) {
+	defer e.TokenLocks.lock(charID)()
+	t, err := e.DB.GetEveToken(c, charID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	// Verify the authenticity of the new authorization
-	ek, err := e.GetEvePublicKeys(c)
-	if err != nil {
-		return err
+	if t.CID == 0 {
+		return nil, errors.Errorf("no esi tokens for character id: %d", charID)
 	}
-	jwtk := ek.JWTKey()
-	if jwtk == nil {
-		return errors.New("could not find EVE public jwt key")
+	if !needsRefresh(t, within) {
+		return t, nil
 	}
-	claims, err := jwtk.ValidateToken([]byte(jwt.AccessToken))
+	return e.refreshToken(c, userID, t.Refresh)
+}
+
+func (e *ESI) refreshToken(c context.Context, userID string, refresh string) (*esi.Tokens, error) {
+	// Do the refresh
+	jwt, err := e.OAC.GetRefresh(refresh)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	err = esi.ValidateEveClaims(claims)
+
+	// Verify the authenticity of the new authorization
+	claims, err := e.ValidateAccessToken(c, jwt.AccessToken)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Construct our internal representation of a validated token, and
 	// store it.
 	tokens, err := esi.NewTokens(jwt, claims)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	err = e.SetEveTokens(c, userID, tokens)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return tokens, nil
 }
 
 func (e *ESI) SearchCorporations(c context.Context, query string, lang language.Tag) ([]*esi.Corporation, error) {
@@ -130,12 +245,12 @@ type Character struct {
 	TokenNeedsRescope bool
 }
 
-func (e *ESI) GetCharactersForUser(c context.Context, userID string) ([]Character, error) {
+func (e *ESI) GetCharactersForUser(c context.Context, userID string, lang language.Tag) ([]Character, error) {
 	ids, rescope, err := e.DB.GetEveCharactersForUser(c, userID)
 	if err != nil {
 		return nil, err
 	}
-	chars, err := e.ESIClient.Characters(c, ids)
+	chars, err := e.ESIClient.Characters(c, ids, lang)
 	if err != nil {
 		return nil, err
 	}
@@ -158,6 +273,31 @@ func (e *ESI) HasCharacterForUser(c context.Context, userID string, charID int32
 	return e.DB.HasCharacterForUser(c, userID, charID)
 }
 
+// UnlinkCharacter revokes the character's refresh token at the single sign on
+// and forgets its tokens. The tokens are forgotten even if revoking fails, as
+// the character can still revoke access from their EVE account.
+//
+// The authoritative character cannot be unlinked, as the corporation would
+// silently stop being synced.
+func (e *ESI) UnlinkCharacter(c context.Context, userID string, charID int32) error {
+	t, err := e.DB.GetEveTokenForUser(c, userID, charID)
+	if err != nil {
+		return err
+	} else if t.CID == 0 {
+		return NotUsersCharacterError
+	}
+	authID, err := e.DB.GetAuthoritativeCharacter(c)
+	if err != nil {
+		return err
+	} else if authID == charID {
+		return AuthoritativeCharacterError
+	}
+	if err := e.OAC.Revoke(t.Refresh); err != nil {
+		e.L.Error().Err(err).Int32("characterID", charID).Msg("could not revoke refresh token of unlinked character")
+	}
+	return e.DB.DeleteEveToken(c, userID, charID)
+}
+
 func (e *ESI) DoesCharacterNeedRescope(c context.Context, charID int32) (bool, error) {
 	return e.DB.DoesCharacterNeedRescope(c, charID)
 }
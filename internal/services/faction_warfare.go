@@ -0,0 +1,123 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/async"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/rs/zerolog"
+	"golang.org/x/text/language"
+)
+
+type FactionWarfare struct {
+	DB               *db.DB
+	ESI              *ESI
+	L                *zerolog.Logger
+	PeriodicSnapshot time.Duration
+	NDays            int
+}
+
+func (f *FactionWarfare) GoPeriodicallySnapshotStats(m *async.Messenger) {
+	m.NowAndPeriodically(f.PeriodicSnapshot, f.snapshotStats, f.L)
+}
+
+// snapshotStats records the managed corporation's faction warfare statistics
+// as today's snapshot, using the authoritative character's token.
+func (f *FactionWarfare) snapshotStats(c context.Context) error {
+	corpID, err := f.DB.GetCorporationManaged(c)
+	if err != nil {
+		return err
+	}
+	charID, err := f.DB.GetAuthoritativeCharacter(c)
+	if err != nil {
+		return err
+	}
+	if corpID == 0 || charID == 0 {
+		// Not yet managing a corporation
+		return nil
+	}
+	s, err := f.ESI.AuthClient().CorporationFactionWarfareStats(c, charID, corpID)
+	if err != nil {
+		return err
+	}
+	return f.DB.SetFactionWarfareStats(c, today(), s)
+}
+
+// FactionWarfareTrend compares the latest snapshot to the one a week before
+// it. Previous is nil if there is no snapshot a week older.
+type FactionWarfareTrend struct {
+	Current  *db.FactionWarfareSnapshot
+	Previous *db.FactionWarfareSnapshot
+	// The change in the weekly kills, victory points, and enrolled pilots.
+	KillsChange  int32
+	VPChange     int32
+	PilotsChange int32
+}
+
+type FactionWarfareStats struct {
+	Faction   *esi.Faction
+	Trend     FactionWarfareTrend
+	Snapshots []db.FactionWarfareSnapshot
+}
+
+// GetStats obtains the recent daily snapshots, most recent first, and the
+// week-over-week trend. The faction is localized into the language.
+func (f *FactionWarfare) GetStats(c context.Context, lang language.Tag) (*FactionWarfareStats, error) {
+	ss, err := f.DB.GetFactionWarfareStatsSince(c, today().AddDate(0, 0, -f.NDays))
+	if err != nil {
+		return nil, err
+	}
+	st := &FactionWarfareStats{
+		Snapshots: make([]db.FactionWarfareSnapshot, len(ss)),
+	}
+	for i := range ss {
+		st.Snapshots[len(ss)-1-i] = ss[i]
+	}
+	if len(ss) == 0 {
+		return st, nil
+	}
+	cur := &ss[len(ss)-1]
+	st.Trend.Current = cur
+	weekAgo := cur.Date.AddDate(0, 0, -7)
+	for i := len(ss) - 1; i >= 0; i-- {
+		if !ss[i].Date.After(weekAgo) {
+			prev := &ss[i]
+			st.Trend.Previous = prev
+			st.Trend.KillsChange = cur.KillsLastWeek - prev.KillsLastWeek
+			st.Trend.VPChange = cur.VPLastWeek - prev.VPLastWeek
+			st.Trend.PilotsChange = cur.Pilots - prev.Pilots
+			break
+		}
+	}
+	if cur.FactionID != 0 {
+		st.Faction, err = f.ESI.ESIClient.Faction(c, cur.FactionID, lang)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return st, nil
+}
+
+// today is the start of the current day in UTC, which is Eve time.
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
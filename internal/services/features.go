@@ -19,6 +19,7 @@ package services
 import (
 	"context"
 
+	"github.com/cjslep/dharma/esi"
 	"github.com/cjslep/dharma/internal/db"
 	"github.com/cjslep/dharma/internal/features"
 	"golang.org/x/text/language"
@@ -31,7 +32,7 @@ type Features struct {
 
 // TODO: Call this after selecting different features, before calling
 // ChangeEnabled.
-func (f *Features) DiffChangeEnabled(ctx context.Context, enableIDs, disableIDs []string) (added, removed []features.Scope, err error) {
+func (f *Features) DiffChangeEnabled(ctx context.Context, enableIDs, disableIDs []string) (added, removed []esi.Scope, err error) {
 	// 0. Validate feature IDs
 	if err = f.E.ValidateFeatureIDs(enableIDs); err != nil {
 		return
@@ -0,0 +1,152 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/async"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/rs/zerolog"
+)
+
+var (
+	// taxRefTypes are the kinds of corporation journal entries that are tax
+	// paid by a member.
+	taxRefTypes = []string{
+		"bounty_prizes",
+		"ess_escrow_transfer",
+		"agent_mission_reward",
+		"agent_mission_time_bonus_reward",
+	}
+)
+
+const (
+	// incomeWindow is how far back the income by kind is summed.
+	incomeWindow = 30 * 24 * time.Hour
+)
+
+type Finance struct {
+	DB           *db.DB
+	ESI          *ESI
+	Names        *Names
+	L            *zerolog.Logger
+	PeriodicSync time.Duration
+	NTaxMonths   int
+}
+
+func (f *Finance) GoPeriodicallySyncWallets(m *async.Messenger) {
+	m.NowAndPeriodically(f.PeriodicSync, f.syncWallets, f.L)
+}
+
+// syncWallets fetches the journal entries and transactions of every wallet
+// division that are newer than the ones already stored, using the
+// authoritative character's token.
+func (f *Finance) syncWallets(c context.Context) error {
+	corpID, charID, err := f.corpAndCharacter(c)
+	if err != nil || corpID == 0 || charID == 0 {
+		return err
+	}
+	ac := f.ESI.AuthClient()
+	for div := int32(1); div <= esi.NWalletDivisions; div++ {
+		jID, tID, err := f.DB.GetLatestWalletIDs(c, div)
+		if err != nil {
+			return err
+		}
+		js, err := ac.CorporationJournal(c, charID, corpID, div, jID)
+		if err != nil {
+			return err
+		}
+		ts, err := ac.CorporationTransactions(c, charID, corpID, div, tID)
+		if err != nil {
+			return err
+		}
+		if err := f.DB.InsertWalletHistory(c, div, js, ts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Finance) corpAndCharacter(c context.Context) (corpID, charID int32, err error) {
+	corpID, err = f.DB.GetCorporationManaged(c)
+	if err != nil {
+		return
+	}
+	charID, err = f.DB.GetAuthoritativeCharacter(c)
+	return
+}
+
+type MonthlyTax struct {
+	Month   time.Time
+	Members []db.MemberTax
+	Total   float64
+}
+
+type FinanceDashboard struct {
+	Balances []esi.WalletBalance
+	Income   []db.RefTypeIncome
+	Tax      []MonthlyTax
+	Names    map[int32]string
+}
+
+// GetDashboard obtains the current wallet balances, the income by kind over
+// the last 30 days, and the tax paid by each member in recent months.
+func (f *Finance) GetDashboard(c context.Context) (*FinanceDashboard, error) {
+	corpID, charID, err := f.corpAndCharacter(c)
+	if err != nil {
+		return nil, err
+	}
+	d := &FinanceDashboard{}
+	if corpID != 0 && charID != 0 {
+		d.Balances, err = f.ESI.AuthClient().CorporationWallets(c, charID, corpID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now().UTC()
+	d.Income, err = f.DB.GetWalletIncomeByRefType(c, now.Add(-incomeWindow))
+	if err != nil {
+		return nil, err
+	}
+	since := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1-f.NTaxMonths, 0)
+	ts, err := f.DB.GetWalletTaxByMember(c, since, taxRefTypes)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, 0, len(ts))
+	for _, t := range ts {
+		ids = append(ids, t.CharacterID)
+		if n := len(d.Tax); n == 0 || !d.Tax[n-1].Month.Equal(t.Month) {
+			d.Tax = append(d.Tax, MonthlyTax{Month: t.Month})
+		}
+		m := &d.Tax[len(d.Tax)-1]
+		m.Members = append(m.Members, t)
+		m.Total += t.Amount
+	}
+	es, err := f.Names.ResolveNames(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	d.Names = make(map[int32]string, len(es))
+	for id, e := range es {
+		d.Names[id] = e.Name
+	}
+	return d, nil
+}
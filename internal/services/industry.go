@@ -0,0 +1,299 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/async"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/cjslep/dharma/internal/util"
+	"github.com/cjslep/dharma/locales"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/rs/zerolog"
+	"golang.org/x/text/language"
+)
+
+// readyNotificationWindow is how long after a job ends its installer may still
+// be told it is ready, so enabling notifications does not mail about jobs that
+// ended long ago.
+const readyNotificationWindow = 24 * time.Hour
+
+// Industry keeps track of the corporation's industry jobs, and optionally
+// mails installers in game when their jobs are ready to be delivered.
+type Industry struct {
+	DB            *db.DB
+	ESI           *ESI
+	SDE           *SDE
+	Names         *Names
+	B             *i18n.Bundle
+	L             *zerolog.Logger
+	PeriodicSync  time.Duration
+	ShowDelivered time.Duration
+	NotifyReady   bool
+	// MaxMails is the most installers mailed each sync, as ESI refuses mail
+	// sent too quickly. The rest are mailed by later syncs.
+	MaxMails int
+}
+
+func (i *Industry) GoPeriodicallySyncIndustryJobs(m *async.Messenger) {
+	m.NowAndPeriodically(i.PeriodicSync, i.syncIndustryJobs, i.L)
+}
+
+// syncIndustryJobs fetches the corporation's industry jobs using the
+// authoritative character's token, then notifies installers of ready jobs.
+func (i *Industry) syncIndustryJobs(c context.Context) error {
+	corpID, err := i.DB.GetCorporationManaged(c)
+	if err != nil || corpID == 0 {
+		return err
+	}
+	charID, err := i.DB.GetAuthoritativeCharacter(c)
+	if err != nil || charID == 0 {
+		return err
+	}
+	js, err := i.ESI.AuthClient().CorporationIndustryJobs(c, charID, corpID)
+	if err != nil {
+		return err
+	}
+	if err := i.DB.UpsertIndustryJobs(c, js); err != nil {
+		return err
+	}
+	if !i.NotifyReady {
+		return nil
+	}
+	return i.notifyReady(c, charID)
+}
+
+// notifyReady sends each installer of newly ready jobs one mail from the
+// authoritative character listing them, up to MaxMails installers. The mail is
+// in English, as the installers' languages are not known.
+func (i *Industry) notifyReady(c context.Context, charID int32) error {
+	now := time.Now()
+	js, err := i.DB.GetUnnotifiedReadyIndustryJobs(c, now, now.Add(-readyNotificationWindow))
+	if err != nil || len(js) == 0 {
+		return err
+	}
+	lang := language.English
+	views, err := i.views(c, js, lang)
+	if err != nil {
+		return err
+	}
+	byInstaller := make(map[int32][]IndustryJob)
+	var installers []int32
+	for _, j := range views {
+		if _, ok := byInstaller[j.InstallerID]; !ok {
+			installers = append(installers, j.InstallerID)
+		}
+		byInstaller[j.InstallerID] = append(byInstaller[j.InstallerID], j)
+	}
+	msg := locales.New(i.B, lang.String())
+	subject, err := msg.IndustryJobsReadyMailSubject()
+	if err != nil {
+		return err
+	}
+	intro, err := msg.IndustryJobsReadyMailBody()
+	if err != nil {
+		return err
+	}
+	if len(installers) > i.MaxMails {
+		installers = installers[:i.MaxMails]
+	}
+	var errs []error
+	ac := i.ESI.AuthClient()
+next:
+	for _, installer := range installers {
+		lines := []string{html.EscapeString(intro), ""}
+		ids := make([]int32, 0, len(byInstaller[installer]))
+		for _, j := range byInstaller[installer] {
+			activity, err := msg.IndustryActivity(j.ActivityID)
+			if err != nil {
+				errs = append(errs, err)
+				continue next
+			}
+			lines = append(lines, html.EscapeString(fmt.Sprintf("%s: %s x%d (%s)", activity, j.typeName(), j.Runs, j.facilityName())))
+			ids = append(ids, j.ID)
+		}
+		to := []esi.MailRecipient{{ID: installer, Type: esi.MailRecipientCharacter}}
+		// ESI expects the same markup the client uses.
+		if _, err := ac.SendMail(c, charID, to, subject, strings.Join(lines, "<br>")); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := i.DB.MarkIndustryJobsNotified(c, ids); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return util.ToErrors(errs)
+}
+
+// The states of a job shown to members, where ready jobs are those ESI still
+// reports as active after they have ended.
+const (
+	IndustryStateActive    = esi.IndustryJobActive
+	IndustryStatePaused    = esi.IndustryJobPaused
+	IndustryStateReady     = esi.IndustryJobReady
+	IndustryStateDelivered = esi.IndustryJobDelivered
+)
+
+type IndustryJob struct {
+	esi.IndustryJob
+	State             string
+	BlueprintTypeName string
+	// ProductTypeName is empty for research and copying, which do not
+	// produce a different type.
+	ProductTypeName string
+	InstallerName   string
+	// FacilityName is empty for player-owned structures.
+	FacilityName string
+}
+
+// typeName is the product being made, or else the blueprint being worked on.
+func (j IndustryJob) typeName() string {
+	if len(j.ProductTypeName) > 0 {
+		return j.ProductTypeName
+	} else if len(j.BlueprintTypeName) > 0 {
+		return j.BlueprintTypeName
+	}
+	return fmt.Sprintf("%d", j.BlueprintTypeID)
+}
+
+// facilityName is the name of the station, or else the structure's ID.
+func (j IndustryJob) facilityName() string {
+	if len(j.FacilityName) > 0 {
+		return j.FacilityName
+	}
+	return fmt.Sprintf("%d", j.FacilityID)
+}
+
+// IndustryGroup is the jobs one installer has at one facility.
+type IndustryGroup struct {
+	InstallerID   int32
+	InstallerName string
+	FacilityID    int64
+	FacilityName  string
+	Jobs          []IndustryJob
+}
+
+type IndustryJobs struct {
+	Groups     []IndustryGroup
+	NActive    int
+	NReady     int
+	NDelivered int
+}
+
+// GetJobs obtains the jobs not yet delivered along with those delivered
+// recently, grouped by installer and facility.
+func (i *Industry) GetJobs(c context.Context, lang language.Tag) (*IndustryJobs, error) {
+	js, err := i.DB.GetIndustryJobs(c, time.Now().Add(-i.ShowDelivered))
+	if err != nil {
+		return nil, err
+	}
+	views, err := i.views(c, js, lang)
+	if err != nil {
+		return nil, err
+	}
+	type key struct {
+		installer int32
+		facility  int64
+	}
+	ij := &IndustryJobs{}
+	groups := make(map[key]int)
+	for _, j := range views {
+		switch j.State {
+		case IndustryStateReady:
+			ij.NReady++
+		case IndustryStateDelivered:
+			ij.NDelivered++
+		default:
+			ij.NActive++
+		}
+		k := key{j.InstallerID, j.FacilityID}
+		g, ok := groups[k]
+		if !ok {
+			g = len(ij.Groups)
+			groups[k] = g
+			ij.Groups = append(ij.Groups, IndustryGroup{
+				InstallerID:   j.InstallerID,
+				InstallerName: j.InstallerName,
+				FacilityID:    j.FacilityID,
+				FacilityName:  j.FacilityName,
+			})
+		}
+		ij.Groups[g].Jobs = append(ij.Groups[g].Jobs, j)
+	}
+	sort.SliceStable(ij.Groups, func(a, b int) bool {
+		ga, gb := ij.Groups[a], ij.Groups[b]
+		if ga.InstallerName != gb.InstallerName {
+			return ga.InstallerName < gb.InstallerName
+		}
+		return ga.FacilityID < gb.FacilityID
+	})
+	return ij, nil
+}
+
+// views names the types, installers, and facilities of the jobs.
+func (i *Industry) views(c context.Context, js []esi.IndustryJob, lang language.Tag) ([]IndustryJob, error) {
+	var typeIDs, entityIDs []int32
+	for _, j := range js {
+		typeIDs = append(typeIDs, j.BlueprintTypeID)
+		if j.ProductTypeID != 0 && j.ProductTypeID != j.BlueprintTypeID {
+			typeIDs = append(typeIDs, j.ProductTypeID)
+		}
+		entityIDs = append(entityIDs, j.InstallerID)
+		// Stations have names ESI resolves, while structure IDs are too
+		// large to.
+		if j.FacilityID <= math.MaxInt32 {
+			entityIDs = append(entityIDs, int32(j.FacilityID))
+		}
+	}
+	ts, err := i.SDE.Types(c, uniqueIDs(typeIDs))
+	if err != nil {
+		return nil, err
+	}
+	es, err := i.Names.ResolveNames(c, uniqueIDs(entityIDs))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	views := make([]IndustryJob, len(js))
+	for n, j := range js {
+		v := IndustryJob{
+			IndustryJob:       j,
+			State:             j.Status,
+			BlueprintTypeName: ts[j.BlueprintTypeID].Name.Get(lang),
+			InstallerName:     es[j.InstallerID].Name,
+		}
+		if j.IsReady(now) {
+			v.State = IndustryStateReady
+		}
+		if j.ProductTypeID != 0 && j.ProductTypeID != j.BlueprintTypeID {
+			v.ProductTypeName = ts[j.ProductTypeID].Name.Get(lang)
+		}
+		if j.FacilityID <= math.MaxInt32 {
+			v.FacilityName = es[int32(j.FacilityID)].Name
+		}
+		views[n] = v
+	}
+	return views, nil
+}
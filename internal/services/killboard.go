@@ -0,0 +1,215 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/async"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// killmailFetchBatch bounds how many killmails are fetched before being
+	// stored, so that progress is kept when a later fetch fails.
+	killmailFetchBatch = 100
+)
+
+type Killboard struct {
+	DB           *db.DB
+	ESI          *ESI
+	Names        *Names
+	L            *zerolog.Logger
+	PeriodicPoll time.Duration
+	NPerPage     int
+}
+
+func (k *Killboard) GoPeriodicallyPollKillmails(m *async.Messenger) {
+	m.NowAndPeriodically(k.PeriodicPoll, k.pollKillmails, k.L)
+}
+
+// pollKillmails fetches the managed corporation's recent killmails with the
+// authoritative character's token, and stores the ones not yet seen.
+func (k *Killboard) pollKillmails(c context.Context) error {
+	corpID, err := k.DB.GetCorporationManaged(c)
+	if err != nil {
+		return err
+	}
+	charID, err := k.DB.GetAuthoritativeCharacter(c)
+	if err != nil {
+		return err
+	}
+	if corpID == 0 || charID == 0 {
+		// Not yet managing a corporation
+		return nil
+	}
+	refs, err := k.ESI.AuthClient().RecentCorporationKillmails(c, charID, corpID)
+	if err != nil {
+		return err
+	}
+	ids := make([]int32, len(refs))
+	for i, r := range refs {
+		ids[i] = r.ID
+	}
+	known, err := k.DB.GetKnownKillmailIDs(c, ids)
+	if err != nil {
+		return err
+	}
+	isKnown := make(map[int32]bool, len(known))
+	for _, id := range known {
+		isKnown[id] = true
+	}
+	unknown := make([]esi.KillmailRef, 0, len(refs)-len(known))
+	for _, r := range refs {
+		if !isKnown[r.ID] {
+			isKnown[r.ID] = true
+			unknown = append(unknown, r)
+		}
+	}
+	for start := 0; start < len(unknown); start += killmailFetchBatch {
+		end := start + killmailFetchBatch
+		if end > len(unknown) {
+			end = len(unknown)
+		}
+		kms, err := k.ESI.ESIClient.Killmails(c, unknown[start:end])
+		if err != nil {
+			return err
+		}
+		if err := k.DB.InsertKillmails(c, kms); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type KillmailListing struct {
+	db.KillmailSummary
+	// Loss is true when the victim is in the managed corporation.
+	Loss bool
+}
+
+// Killmails is a list of killmails along with the names of everything they
+// refer to.
+type Killmails struct {
+	Listings []KillmailListing
+	Names    map[int32]string
+	NKills   int
+	NLosses  int
+}
+
+// GetMonth obtains the killmails in the month, most recent first.
+func (k *Killboard) GetMonth(c context.Context, year int, month time.Month) (*Killmails, error) {
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	ks, err := k.DB.GetKillmailSummariesBetween(c, start, start.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, err
+	}
+	return k.toKillmails(c, ks)
+}
+
+// GetCharacter obtains a page of the killmails involving the character, most
+// recent first.
+func (k *Killboard) GetCharacter(c context.Context, charID int32, page int) (*Killmails, error) {
+	ks, err := k.DB.GetKillmailSummariesForCharacter(c, charID, k.NPerPage, page)
+	if err != nil {
+		return nil, err
+	}
+	return k.toKillmails(c, ks)
+}
+
+func (k *Killboard) toKillmails(c context.Context, ks []db.KillmailSummary) (*Killmails, error) {
+	corpID, err := k.DB.GetCorporationManaged(c)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, 0, 7*len(ks))
+	km := &Killmails{
+		Listings: make([]KillmailListing, len(ks)),
+	}
+	for i, s := range ks {
+		km.Listings[i] = KillmailListing{
+			KillmailSummary: s,
+			Loss:            s.VictimCorporationID == corpID,
+		}
+		if km.Listings[i].Loss {
+			km.NLosses++
+		} else {
+			km.NKills++
+		}
+		ids = append(ids, s.SolarSystemID, s.VictimCharacterID, s.VictimCorporationID, s.VictimAllianceID, s.VictimShipTypeID, s.FinalBlowCharacterID, s.FinalBlowCorporationID)
+	}
+	km.Names, err = k.resolveNames(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	return km, nil
+}
+
+// KillmailDetail is a killmail along with the names of everything it refers
+// to.
+type KillmailDetail struct {
+	*esi.Killmail
+	Names map[int32]string
+	Loss  bool
+}
+
+// GetKillmail obtains a stored killmail in full, or nil if it is not stored.
+func (k *Killboard) GetKillmail(c context.Context, id int32) (*KillmailDetail, error) {
+	km, err := k.DB.GetKillmail(c, id)
+	if err != nil || km == nil {
+		return nil, err
+	}
+	corpID, err := k.DB.GetCorporationManaged(c)
+	if err != nil {
+		return nil, err
+	}
+	v := km.Victim
+	ids := []int32{km.SolarSystemID, v.CharacterID, v.CorporationID, v.AllianceID, v.FactionID, v.ShipTypeID}
+	for _, a := range km.Attackers {
+		ids = append(ids, a.CharacterID, a.CorporationID, a.AllianceID, a.FactionID, a.ShipTypeID, a.WeaponTypeID)
+	}
+	for _, i := range v.Items {
+		ids = append(ids, i.TypeID)
+		for _, n := range i.Items {
+			ids = append(ids, n.TypeID)
+		}
+	}
+	names, err := k.resolveNames(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	return &KillmailDetail{
+		Killmail: km,
+		Names:    names,
+		Loss:     v.CorporationID == corpID,
+	}, nil
+}
+
+func (k *Killboard) resolveNames(c context.Context, ids []int32) (map[int32]string, error) {
+	es, err := k.Names.ResolveNames(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int32]string, len(es))
+	for id, e := range es {
+		names[id] = e.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,292 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"golang.org/x/text/language"
+)
+
+var (
+	KOSEntityNotFoundError = errors.New("no character, corporation, or alliance has that name")
+)
+
+const (
+	// nKOSResults is the most entries shown when searching the kill on
+	// sight list.
+	nKOSResults = 500
+	// maxKOSCheckNames is the most names checked against the kill on sight
+	// list at once.
+	maxKOSCheckNames = 1000
+)
+
+// KOS keeps the kill on sight list of characters, corporations, and alliances,
+// which members may search or check names against.
+type KOS struct {
+	DB    *db.DB
+	ESI   *ESI
+	Names *Names
+	L     *zerolog.Logger
+}
+
+type KOSForm struct {
+	Name string
+	// Category is one of esi.CharacterCategory, esi.CorporationCategory,
+	// or esi.AllianceCategory.
+	Category string
+	Reason   string
+	Evidence []string
+	// Expires is nil if the entry never expires.
+	Expires *time.Time
+}
+
+// IsKOSCategory determines whether entities of the esi category may be put on
+// the kill on sight list.
+func IsKOSCategory(category string) bool {
+	return category == esi.CharacterCategory ||
+		category == esi.CorporationCategory ||
+		category == esi.AllianceCategory
+}
+
+// Add resolves the named entity and puts it on the kill on sight list,
+// replacing any entry it already has.
+func (k *KOS) Add(c context.Context, addedBy int32, f KOSForm, lang language.Tag) error {
+	name := strings.TrimSpace(f.Name)
+	es, err := k.Names.ResolveIDs(c, []string{name}, lang)
+	if err != nil {
+		return err
+	}
+	var found *esi.Entity
+	for i, e := range es[strings.ToLower(name)] {
+		if e.Category == f.Category {
+			found = &es[strings.ToLower(name)][i]
+		}
+	}
+	if found == nil {
+		return KOSEntityNotFoundError
+	}
+	evidence := make([]string, 0, len(f.Evidence))
+	for _, e := range f.Evidence {
+		if e = strings.TrimSpace(e); len(e) > 0 {
+			evidence = append(evidence, e)
+		}
+	}
+	return k.DB.UpsertKOSEntry(c, db.KOSEntry{
+		EntityID: found.ID,
+		Category: found.Category,
+		Name:     found.Name,
+		Reason:   strings.TrimSpace(f.Reason),
+		Evidence: strings.Join(evidence, "\n"),
+		AddedBy:  addedBy,
+		Expires:  f.Expires,
+	})
+}
+
+func (k *KOS) Remove(c context.Context, entityID int32) error {
+	return k.DB.DeleteKOSEntry(c, entityID)
+}
+
+// GetEditors obtains the IDs of the users privileged to edit the list. Admins
+// may edit it regardless.
+func (k *KOS) GetEditors(c context.Context) (map[string]bool, error) {
+	return k.DB.GetKOSEditors(c)
+}
+
+// CanBeEditor determines whether the user may be privileged to edit the list,
+// which requires them to have linked a character.
+func (k *KOS) CanBeEditor(c context.Context, userID string) (bool, error) {
+	ids, _, err := k.DB.GetEveCharactersForUser(c, userID)
+	return len(ids) > 0, err
+}
+
+type KOSEntry struct {
+	db.KOSEntry
+	Links       []string
+	AddedByName string
+}
+
+// Search obtains the unexpired entries whose name or reason contains the
+// query, and whether there were too many to show.
+func (k *KOS) Search(c context.Context, q string) ([]KOSEntry, bool, error) {
+	ks, err := k.DB.SearchKOSEntries(c, strings.TrimSpace(q), nKOSResults+1)
+	if err != nil {
+		return nil, false, err
+	}
+	truncated := len(ks) > nKOSResults
+	if truncated {
+		ks = ks[:nKOSResults]
+	}
+	es, err := k.entries(c, ks)
+	return es, truncated, err
+}
+
+// entries adds the names of who added the entries, and splits their evidence
+// into links.
+func (k *KOS) entries(c context.Context, ks []db.KOSEntry) ([]KOSEntry, error) {
+	ids := make([]int32, len(ks))
+	for i, e := range ks {
+		ids[i] = e.AddedBy
+	}
+	names, err := k.Names.ResolveNames(c, uniqueIDs(ids))
+	if err != nil {
+		return nil, err
+	}
+	es := make([]KOSEntry, len(ks))
+	for i, e := range ks {
+		es[i] = KOSEntry{
+			KOSEntry:    e,
+			AddedByName: names[e.AddedBy].Name,
+		}
+		if len(e.Evidence) > 0 {
+			es[i].Links = strings.Split(e.Evidence, "\n")
+		}
+	}
+	return es, nil
+}
+
+// KOSMatch is a checked name that is on the kill on sight list, itself or by
+// its corporation or alliance.
+type KOSMatch struct {
+	Name    string
+	Entries []KOSEntry
+}
+
+type KOSCheck struct {
+	Matches []KOSMatch
+	// NClear is how many names are not on the list.
+	NClear int
+	// Unknown are the names that are not characters, corporations, or
+	// alliances.
+	Unknown []string
+	// Truncated is whether there were more names than were checked.
+	Truncated bool
+}
+
+// Check matches names, one per line like those copied from a chat channel's
+// member list, against the kill on sight list. Characters also match by their
+// current corporation and alliance.
+func (k *KOS) Check(c context.Context, text string, lang language.Tag) (*KOSCheck, error) {
+	names, truncated := pastedNames(text, maxKOSCheckNames)
+	check := &KOSCheck{Truncated: truncated}
+	if len(names) == 0 {
+		return check, nil
+	}
+	es, err := k.Names.ResolveIDs(c, names, lang)
+	if err != nil {
+		return nil, err
+	}
+	// Prefer characters, as a pasted member list names characters.
+	byName := make(map[string]esi.Entity, len(names))
+	var charIDs []int32
+	for _, n := range names {
+		for _, e := range es[strings.ToLower(n)] {
+			if !IsKOSCategory(e.Category) {
+				continue
+			}
+			if cur, ok := byName[n]; !ok || (e.Category == esi.CharacterCategory && cur.Category != esi.CharacterCategory) {
+				byName[n] = e
+			}
+		}
+		if e, ok := byName[n]; !ok {
+			check.Unknown = append(check.Unknown, n)
+		} else if e.Category == esi.CharacterCategory {
+			charIDs = append(charIDs, e.ID)
+		}
+	}
+	as, err := k.ESI.ESIClient.Affiliations(c, uniqueIDs(charIDs))
+	if err != nil {
+		return nil, err
+	}
+	affiliations := make(map[int32]esi.Affiliation, len(as))
+	ids := make([]int32, 0, len(byName)+2*len(as))
+	for _, e := range byName {
+		ids = append(ids, e.ID)
+	}
+	for _, a := range as {
+		affiliations[a.CharacterID] = a
+		ids = append(ids, a.CorporationID, a.AllianceID)
+	}
+	ks, err := k.DB.GetKOSEntries(c, uniqueIDs(ids))
+	if err != nil {
+		return nil, err
+	}
+	var matched []db.KOSEntry
+	var matchedNames []string
+	var nMatched []int
+	for _, n := range names {
+		e, ok := byName[n]
+		if !ok {
+			continue
+		}
+		a := affiliations[e.ID]
+		var m []db.KOSEntry
+		for _, id := range []int32{e.ID, a.CorporationID, a.AllianceID} {
+			if ke, ok := ks[id]; ok && id != 0 {
+				m = append(m, ke)
+			}
+		}
+		if len(m) == 0 {
+			check.NClear++
+			continue
+		}
+		matched = append(matched, m...)
+		matchedNames = append(matchedNames, e.Name)
+		nMatched = append(nMatched, len(m))
+	}
+	entries, err := k.entries(c, matched)
+	if err != nil {
+		return nil, err
+	}
+	for i, n := range matchedNames {
+		check.Matches = append(check.Matches, KOSMatch{
+			Name:    n,
+			Entries: entries[:nMatched[i]],
+		})
+		entries = entries[nMatched[i]:]
+	}
+	sort.SliceStable(check.Matches, func(i, j int) bool {
+		return strings.ToLower(check.Matches[i].Name) < strings.ToLower(check.Matches[j].Name)
+	})
+	return check, nil
+}
+
+// pastedNames obtains the distinct names, one per line, of up to max names and
+// whether there were more.
+func pastedNames(text string, max int) ([]string, bool) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, n := range strings.Split(text, "\n") {
+		n = strings.TrimSpace(n)
+		if len(n) == 0 || seen[strings.ToLower(n)] {
+			continue
+		}
+		if len(names) == max {
+			return names, true
+		}
+		seen[strings.ToLower(n)] = true
+		names = append(names, n)
+	}
+	return names, false
+}
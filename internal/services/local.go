@@ -0,0 +1,287 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/rs/zerolog"
+	"golang.org/x/text/language"
+)
+
+// maxLocalScanNames is the most names scanned from local chat at once.
+const maxLocalScanNames = 1000
+
+// Local scans names pasted from local chat, grouping the pilots by their
+// alliance and corporation and flagging those on the kill on sight list or
+// with standings. Scans are kept so they can be shared until they expire.
+type Local struct {
+	DB     *db.DB
+	ESI    *ESI
+	Names  *Names
+	L      *zerolog.Logger
+	Expiry time.Duration
+}
+
+// Flags are what is known of a pilot, corporation, or alliance.
+type Flags struct {
+	KOS         []db.KOSEntry
+	Standing    float64
+	HasStanding bool
+	// Member is true for the managed corporation and its alliance, which
+	// are friendly without any standings.
+	Member bool
+}
+
+func (f Flags) IsKOS() bool {
+	return len(f.KOS) > 0
+}
+
+func (f Flags) IsHostile() bool {
+	return f.IsKOS() || (!f.Member && f.HasStanding && f.Standing < 0)
+}
+
+func (f Flags) IsFriendly() bool {
+	return !f.IsKOS() && (f.Member || (f.HasStanding && f.Standing > 0))
+}
+
+type LocalPilot struct {
+	CharacterID int32
+	Name        string
+	// Flags has the kill on sight entries of the pilot, its corporation,
+	// and its alliance, and the most specific of their standings.
+	Flags
+}
+
+type LocalCorporation struct {
+	CorporationID int32
+	Name          string
+	Flags
+	Pilots []LocalPilot
+}
+
+type LocalAlliance struct {
+	// AllianceID is zero for the corporations not in an alliance.
+	AllianceID int32
+	Name       string
+	Flags
+	Corporations []LocalCorporation
+	NPilots      int
+}
+
+type LocalScanResult struct {
+	Alliances []LocalAlliance
+	// Unknown are the names that are not characters.
+	Unknown   []string
+	Truncated bool
+	NPilots   int
+	NHostile  int
+	NFriendly int
+}
+
+type LocalScan struct {
+	ID            string
+	Created       time.Time
+	Expires       time.Time
+	CreatedByName string
+	LocalScanResult
+}
+
+// Scan resolves the names, one per line as copied from local chat, and stores
+// the result as made by the character. It returns the new scan's ID.
+func (l *Local) Scan(c context.Context, charID int32, text string, lang language.Tag) (string, error) {
+	names, truncated := pastedNames(text, maxLocalScanNames)
+	res := LocalScanResult{Truncated: truncated}
+	es, err := l.Names.ResolveIDs(c, names, lang)
+	if err != nil {
+		return "", err
+	}
+	chars := make(map[int32]string, len(names))
+	var charIDs []int32
+	for _, n := range names {
+		found := false
+		for _, e := range es[strings.ToLower(n)] {
+			if e.Category == esi.CharacterCategory {
+				chars[e.ID] = e.Name
+				charIDs = append(charIDs, e.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			res.Unknown = append(res.Unknown, n)
+		}
+	}
+	charIDs = uniqueIDs(charIDs)
+	as, err := l.ESI.ESIClient.Affiliations(c, charIDs)
+	if err != nil {
+		return "", err
+	}
+	ids := make([]int32, 0, 3*len(as))
+	var groupIDs []int32
+	for _, a := range as {
+		ids = append(ids, a.CharacterID, a.CorporationID, a.AllianceID)
+		groupIDs = append(groupIDs, a.CorporationID, a.AllianceID)
+	}
+	ids = uniqueIDs(ids)
+	groupNames, err := l.Names.ResolveNames(c, uniqueIDs(groupIDs))
+	if err != nil {
+		return "", err
+	}
+	kos, err := l.DB.GetKOSEntries(c, ids)
+	if err != nil {
+		return "", err
+	}
+	standings, err := l.DB.GetStandings(c, ids)
+	if err != nil {
+		return "", err
+	}
+	corpID, err := l.DB.GetCorporationManaged(c)
+	if err != nil {
+		return "", err
+	}
+	allianceID, err := l.DB.GetAlliance(c)
+	if err != nil {
+		return "", err
+	}
+	flags := func(id int32) (f Flags) {
+		if id == 0 {
+			return
+		}
+		if k, ok := kos[id]; ok {
+			f.KOS = []db.KOSEntry{k}
+		}
+		f.Standing, f.HasStanding = standings[id]
+		f.Member = id == corpID || id == allianceID
+		return
+	}
+
+	alliances := make(map[int32]*LocalAlliance)
+	corps := make(map[int32]*LocalCorporation)
+	for _, a := range as {
+		ally, ok := alliances[a.AllianceID]
+		if !ok {
+			ally = &LocalAlliance{
+				AllianceID: a.AllianceID,
+				Name:       groupNames[a.AllianceID].Name,
+				Flags:      flags(a.AllianceID),
+			}
+			alliances[a.AllianceID] = ally
+		}
+		corp, ok := corps[a.CorporationID]
+		if !ok {
+			corp = &LocalCorporation{
+				CorporationID: a.CorporationID,
+				Name:          groupNames[a.CorporationID].Name,
+				Flags:         flags(a.CorporationID),
+			}
+			corps[a.CorporationID] = corp
+		}
+		p := LocalPilot{
+			CharacterID: a.CharacterID,
+			Name:        chars[a.CharacterID],
+			Flags:       flags(a.CharacterID),
+		}
+		for _, f := range []Flags{corp.Flags, ally.Flags} {
+			p.KOS = append(p.KOS, f.KOS...)
+			if !p.HasStanding {
+				p.Standing, p.HasStanding = f.Standing, f.HasStanding
+			}
+			p.Member = p.Member || f.Member
+		}
+		corp.Pilots = append(corp.Pilots, p)
+		ally.NPilots++
+		res.NPilots++
+		if p.IsHostile() {
+			res.NHostile++
+		} else if p.IsFriendly() {
+			res.NFriendly++
+		}
+	}
+	for _, corp := range corps {
+		sort.Slice(corp.Pilots, func(i, j int) bool {
+			return strings.ToLower(corp.Pilots[i].Name) < strings.ToLower(corp.Pilots[j].Name)
+		})
+	}
+	for _, a := range as {
+		if corp, ok := corps[a.CorporationID]; ok {
+			ally := alliances[a.AllianceID]
+			ally.Corporations = append(ally.Corporations, *corp)
+			delete(corps, a.CorporationID)
+		}
+	}
+	for _, ally := range alliances {
+		sort.Slice(ally.Corporations, func(i, j int) bool {
+			return largestFirst(len(ally.Corporations[i].Pilots), len(ally.Corporations[j].Pilots), ally.Corporations[i].Name, ally.Corporations[j].Name)
+		})
+		res.Alliances = append(res.Alliances, *ally)
+	}
+	sort.Slice(res.Alliances, func(i, j int) bool {
+		return largestFirst(res.Alliances[i].NPilots, res.Alliances[j].NPilots, res.Alliances[i].Name, res.Alliances[j].Name)
+	})
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+	if err := l.DB.DeleteExpiredLocalScans(c); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	return l.DB.InsertLocalScan(c, db.LocalScan{
+		Created:   now,
+		CreatedBy: charID,
+		Expires:   now.Add(l.Expiry),
+		Result:    b,
+	})
+}
+
+// largestFirst orders groups by their number of pilots, then by name.
+func largestFirst(ni, nj int, namei, namej string) bool {
+	if ni != nj {
+		return ni > nj
+	}
+	return strings.ToLower(namei) < strings.ToLower(namej)
+}
+
+// Get obtains the scan, which is nil if it does not exist or has expired.
+func (l *Local) Get(c context.Context, id string) (*LocalScan, error) {
+	s, err := l.DB.GetLocalScan(c, id)
+	if err != nil || s == nil {
+		return nil, err
+	}
+	scan := &LocalScan{
+		ID:      s.ID,
+		Created: s.Created,
+		Expires: s.Expires,
+	}
+	if err := json.Unmarshal(s.Result, &scan.LocalScanResult); err != nil {
+		return nil, err
+	}
+	names, err := l.Names.ResolveNames(c, []int32{s.CreatedBy})
+	if err != nil {
+		return nil, err
+	}
+	scan.CreatedByName = names[s.CreatedBy].Name
+	return scan, nil
+}
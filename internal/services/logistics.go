@@ -0,0 +1,298 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/async"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/cjslep/dharma/internal/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"golang.org/x/text/language"
+)
+
+var (
+	HaulRequestNotFoundError   = errors.New("haul request does not exist")
+	HaulRequestNotAllowedError = errors.New("cannot change haul request: character is not permitted or it has moved on")
+)
+
+const (
+	// haulReferencePrefix begins the reference a courier contract's title
+	// may contain to tie it to a haul request. The game client labels the
+	// title as the contract's description.
+	haulReferencePrefix = "haul-"
+	// haulReferenceLength is how many characters of the request's ID are
+	// used in its reference.
+	haulReferenceLength = 8
+)
+
+// Logistics runs the haul request board, where members ask for items to be
+// hauled and haulers claim them. Requests are completed by finding the courier
+// contracts made for them.
+type Logistics struct {
+	DB           *db.DB
+	ESI          *ESI
+	Names        *Names
+	L            *zerolog.Logger
+	PeriodicSync time.Duration
+	ShowFinished time.Duration
+}
+
+func (l *Logistics) GoPeriodicallyMatchContracts(m *async.Messenger) {
+	m.NowAndPeriodically(l.PeriodicSync, l.matchContracts, l.L)
+}
+
+type HaulRequestForm struct {
+	Pickup     string
+	Dropoff    string
+	Volume     float64
+	Collateral float64
+	Reward     float64
+	Notes      string
+}
+
+// Post creates an open haul request for the character. Pickup and dropoff
+// locations that are station names are resolved, so their courier contracts
+// can be found without a reference.
+func (l *Logistics) Post(c context.Context, charID int32, f HaulRequestForm, lang language.Tag) error {
+	f.Pickup, f.Dropoff = strings.TrimSpace(f.Pickup), strings.TrimSpace(f.Dropoff)
+	es, err := l.Names.ResolveIDs(c, []string{f.Pickup, f.Dropoff}, lang)
+	if err != nil {
+		return err
+	}
+	station := func(name string) int64 {
+		for _, e := range es[strings.ToLower(name)] {
+			if e.Category == esi.StationCategory {
+				return int64(e.ID)
+			}
+		}
+		return 0
+	}
+	return l.DB.InsertHaulRequest(c, db.HaulRequest{
+		Created:           time.Now(),
+		RequesterID:       charID,
+		Pickup:            f.Pickup,
+		PickupLocationID:  station(f.Pickup),
+		Dropoff:           f.Dropoff,
+		DropoffLocationID: station(f.Dropoff),
+		Volume:            f.Volume,
+		Collateral:        f.Collateral,
+		Reward:            f.Reward,
+		Notes:             strings.TrimSpace(f.Notes),
+	})
+}
+
+// Claim has the character haul an open request made by someone else.
+func (l *Logistics) Claim(c context.Context, id string, charID int32) error {
+	h, err := l.DB.GetHaulRequest(c, id)
+	if err != nil {
+		return err
+	} else if h == nil {
+		return HaulRequestNotFoundError
+	} else if h.Status != db.HaulOpen || h.RequesterID == charID {
+		return HaulRequestNotAllowedError
+	}
+	return l.DB.SetHaulRequestHauler(c, id, db.HaulOpen, db.HaulClaimed, charID)
+}
+
+// Unclaim reopens a request the character claimed but has no contract yet.
+func (l *Logistics) Unclaim(c context.Context, id string, charID int32) error {
+	h, err := l.DB.GetHaulRequest(c, id)
+	if err != nil {
+		return err
+	} else if h == nil {
+		return HaulRequestNotFoundError
+	} else if h.Status != db.HaulClaimed || h.HaulerID != charID {
+		return HaulRequestNotAllowedError
+	}
+	return l.DB.SetHaulRequestHauler(c, id, db.HaulClaimed, db.HaulOpen, 0)
+}
+
+// Cancel withdraws a request the character made, as long as no courier
+// contract was found for it.
+func (l *Logistics) Cancel(c context.Context, id string, charID int32) error {
+	h, err := l.DB.GetHaulRequest(c, id)
+	if err != nil {
+		return err
+	} else if h == nil {
+		return HaulRequestNotFoundError
+	} else if (h.Status != db.HaulOpen && h.Status != db.HaulClaimed) || h.RequesterID != charID {
+		return HaulRequestNotAllowedError
+	}
+	return l.DB.SetHaulRequestHauler(c, id, h.Status, db.HaulCancelled, h.HaulerID)
+}
+
+type HaulRequest struct {
+	db.HaulRequest
+	// Reference may be put in a courier contract's title to tie it to the
+	// request.
+	Reference     string
+	RequesterName string
+	HaulerName    string
+}
+
+// GetBoard obtains the unfinished haul requests along with those recently
+// completed or cancelled, newest first.
+func (l *Logistics) GetBoard(c context.Context) ([]HaulRequest, error) {
+	hs, err := l.DB.GetHaulRequests(c, time.Now().Add(-l.ShowFinished))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, 0, 2*len(hs))
+	for _, h := range hs {
+		ids = append(ids, h.RequesterID, h.HaulerID)
+	}
+	es, err := l.Names.ResolveNames(c, uniqueIDs(ids))
+	if err != nil {
+		return nil, err
+	}
+	board := make([]HaulRequest, len(hs))
+	for i, h := range hs {
+		board[i] = HaulRequest{
+			HaulRequest:   h,
+			Reference:     haulReference(h.ID),
+			RequesterName: es[h.RequesterID].Name,
+			HaulerName:    es[h.HaulerID].Name,
+		}
+	}
+	return board, nil
+}
+
+func haulReference(id string) string {
+	if len(id) > haulReferenceLength {
+		id = id[:haulReferenceLength]
+	}
+	return haulReferencePrefix + id
+}
+
+// matchContracts finds the courier contracts made for unfinished haul
+// requests using the authoritative character's token, and follows the found
+// contracts until they are delivered.
+func (l *Logistics) matchContracts(c context.Context) error {
+	corpID, err := l.DB.GetCorporationManaged(c)
+	if err != nil || corpID == 0 {
+		return err
+	}
+	charID, err := l.DB.GetAuthoritativeCharacter(c)
+	if err != nil || charID == 0 {
+		return err
+	}
+	hs, err := l.DB.GetUnfinishedHaulRequests(c)
+	if err != nil || len(hs) == 0 {
+		return err
+	}
+	cs, err := l.ESI.AuthClient().CorporationContracts(c, charID, corpID)
+	if err != nil {
+		return err
+	}
+	linked, err := l.DB.GetHaulRequestContractIDs(c)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int32]esi.Contract, len(cs))
+	for _, ct := range cs {
+		byID[ct.ID] = ct
+	}
+	now := time.Now()
+	var errs []error
+	for _, h := range hs {
+		var ct esi.Contract
+		if h.ContractID != 0 {
+			// A contract ESI no longer reports is let go, the same as
+			// one that expired.
+			ct = byID[h.ContractID]
+		} else if m := matchHaulContract(h, cs, linked, now); m != nil {
+			ct = *m
+			linked[ct.ID] = true
+		} else {
+			continue
+		}
+		status, haulerID, contractID := haulProgress(h, ct, now)
+		if h.ContractID == 0 && contractID == 0 {
+			continue
+		} else if status == h.Status && haulerID == h.HaulerID && contractID == h.ContractID && ct.Status == h.ContractStatus {
+			continue
+		}
+		errs = append(errs, l.DB.SetHaulRequestContract(c, h.ID, h.Status, status, haulerID, contractID, ct.Status))
+	}
+	return util.ToErrors(errs)
+}
+
+// matchHaulContract finds the courier contract the requester issued for the
+// request after making it. It must either contain the request's reference, or
+// go between the request's stations with the same collateral. Contracts that
+// will never be delivered are passed over, so a reissued one is found.
+func matchHaulContract(h db.HaulRequest, cs []esi.Contract, linked map[int32]bool, now time.Time) *esi.Contract {
+	ref := haulReference(h.ID)
+	for i, ct := range cs {
+		if ct.Type != esi.ContractCourier ||
+			!mayDeliver(ct, now) ||
+			linked[ct.ID] ||
+			ct.IssuerID != h.RequesterID ||
+			ct.DateIssued.Before(h.Created) ||
+			(h.HaulerID != 0 && ct.AcceptorID != 0 && ct.AcceptorID != h.HaulerID) ||
+			(h.PickupLocationID != 0 && ct.StartLocationID != h.PickupLocationID) ||
+			(h.DropoffLocationID != 0 && ct.EndLocationID != h.DropoffLocationID) {
+			continue
+		}
+		if strings.Contains(strings.ToLower(ct.Title), ref) {
+			return &cs[i]
+		}
+		if h.PickupLocationID != 0 && h.DropoffLocationID != 0 && math.Abs(ct.Collateral-h.Collateral) < 1 {
+			return &cs[i]
+		}
+	}
+	return nil
+}
+
+// haulProgress determines the request's status, hauler, and contract from its
+// courier contract. Contracts that will never be delivered are let go, so that
+// another may be found.
+func haulProgress(h db.HaulRequest, ct esi.Contract, now time.Time) (status string, haulerID, contractID int32) {
+	haulerID = h.HaulerID
+	if ct.AcceptorID != 0 {
+		haulerID = ct.AcceptorID
+	}
+	switch {
+	case ct.Status == esi.ContractFinished:
+		return db.HaulCompleted, haulerID, ct.ID
+	case mayDeliver(ct, now):
+		return db.HaulContracted, haulerID, ct.ID
+	case h.HaulerID != 0:
+		return db.HaulClaimed, h.HaulerID, 0
+	default:
+		return db.HaulOpen, 0, 0
+	}
+}
+
+// mayDeliver determines whether the contract was delivered or still may be.
+func mayDeliver(ct esi.Contract, now time.Time) bool {
+	switch ct.Status {
+	case esi.ContractFinished, esi.ContractInProgress:
+		return true
+	case esi.ContractOutstanding:
+		return ct.DateExpired.After(now)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,85 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/db"
+)
+
+func TestMatchHaulContractSkipsExpiredForReissued(t *testing.T) {
+	now := time.Now()
+	h := db.HaulRequest{
+		ID:                "3f2a9c1e-0000-0000-0000-000000000000",
+		Created:           now.Add(-72 * time.Hour),
+		RequesterID:       90000002,
+		PickupLocationID:  60003760,
+		DropoffLocationID: 60008494,
+		Collateral:        1e9,
+		Status:            db.HaulClaimed,
+		HaulerID:          90000003,
+	}
+	courier := func(id int32, status string, issued, expires time.Time) esi.Contract {
+		return esi.Contract{
+			ID:              id,
+			Type:            esi.ContractCourier,
+			Status:          status,
+			Title:           haulReference(h.ID),
+			IssuerID:        h.RequesterID,
+			StartLocationID: h.PickupLocationID,
+			EndLocationID:   h.DropoffLocationID,
+			Collateral:      h.Collateral,
+			DateIssued:      issued,
+			DateExpired:     expires,
+		}
+	}
+	cs := []esi.Contract{
+		courier(1, esi.ContractOutstanding, now.Add(-48*time.Hour), now.Add(-time.Hour)),
+		courier(2, esi.ContractDeleted, now.Add(-36*time.Hour), now.Add(time.Hour)),
+		courier(3, esi.ContractOutstanding, now.Add(-time.Hour), now.Add(24*time.Hour)),
+	}
+	m := matchHaulContract(h, cs, map[int32]bool{}, now)
+	if m == nil {
+		t.Fatal("no contract matched")
+	} else if m.ID != 3 {
+		t.Fatalf("matched contract %d, want the reissued contract 3", m.ID)
+	}
+	status, haulerID, contractID := haulProgress(h, *m, now)
+	if status != db.HaulContracted || haulerID != h.HaulerID || contractID != 3 {
+		t.Errorf("progress is %s, %d, %d", status, haulerID, contractID)
+	}
+}
+
+func TestMatchHaulContractNoneDeliverable(t *testing.T) {
+	now := time.Now()
+	h := db.HaulRequest{ID: "3f2a9c1e", Created: now.Add(-time.Hour), RequesterID: 90000002}
+	cs := []esi.Contract{{
+		ID:          1,
+		Type:        esi.ContractCourier,
+		Status:      esi.ContractRejected,
+		Title:       haulReference(h.ID),
+		IssuerID:    h.RequesterID,
+		DateIssued:  now,
+		DateExpired: now.Add(time.Hour),
+	}}
+	if m := matchHaulContract(h, cs, map[int32]bool{}, now); m != nil {
+		t.Errorf("matched rejected contract %d", m.ID)
+	}
+}
@@ -0,0 +1,231 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/cjslep/dharma/esi"
+	"golang.org/x/text/language"
+)
+
+var (
+	mailLineBreakRegexp = regexp.MustCompile(`(?i)<br\s*/?>`)
+	mailTagRegexp       = regexp.MustCompile(`<[^>]*>`)
+)
+
+// UnknownRecipientsError is returned when composing a mail to names that do
+// not match any character, corporation, alliance, or mailing list.
+type UnknownRecipientsError struct {
+	Names []string
+}
+
+func (u *UnknownRecipientsError) Error() string {
+	return fmt.Sprintf("unknown mail recipients: %s", strings.Join(u.Names, ", "))
+}
+
+// Mail reads and sends EVE mail on behalf of members' characters. Mail is not
+// kept locally, so that it stays private to the character.
+type Mail struct {
+	ESI   *ESI
+	Names *Names
+}
+
+type Mailbox struct {
+	Labels      []esi.MailLabel
+	TotalUnread int32
+	Label       int32
+	Headers     []esi.MailHeader
+	Names       map[int32]string
+	// Oldest is the ID of the last mail in Headers, when there may be
+	// older mail to page to.
+	Oldest int32
+}
+
+// GetMailbox obtains a page of the character's mail with the label, or all
+// mail if the label is zero. Only mail older than beforeID is obtained, unless
+// it is zero.
+func (m *Mail) GetMailbox(c context.Context, charID, label, beforeID int32) (*Mailbox, error) {
+	ac := m.ESI.AuthClient()
+	ls, unread, err := ac.MailLabels(c, charID)
+	if err != nil {
+		return nil, err
+	}
+	var labels []int32
+	if label != 0 {
+		labels = []int32{label}
+	}
+	hs, err := ac.MailHeaders(c, charID, labels, beforeID)
+	if err != nil {
+		return nil, err
+	}
+	b := &Mailbox{
+		Labels:      ls,
+		TotalUnread: unread,
+		Label:       label,
+		Headers:     hs,
+	}
+	if len(hs) == esi.NMailPerPage {
+		b.Oldest = hs[len(hs)-1].ID
+	}
+	ids := make([]int32, len(hs))
+	for i, h := range hs {
+		ids[i] = h.From
+	}
+	b.Names, err = m.resolveNames(c, charID, ids, nil)
+	return b, err
+}
+
+type MailContents struct {
+	esi.Mail
+	// Text is the Body without EVE's markup.
+	Text  string
+	Names map[int32]string
+}
+
+// GetMail obtains one of the character's mail, marking it as read if the
+// character permits organizing their mail.
+func (m *Mail) GetMail(c context.Context, charID, mailID int32) (*MailContents, error) {
+	ac := m.ESI.AuthClient()
+	ml, err := ac.Mail(c, charID, mailID)
+	if err != nil {
+		return nil, err
+	}
+	if !ml.IsRead {
+		err = ac.UpdateMail(c, charID, mailID, ml.Labels, true)
+		if _, ok := err.(*esi.MissingScopeError); err != nil && !ok {
+			return nil, err
+		}
+	}
+	mc := &MailContents{
+		Mail: *ml,
+		Text: mailText(ml.Body),
+	}
+	mc.Names, err = m.resolveNames(c, charID, []int32{ml.From}, ml.Recipients)
+	return mc, err
+}
+
+// resolveNames names the senders and recipients of mail. Mailing lists are
+// named from the character's subscriptions, as ESI does not resolve them.
+func (m *Mail) resolveNames(c context.Context, charID int32, ids []int32, rs []esi.MailRecipient) (map[int32]string, error) {
+	names := make(map[int32]string, len(ids)+len(rs))
+	hasLists := false
+	for _, r := range rs {
+		if r.Type == esi.MailRecipientMailingList {
+			hasLists = true
+		} else {
+			ids = append(ids, r.ID)
+		}
+	}
+	if hasLists {
+		ls, err := m.ESI.AuthClient().MailingLists(c, charID)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range ls {
+			names[l.ID] = l.Name
+		}
+	}
+	es, err := m.Names.ResolveNames(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	for id, e := range es {
+		names[id] = e.Name
+	}
+	return names, nil
+}
+
+// ResolveRecipients finds the recipients with the given names, preferring the
+// character's mailing lists, then characters, corporations, and alliances.
+func (m *Mail) ResolveRecipients(c context.Context, charID int32, names []string, lang language.Tag) ([]esi.MailRecipient, error) {
+	ls, err := m.ESI.AuthClient().MailingLists(c, charID)
+	if err != nil {
+		return nil, err
+	}
+	lists := make(map[string]int32, len(ls))
+	for _, l := range ls {
+		lists[strings.ToLower(l.Name)] = l.ID
+	}
+	var rest []string
+	for _, n := range uniqueLowerNames(names) {
+		if _, ok := lists[n]; !ok {
+			rest = append(rest, n)
+		}
+	}
+	es, err := m.Names.ResolveIDs(c, rest, lang)
+	if err != nil {
+		return nil, err
+	}
+	var rs []esi.MailRecipient
+	var unknown []string
+	for _, n := range uniqueLowerNames(names) {
+		if id, ok := lists[n]; ok {
+			rs = append(rs, esi.MailRecipient{ID: id, Type: esi.MailRecipientMailingList})
+		} else if r, ok := mailRecipient(es[n]); ok {
+			rs = append(rs, r)
+		} else {
+			unknown = append(unknown, n)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, &UnknownRecipientsError{unknown}
+	}
+	return rs, nil
+}
+
+// mailRecipient picks the entity that mail may be sent to, in order of
+// preference.
+func mailRecipient(es []esi.Entity) (esi.MailRecipient, bool) {
+	for _, category := range []string{esi.CharacterCategory, esi.CorporationCategory, esi.AllianceCategory} {
+		for _, e := range es {
+			if e.Category == category {
+				// The recipient types match the entity categories.
+				return esi.MailRecipient{ID: e.ID, Type: category}, true
+			}
+		}
+	}
+	return esi.MailRecipient{}, false
+}
+
+// Send sends a mail from the character to the named recipients, which are
+// separated by commas or new lines.
+func (m *Mail) Send(c context.Context, charID int32, to, subject, body string, lang language.Tag) error {
+	names := strings.FieldsFunc(to, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r'
+	})
+	rs, err := m.ResolveRecipients(c, charID, names, lang)
+	if err != nil {
+		return err
+	}
+	// ESI expects the same markup the client uses.
+	body = strings.ReplaceAll(html.EscapeString(body), "\n", "<br>")
+	_, err = m.ESI.AuthClient().SendMail(c, charID, rs, subject, body)
+	return err
+}
+
+// mailText removes EVE's markup from a mail body, keeping its line breaks.
+func mailText(body string) string {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	body = mailLineBreakRegexp.ReplaceAllString(body, "\n")
+	body = mailTagRegexp.ReplaceAllString(body, "")
+	return html.UnescapeString(body)
+}
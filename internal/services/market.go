@@ -0,0 +1,264 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/async"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/cjslep/dharma/internal/sde"
+	"github.com/cjslep/dharma/internal/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"golang.org/x/text/language"
+)
+
+const (
+	// marketPercentile is the fraction of the order book's volume, from the
+	// best price inwards, that a hub's buy and sell prices are averaged
+	// over. It keeps a single outlier order from setting the price.
+	marketPercentile = 0.05
+	// nMarketSearchResults is the most types shown when searching prices.
+	nMarketSearchResults = 50
+)
+
+// MarketHub is a station whose order book is summarized into prices.
+type MarketHub struct {
+	RegionID  int32
+	StationID int32
+}
+
+// ParseMarketHubs parses a comma separated list of hubs, each written as its
+// region ID and station ID separated by a colon.
+func ParseMarketHubs(s string) ([]MarketHub, error) {
+	var hs []MarketHub
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if len(f) == 0 {
+			continue
+		}
+		parts := strings.Split(f, ":")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("market hub is not region_id:station_id: %q", f)
+		}
+		region, err := strconv.ParseInt(parts[0], 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid market hub region id: %q", f)
+		}
+		station, err := strconv.ParseInt(parts[1], 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid market hub station id: %q", f)
+		}
+		hs = append(hs, MarketHub{RegionID: int32(region), StationID: int32(station)})
+	}
+	return hs, nil
+}
+
+// Market keeps the universe-wide prices and the prices at the configured
+// trade hubs, for pricing items without asking ESI.
+type Market struct {
+	DB           *db.DB
+	ESI          *ESI
+	SDE          *SDE
+	L            *zerolog.Logger
+	PeriodicSync time.Duration
+	Hubs         []MarketHub
+}
+
+func (m *Market) GoPeriodicallySyncMarket(ms *async.Messenger) {
+	ms.NowAndPeriodically(m.PeriodicSync, m.syncMarket, m.L)
+}
+
+// syncMarket fetches the universe-wide prices and each hub's order book. Hubs
+// in the same region share one fetch of the region's order book.
+func (m *Market) syncMarket(c context.Context) error {
+	ps, err := m.ESI.ESIClient.MarketPrices(c)
+	if err != nil {
+		return err
+	}
+	if err := m.DB.SetMarketPrices(c, ps); err != nil {
+		return err
+	}
+	orders := make(map[int32][]esi.MarketOrder)
+	errs := make([]error, len(m.Hubs))
+	for i, h := range m.Hubs {
+		os, ok := orders[h.RegionID]
+		if !ok {
+			os, err = m.ESI.ESIClient.RegionOrders(c, h.RegionID)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			orders[h.RegionID] = os
+		}
+		errs[i] = m.DB.ReplaceHubPrices(c, int64(h.StationID), summarizeOrders(int64(h.StationID), os))
+	}
+	return util.ToErrors(errs)
+}
+
+// summarizeOrders prices each type with orders at the location. The buy price
+// is the volume-weighted average of the highest buy orders making up the
+// marketPercentile of buy volume, and the sell price likewise for the lowest
+// sell orders.
+func summarizeOrders(locationID int64, os []esi.MarketOrder) []db.HubPrice {
+	buys := make(map[int32][]esi.MarketOrder)
+	sells := make(map[int32][]esi.MarketOrder)
+	for _, o := range os {
+		if o.LocationID != locationID || o.VolumeRemain <= 0 {
+			continue
+		}
+		if o.IsBuy {
+			buys[o.TypeID] = append(buys[o.TypeID], o)
+		} else {
+			sells[o.TypeID] = append(sells[o.TypeID], o)
+		}
+	}
+	byType := make(map[int32]*db.HubPrice)
+	price := func(typeID int32) *db.HubPrice {
+		if h, ok := byType[typeID]; ok {
+			return h
+		}
+		h := &db.HubPrice{LocationID: locationID, TypeID: typeID}
+		byType[typeID] = h
+		return h
+	}
+	for typeID, bs := range buys {
+		sort.Slice(bs, func(i, j int) bool { return bs[i].Price > bs[j].Price })
+		h := price(typeID)
+		h.Buy, h.BuyVolume = percentilePrice(bs)
+	}
+	for typeID, ss := range sells {
+		sort.Slice(ss, func(i, j int) bool { return ss[i].Price < ss[j].Price })
+		h := price(typeID)
+		h.Sell, h.SellVolume = percentilePrice(ss)
+	}
+	hps := make([]db.HubPrice, 0, len(byType))
+	for _, h := range byType {
+		hps = append(hps, *h)
+	}
+	return hps
+}
+
+// percentilePrice averages the prices of the orders, which are sorted best
+// first, over the marketPercentile of their total volume. It also returns the
+// total volume.
+func percentilePrice(os []esi.MarketOrder) (float64, int64) {
+	var total int64
+	for _, o := range os {
+		total += int64(o.VolumeRemain)
+	}
+	want := int64(float64(total) * marketPercentile)
+	if want < 1 {
+		want = 1
+	}
+	var n int64
+	var sum float64
+	for _, o := range os {
+		take := int64(o.VolumeRemain)
+		if n+take > want {
+			take = want - n
+		}
+		n += take
+		sum += float64(take) * o.Price
+		if n >= want {
+			break
+		}
+	}
+	return sum / float64(n), total
+}
+
+// HubPrices obtains what the types buy and sell for at the hub's station.
+// Types without any orders there are absent.
+func (m *Market) HubPrices(c context.Context, stationID int32, typeIDs []int32) (map[int32]db.HubPrice, error) {
+	return m.DB.GetHubPrices(c, int64(stationID), typeIDs)
+}
+
+// UniversePrices obtains CCP Games' universe-wide prices of the types.
+func (m *Market) UniversePrices(c context.Context, typeIDs []int32) (map[int32]esi.MarketPrice, error) {
+	return m.DB.GetMarketPrices(c, typeIDs)
+}
+
+// Hub is a configured trade hub along with its station.
+type Hub struct {
+	MarketHub
+	Station *esi.Station
+}
+
+// GetHubs obtains the configured trade hubs.
+func (m *Market) GetHubs(c context.Context) ([]Hub, error) {
+	hs := make([]Hub, len(m.Hubs))
+	for i, h := range m.Hubs {
+		st, err := m.ESI.ESIClient.Station(c, h.StationID)
+		if err != nil {
+			return nil, err
+		}
+		hs[i] = Hub{h, st}
+	}
+	return hs, nil
+}
+
+// TypePrices are a type's prices at a hub and across the universe.
+type TypePrices struct {
+	Type     sde.Type
+	Name     string
+	Hub      db.HubPrice
+	HasHub   bool
+	Universe esi.MarketPrice
+}
+
+// SearchPrices finds the market types whose name contains the query, along
+// with their prices at the hub's station.
+func (m *Market) SearchPrices(c context.Context, query string, stationID int32, lang language.Tag) ([]TypePrices, error) {
+	query = strings.TrimSpace(query)
+	if len(query) == 0 {
+		return nil, nil
+	}
+	ts, err := m.SDE.SearchMarketTypes(c, query, nMarketSearchResults)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, len(ts))
+	for i, t := range ts {
+		ids[i] = t.ID
+	}
+	hps, err := m.HubPrices(c, stationID, ids)
+	if err != nil {
+		return nil, err
+	}
+	ups, err := m.UniversePrices(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]TypePrices, len(ts))
+	for i, t := range ts {
+		hp, ok := hps[t.ID]
+		out[i] = TypePrices{
+			Type:     t,
+			Name:     t.Name.Get(lang),
+			Hub:      hp,
+			HasHub:   ok,
+			Universe: ups[t.ID],
+		}
+	}
+	return out, nil
+}
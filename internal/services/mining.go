@@ -0,0 +1,259 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/async"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/cjslep/dharma/internal/util"
+	"github.com/rs/zerolog"
+	"golang.org/x/text/language"
+)
+
+// Mining keeps the ledgers of the corporation's mining observers, so that
+// members can be taxed on the value of the ore they mined.
+type Mining struct {
+	DB           *db.DB
+	ESI          *ESI
+	SDE          *SDE
+	Market       *Market
+	Names        *Names
+	L            *zerolog.Logger
+	PeriodicSync time.Duration
+	// TaxRate is the percentage of the ore's value owed by members without
+	// a rate of their own.
+	TaxRate float64
+}
+
+func (m *Mining) GoPeriodicallySyncMiningLedgers(ms *async.Messenger) {
+	ms.NowAndPeriodically(m.PeriodicSync, m.syncMiningLedgers, m.L)
+}
+
+// syncMiningLedgers fetches the ledger of each of the corporation's mining
+// observers using the authoritative character's token.
+func (m *Mining) syncMiningLedgers(c context.Context) error {
+	corpID, err := m.DB.GetCorporationManaged(c)
+	if err != nil || corpID == 0 {
+		return err
+	}
+	charID, err := m.DB.GetAuthoritativeCharacter(c)
+	if err != nil || charID == 0 {
+		return err
+	}
+	ac := m.ESI.AuthClient()
+	os, err := ac.CorporationMiningObservers(c, charID, corpID)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	var mined []esi.MiningLedgerEntry
+	for _, o := range os {
+		es, err := ac.CorporationMiningLedger(c, charID, corpID, o.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		errs = append(errs, m.DB.UpsertMiningLedger(c, o.ID, es))
+		mined = append(mined, es...)
+	}
+	errs = append(errs, m.snapshotOrePrices(c, mined))
+	return util.ToErrors(errs)
+}
+
+// snapshotOrePrices prices each type of ore on each day it was mined, at what
+// the first trade hub buys it for or else its universe-wide average price.
+// Days already priced keep their price, so the tax owed does not follow the
+// market afterwards. Ore without any price yet is priced by a later sync.
+func (m *Mining) snapshotOrePrices(c context.Context, es []esi.MiningLedgerEntry) error {
+	if len(es) == 0 {
+		return nil
+	}
+	typeIDs := make([]int32, len(es))
+	for i, e := range es {
+		typeIDs[i] = e.TypeID
+	}
+	typeIDs = uniqueIDs(typeIDs)
+	var stationID int32
+	var hubPrices map[int32]db.HubPrice
+	var err error
+	if len(m.Market.Hubs) > 0 {
+		stationID = m.Market.Hubs[0].StationID
+		if hubPrices, err = m.Market.HubPrices(c, stationID, typeIDs); err != nil {
+			return err
+		}
+	}
+	universe, err := m.Market.UniversePrices(c, typeIDs)
+	if err != nil {
+		return err
+	}
+	type key struct {
+		day    int64
+		typeID int32
+	}
+	seen := make(map[key]bool)
+	var ps []db.MiningOrePrice
+	for _, e := range es {
+		k := key{e.Day.Unix(), e.TypeID}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		p := db.MiningOrePrice{Day: e.Day, TypeID: e.TypeID, Price: universe[e.TypeID].AveragePrice}
+		if hp, ok := hubPrices[e.TypeID]; ok && hp.BuyVolume > 0 {
+			p.StationID = stationID
+			p.Price = hp.Buy
+		}
+		if p.Price > 0 {
+			ps = append(ps, p)
+		}
+	}
+	return m.DB.InsertMiningOrePrices(c, ps)
+}
+
+// SetTaxRate sets the percentage of the ore's value the character owes,
+// instead of the default.
+func (m *Mining) SetTaxRate(c context.Context, charID int32, rate float64) error {
+	return m.DB.SetMiningTaxRate(c, charID, rate)
+}
+
+// ResetTaxRate has the character owe the default percentage again.
+func (m *Mining) ResetTaxRate(c context.Context, charID int32) error {
+	return m.DB.DeleteMiningTaxRate(c, charID)
+}
+
+type MinedOre struct {
+	TypeID   int32
+	Name     string
+	Quantity int64
+	// Price is per unit, averaged over the days it was mined.
+	Price float64
+	Value float64
+}
+
+type MiningMember struct {
+	CharacterID int32
+	Name        string
+	Ores        []MinedOre
+	Value       float64
+	// Rate is the percentage of Value owed as Tax.
+	Rate float64
+	// HasOwnRate is true when Rate is not the default.
+	HasOwnRate bool
+	Tax        float64
+}
+
+type MiningReport struct {
+	Month       time.Time
+	DefaultRate float64
+	// HubStationID is the trade hub the ore was priced at, which is zero if
+	// only universe-wide prices were used.
+	HubStationID int32
+	HubName      string
+	Members      []MiningMember
+	Value        float64
+	Tax          float64
+}
+
+// GetReport obtains what each character mined during the month and the tax
+// they owe, owing the most first. Ore is priced as it was snapshotted on each
+// day it was mined, so Price is the average over the month.
+func (m *Mining) GetReport(c context.Context, year int, month time.Month, lang language.Tag) (*MiningReport, error) {
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	ts, err := m.DB.GetMiningTotals(c, start, end)
+	if err != nil {
+		return nil, err
+	}
+	stationID, err := m.DB.GetMiningOrePriceStation(c, start, end)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := m.DB.GetMiningTaxRates(c)
+	if err != nil {
+		return nil, err
+	}
+	r := &MiningReport{
+		Month:        start,
+		DefaultRate:  m.TaxRate,
+		HubStationID: stationID,
+	}
+	var typeIDs, nameIDs []int32
+	for _, t := range ts {
+		typeIDs = append(typeIDs, t.TypeID)
+		nameIDs = append(nameIDs, t.CharacterID)
+	}
+	if stationID != 0 {
+		nameIDs = append(nameIDs, stationID)
+	}
+	types, err := m.SDE.Types(c, uniqueIDs(typeIDs))
+	if err != nil {
+		return nil, err
+	}
+	names, err := m.Names.ResolveNames(c, uniqueIDs(nameIDs))
+	if err != nil {
+		return nil, err
+	}
+	r.HubName = names[stationID].Name
+
+	members := make(map[int32]int)
+	for _, t := range ts {
+		i, ok := members[t.CharacterID]
+		if !ok {
+			i = len(r.Members)
+			members[t.CharacterID] = i
+			rate, own := rates[t.CharacterID]
+			if !own {
+				rate = m.TaxRate
+			}
+			r.Members = append(r.Members, MiningMember{
+				CharacterID: t.CharacterID,
+				Name:        names[t.CharacterID].Name,
+				Rate:        rate,
+				HasOwnRate:  own,
+			})
+		}
+		ore := MinedOre{
+			TypeID:   t.TypeID,
+			Name:     types[t.TypeID].Name.Get(lang),
+			Quantity: t.Quantity,
+			Value:    t.Value,
+		}
+		if t.Quantity > 0 {
+			ore.Price = t.Value / float64(t.Quantity)
+		}
+		r.Members[i].Ores = append(r.Members[i].Ores, ore)
+		r.Members[i].Value += ore.Value
+	}
+	for i := range r.Members {
+		mm := &r.Members[i]
+		mm.Tax = mm.Value * mm.Rate / 100
+		sort.Slice(mm.Ores, func(a, b int) bool {
+			return mm.Ores[a].Value > mm.Ores[b].Value
+		})
+		r.Value += mm.Value
+		r.Tax += mm.Tax
+	}
+	sort.Slice(r.Members, func(a, b int) bool {
+		return r.Members[a].Tax > r.Members[b].Tax
+	})
+	return r, nil
+}
@@ -0,0 +1,184 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/db"
+	"golang.org/x/text/language"
+)
+
+// Names resolves Eve entities between their IDs and names in bulk, keeping a
+// local copy so that repeatedly displaying the same names is cheap.
+type Names struct {
+	DB        *db.DB
+	ESIClient *esi.Client
+	Expiry    time.Duration
+	// UnknownExpiry is how long names that match nothing, and IDs that name
+	// nothing, are remembered.
+	UnknownExpiry time.Duration
+}
+
+// ResolveNames maps each ID to its entity, only asking ESI for those IDs
+// without an unexpired local copy. IDs that name nothing are absent from the
+// map, and are not asked of ESI again until they are forgotten.
+func (n *Names) ResolveNames(c context.Context, ids []int32) (map[int32]esi.Entity, error) {
+	ids = uniqueIDs(ids)
+	if len(ids) == 0 {
+		return map[int32]esi.Entity{}, nil
+	}
+	local, err := n.DB.GetUnexpiredEveEntities(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int32]esi.Entity, len(ids))
+	for _, e := range local {
+		m[e.ID] = e
+	}
+	unknown, err := n.DB.GetUnexpiredEveUnknownIDs(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	skip := make(map[int32]bool, len(unknown))
+	for _, id := range unknown {
+		skip[id] = true
+	}
+	missing := make([]int32, 0, len(ids)-len(m))
+	for _, id := range ids {
+		if _, ok := m[id]; !ok && !skip[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return m, nil
+	}
+	es, err := n.ESIClient.Names(c, missing)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.DB.SetEveEntities(c, es, time.Now().Add(n.Expiry)); err != nil {
+		return nil, err
+	}
+	for _, e := range es {
+		m[e.ID] = e
+	}
+	var none []int32
+	for _, id := range missing {
+		if _, ok := m[id]; !ok {
+			none = append(none, id)
+		}
+	}
+	if len(none) > 0 {
+		if err := n.DB.SetEveUnknownIDs(c, none, time.Now().Add(n.UnknownExpiry)); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ResolveIDs maps each name to the entities with that exact name, ignoring
+// case. Names that match nothing are absent from the map, and a name may match
+// entities in more than one category. Names recently found to match nothing
+// are not asked of ESI again.
+//
+// The map keys are the lowercase names.
+func (n *Names) ResolveIDs(c context.Context, names []string, lang language.Tag) (map[string][]esi.Entity, error) {
+	lower := uniqueLowerNames(names)
+	if len(lower) == 0 {
+		return map[string][]esi.Entity{}, nil
+	}
+	local, err := n.DB.GetUnexpiredEveEntitiesByName(c, lower)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string][]esi.Entity, len(lower))
+	for _, e := range local {
+		k := strings.ToLower(e.Name)
+		m[k] = append(m[k], e)
+	}
+	unknown, err := n.DB.GetUnexpiredEveUnknownNames(c, lower)
+	if err != nil {
+		return nil, err
+	}
+	skip := make(map[string]bool, len(unknown))
+	for _, name := range unknown {
+		skip[name] = true
+	}
+	missing := make([]string, 0, len(lower)-len(m))
+	for _, name := range lower {
+		if _, ok := m[name]; !ok && !skip[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return m, nil
+	}
+	es, err := n.ESIClient.IDs(c, missing, lang)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.DB.SetEveEntities(c, es, time.Now().Add(n.Expiry)); err != nil {
+		return nil, err
+	}
+	for _, e := range es {
+		k := strings.ToLower(e.Name)
+		m[k] = append(m[k], e)
+	}
+	var none []string
+	for _, name := range missing {
+		if _, ok := m[name]; !ok {
+			none = append(none, name)
+		}
+	}
+	if len(none) > 0 {
+		if err := n.DB.SetEveUnknownNames(c, none, time.Now().Add(n.UnknownExpiry)); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func uniqueIDs(ids []int32) []int32 {
+	seen := make(map[int32]bool, len(ids))
+	u := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		u = append(u, id)
+	}
+	return u
+}
+
+func uniqueLowerNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	u := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if len(name) == 0 || seen[name] {
+			continue
+		}
+		seen[name] = true
+		u = append(u, name)
+	}
+	return u
+}
@@ -16,7 +16,12 @@
 
 package services
 
-type Privileges struct{}
+// Privileges mirrors data.Privileges, as the app privileges stored for a user.
+type Privileges struct {
+	// KOSEditor permits adding to and removing from the kill on sight
+	// list.
+	KOSEditor bool
+}
 
 func DefaultPrivileges() Privileges {
 	return Privileges{}
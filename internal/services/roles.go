@@ -0,0 +1,107 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/cjslep/dharma/esi"
+	"github.com/cjslep/dharma/internal/async"
+	"github.com/cjslep/dharma/internal/db"
+	dutil "github.com/cjslep/dharma/internal/util"
+	"github.com/rs/zerolog"
+	"golang.org/x/text/language"
+)
+
+// Roles tracks the in-game corporation roles of members' characters, so that
+// managing the corporation in dharma requires in-game authority.
+//
+// Roles are only fetched periodically, so that checking a character's
+// authority on each request never waits on ESI.
+type Roles struct {
+	DB           *db.DB
+	ESI          *ESI
+	L            *zerolog.Logger
+	PeriodicSync time.Duration
+}
+
+func (r *Roles) GoPeriodicallySyncRoles(m *async.Messenger) {
+	m.NowAndPeriodically(r.PeriodicSync, r.syncRoles, r.L)
+}
+
+// syncRoles fetches the roles of every character that granted access to them.
+func (r *Roles) syncRoles(c context.Context) error {
+	ids, err := r.DB.GetCharactersWithScope(c, string(esi.ReadCorporationRolesScope))
+	if err != nil {
+		return err
+	}
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		_, errs[i] = r.fetchRoles(c, id)
+	}
+	return dutil.ToErrors(errs)
+}
+
+// fetchRoles fetches and stores the character's corporation, whether they are
+// its CEO, and their roles within it.
+func (r *Roles) fetchRoles(c context.Context, charID int32) (db.CharacterRoles, error) {
+	cr := db.CharacterRoles{CharacterID: charID}
+	ch, err := r.ESI.ESIClient.Character(c, charID, language.English)
+	if err != nil {
+		return cr, err
+	}
+	if ch.Corporation != nil {
+		cr.CorporationID = ch.Corporation.ID
+		cr.CEO = ch.Corporation.CEO != nil && ch.Corporation.CEO.ID == charID
+	}
+	cr.Roles, err = r.ESI.AuthClient().CorporationRoles(c, charID)
+	if err != nil {
+		return cr, err
+	}
+	cr.Updated = time.Now()
+	return cr, r.DB.SetCharacterRoles(c, cr)
+}
+
+// IsDirector determines whether the character is the CEO or a director of
+// the managed corporation in game, as of when their roles were last synced.
+func (r *Roles) IsDirector(c context.Context, charID int32) (bool, error) {
+	corpID, err := r.DB.GetCorporationManaged(c)
+	if err != nil {
+		return false, err
+	}
+	cr, err := r.DB.GetCharacterRoles(c, charID)
+	if err != nil {
+		return false, err
+	}
+	return cr.CorporationID == corpID && cr.IsDirector(), nil
+}
+
+// FetchIsDirector fetches the character's roles now, and determines whether
+// they are the CEO or a director of the corporation. It is meant for choosing
+// the corporation to manage, before any roles are synced for it.
+func (r *Roles) FetchIsDirector(c context.Context, charID, corpID int32) (bool, error) {
+	cr, err := r.fetchRoles(c, charID)
+	if _, ok := err.(*esi.MissingScopeError); ok {
+		// Characters authorized before roles were requested cannot prove
+		// their roles until they authorize again.
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return cr.CorporationID == corpID && cr.IsDirector(), nil
+}
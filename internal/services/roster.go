@@ -0,0 +1,92 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/cjslep/dharma/internal/async"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/rs/zerolog"
+)
+
+type Roster struct {
+	DB           *db.DB
+	ESI          *ESI
+	Names        *Names
+	L            *zerolog.Logger
+	PeriodicSync time.Duration
+	// ShowLeftWithin is how long members that left remain on the roster.
+	ShowLeftWithin time.Duration
+}
+
+func (r *Roster) GoPeriodicallySyncRoster(m *async.Messenger) {
+	m.NowAndPeriodically(r.PeriodicSync, r.syncRoster, r.L)
+}
+
+// syncRoster fetches the managed corporation's member list with the
+// authoritative character's token.
+func (r *Roster) syncRoster(c context.Context) error {
+	corpID, err := r.DB.GetCorporationManaged(c)
+	if err != nil {
+		return err
+	}
+	charID, err := r.DB.GetAuthoritativeCharacter(c)
+	if err != nil {
+		return err
+	}
+	if corpID == 0 || charID == 0 {
+		// Not yet managing a corporation
+		return nil
+	}
+	ids, err := r.ESI.AuthClient().CorporationMembers(c, charID, corpID)
+	if err != nil {
+		return err
+	}
+	return r.DB.SyncCorporationMembers(c, ids)
+}
+
+type Member struct {
+	db.RosterEntry
+	Name string
+}
+
+// GetRoster obtains the current members and recently departed members, with
+// their names.
+func (r *Roster) GetRoster(c context.Context) ([]Member, error) {
+	es, err := r.DB.GetCorporationRoster(c, time.Now().Add(-r.ShowLeftWithin))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, len(es))
+	for i, e := range es {
+		ids[i] = e.CharacterID
+	}
+	names, err := r.Names.ResolveNames(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	ms := make([]Member, len(es))
+	for i, e := range es {
+		ms[i] = Member{
+			RosterEntry: e,
+			Name:        names[e.CharacterID].Name,
+		}
+	}
+	return ms, nil
+}
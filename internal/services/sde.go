@@ -0,0 +1,115 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/cjslep/dharma/internal/sde"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+var (
+	NoSDEDirectoryError = errors.New("no directory configured for the static data export")
+)
+
+// SDE imports CCP Games' Static Data Export so that types and the universe can
+// be looked up without asking ESI.
+type SDE struct {
+	DB *db.DB
+	L  *zerolog.Logger
+	// Dir is where the Static Data Export is unzipped.
+	Dir string
+}
+
+// Import replaces the imported Static Data Export when a different release
+// has been unzipped into the directory. It is run by the import-sde command,
+// so that each release is parsed once rather than checked for by the server.
+func (s *SDE) Import(c context.Context) error {
+	if len(s.Dir) == 0 {
+		return NoSDEDirectoryError
+	}
+	b, err := sde.ReadBuild(s.Dir)
+	if err != nil {
+		return err
+	}
+	have, err := s.DB.GetSDEBuild(c)
+	if err != nil {
+		return err
+	}
+	if have.Number == b.Number {
+		s.L.Info().Int64("build", b.Number).Msg("static data export already imported")
+		return nil
+	}
+	s.L.Info().Int64("build", b.Number).Int64("previous", have.Number).Msg("importing static data export")
+	if err := sde.Import(c, s.Dir, s.DB); err != nil {
+		return err
+	}
+	s.L.Info().Int64("build", b.Number).Msg("imported static data export")
+	return nil
+}
+
+func (s *SDE) Build(c context.Context) (sde.Build, error) {
+	return s.DB.GetSDEBuild(c)
+}
+
+func (s *SDE) Types(c context.Context, ids []int32) (map[int32]sde.Type, error) {
+	return s.DB.GetSDETypes(c, ids)
+}
+
+func (s *SDE) Groups(c context.Context, ids []int32) (map[int32]sde.Group, error) {
+	return s.DB.GetSDEGroups(c, ids)
+}
+
+func (s *SDE) MarketGroups(c context.Context, ids []int32) (map[int32]sde.MarketGroup, error) {
+	return s.DB.GetSDEMarketGroups(c, ids)
+}
+
+func (s *SDE) Regions(c context.Context, ids []int32) (map[int32]sde.Region, error) {
+	return s.DB.GetSDERegions(c, ids)
+}
+
+func (s *SDE) SolarSystems(c context.Context, ids []int32) (map[int32]sde.SolarSystem, error) {
+	return s.DB.GetSDESolarSystems(c, ids)
+}
+
+// SolarSystemByName finds the solar system by its English name, ignoring case.
+// The returned solar system has a zero ID if there is none.
+func (s *SDE) SolarSystemByName(c context.Context, name string) (sde.SolarSystem, error) {
+	return s.DB.GetSDESolarSystemByName(c, name)
+}
+
+// SearchMarketTypes finds up to n types sold on the market whose English name
+// contains the query.
+func (s *SDE) SearchMarketTypes(c context.Context, query string, n int) ([]sde.Type, error) {
+	return s.DB.SearchSDEMarketTypes(c, query, n)
+}
+
+// Neighbors obtains the solar systems one jump away from the solar system.
+func (s *SDE) Neighbors(c context.Context, systemID int32) ([]int32, error) {
+	sgs, err := s.DB.GetSDEStargatesInSolarSystem(c, systemID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, len(sgs))
+	for i, sg := range sgs {
+		ids[i] = sg.DestinationSolarSystemID
+	}
+	return ids, nil
+}
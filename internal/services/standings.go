@@ -0,0 +1,72 @@
+// dharma is a supplementary corporation community tool for Eve Online.
+// Copyright (C) 2021 Cory Slep
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/cjslep/dharma/internal/async"
+	"github.com/cjslep/dharma/internal/db"
+	"github.com/rs/zerolog"
+)
+
+// Standings keeps a copy of the managed corporation's contacts, and those of
+// its alliance, so that names can be flagged by their standings.
+type Standings struct {
+	DB           *db.DB
+	ESI          *ESI
+	L            *zerolog.Logger
+	PeriodicSync time.Duration
+}
+
+func (s *Standings) GoPeriodicallySyncStandings(m *async.Messenger) {
+	m.NowAndPeriodically(s.PeriodicSync, s.syncStandings, s.L)
+}
+
+// syncStandings replaces the corporation's and alliance's contacts using the
+// authoritative character's token. The alliance's contacts are cleared if the
+// corporation is not in one.
+func (s *Standings) syncStandings(c context.Context) error {
+	corpID, err := s.DB.GetCorporationManaged(c)
+	if err != nil || corpID == 0 {
+		return err
+	}
+	charID, err := s.DB.GetAuthoritativeCharacter(c)
+	if err != nil || charID == 0 {
+		return err
+	}
+	cs, err := s.ESI.AuthClient().CorporationContacts(c, charID, corpID)
+	if err != nil {
+		return err
+	}
+	if err := s.DB.SetContacts(c, db.CorporationContacts, cs); err != nil {
+		return err
+	}
+	as, err := s.ESI.ESIClient.Affiliations(c, []int32{charID})
+	if err != nil {
+		return err
+	}
+	if len(as) == 0 || as[0].AllianceID == 0 {
+		return s.DB.SetContacts(c, db.AllianceContacts, nil)
+	}
+	cs, err = s.ESI.AuthClient().AllianceContacts(c, charID, as[0].AllianceID)
+	if err != nil {
+		return err
+	}
+	return s.DB.SetContacts(c, db.AllianceContacts, cs)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/cjslep/dharma/internal/db"
 	"github.com/go-fed/apcore/util"
 	"github.com/pkg/errors"
+	"golang.org/x/text/language"
 )
 
 // State solely manages the state of the dharma application in the face of
@@ -95,13 +96,14 @@ func NewState(c util.Context, db *db.DB, esi *esi.Client) (*State, error) {
 	} else if x == r {
 		s.state = managedExecutorCorpState
 	} else {
-		return nil, errors.Errorf("cannot initialize application state: char=%s, corp=%s, alli=%s, exec=%s", h, r, a, x)
+		return nil, errors.Errorf("cannot initialize application state: char=%d, corp=%d, alli=%d, exec=%d", h, r, a, x)
 	}
 	return s, nil
 }
 
 // RequiresCorpToBeManaged determines if the current state requires an admin
-// account to log in with a CEO character to manage that corporation.
+// account to log in with a CEO or director character to manage that
+// corporation.
 func (s *State) RequiresCorpToBeManaged() bool {
 	return s.state == unmanagedState
 }
@@ -133,31 +135,47 @@ func (s *State) ShouldCorpSendAllianceData() bool {
 }
 
 var (
-	NotCEOError = errors.New("cannot choose corp: user is not CEO")
+	NotCEOError = errors.New("cannot choose corp: user is not CEO or a director")
 )
 
-func (s *State) ChooseCorporation(c util.Context, userID string, corpID int32) error {
+// ChooseCorporation manages the corporation, if the user has its CEO or one
+// of its directors. The CEO is preferred as the authoritative character.
+func (s *State) ChooseCorporation(c util.Context, userID string, corpID int32, roles *Roles) error {
 	charIDs, _, err := s.db.GetEveCharactersForUser(c, userID)
 	if err != nil {
 		return err
 	}
-	corp, err := s.esi.Corporation(c, corpID)
+	corp, err := s.esi.Corporation(c, corpID, language.English)
 	if err != nil {
 		return err
 	}
-	// Check that the character ID of one of the ESI characters is the corp CEO
-	found := false
+	// Check that one of the ESI characters is the corp CEO or a director
+	var authID int32
 	for _, cID := range charIDs {
 		if cID == corp.CEO.ID {
-			found = true
+			authID = cID
 			break
 		}
 	}
-	if !found {
+	// A character whose roles cannot be fetched, such as one with a
+	// revoked token, does not keep another from being a director.
+	var fetchErr error
+	for i := 0; authID == 0 && i < len(charIDs); i++ {
+		isDir, err := roles.FetchIsDirector(c, charIDs[i], corp.ID)
+		if err != nil {
+			roles.L.Error().Err(err).Int32("characterID", charIDs[i]).Msg("could not fetch roles when choosing the corporation")
+			fetchErr = err
+		} else if isDir {
+			authID = charIDs[i]
+		}
+	}
+	if authID == 0 && fetchErr != nil {
+		return fetchErr
+	} else if authID == 0 {
 		return NotCEOError
 	}
 	// Set the data governing software behavior
-	if err := s.db.SetAuthoritativeCharacter(c, corp.CEO.ID); err != nil {
+	if err := s.db.SetAuthoritativeCharacter(c, authID); err != nil {
 		return err
 	}
 	if err := s.db.SetCorporationManaged(c, corp.ID); err != nil {
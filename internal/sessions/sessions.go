@@ -22,6 +22,7 @@ import (
 
 const (
 	esiOAuth2State          = "dharma-esi-oauth2-state"
+	esiOAuth2Verifier       = "dharma-esi-oauth2-verifier"
 	dharmaCharacterSelected = "dharma-character-selected"
 )
 
@@ -42,6 +43,23 @@ func ClearESIOAuth2State(k app.Session) {
 	k.Delete(esiOAuth2State)
 }
 
+func SetESIOAuth2Verifier(k app.Session, verifier string) {
+	k.Set(esiOAuth2Verifier, verifier)
+}
+
+func GetESIOAuth2Verifier(k app.Session) string {
+	v, _ := k.Get(esiOAuth2Verifier)
+	if s, ok := v.(string); !ok {
+		return ""
+	} else {
+		return s
+	}
+}
+
+func ClearESIOAuth2Verifier(k app.Session) {
+	k.Delete(esiOAuth2Verifier)
+}
+
 func SetCharacterSelected(k app.Session, cID int32) {
 	k.Set(dharmaCharacterSelected, cID)
 }
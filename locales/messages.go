@@ -644,8 +644,8 @@ func (m *Messages) MustBeCEOError() (string, error) {
 	return m.l.Localize(&i18n.LocalizeConfig{
 		DefaultMessage: &i18n.Message{
 			ID:          "mustBeCEOError",
-			Description: "Error message shown to an administrator when they have selected to manage a corporation that they are not the CEO of.",
-			Other:       "You do not have the CEO character associated with your account",
+			Description: "Error message shown to an administrator when they have selected to manage a corporation that they are neither the CEO nor a director of.",
+			Other:       "You do not have the CEO or a director character associated with your account",
 		},
 	})
 }
@@ -770,6 +770,76 @@ func (m *Messages) FeatureCoreCorporationReadCorporationWalletsScopeExplanation(
 	})
 }
 
+func (m *Messages) FeatureCoreCorporationReadCorporationRolesScopeExplanation() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "featureCoreCorporationReadCorporationRolesScopeExplanation",
+			Description: "Description of why dharma is requesting the Eve ESI scope for reading a character's corporation roles",
+			Other:       "Corporation roles are used to ensure only directors may manage the corporation in dharma.",
+		},
+	})
+}
+
+func (m *Messages) FeatureCoreCorporationReadCorporationAssetsScopeExplanation() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "featureCoreCorporationReadCorporationAssetsScopeExplanation",
+			Description: "Description of why dharma is requesting the Eve ESI scope for reading a corporation's assets",
+			Other:       "Corporation assets are used to let directors browse what the corporation owns and where it is.",
+		},
+	})
+}
+
+func (m *Messages) FeatureCoreCorporationReadCorporationIndustryJobsScopeExplanation() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "featureCoreCorporationReadCorporationIndustryJobsScopeExplanation",
+			Description: "Description of why dharma is requesting the Eve ESI scope for reading a corporation's industry jobs",
+			Other:       "Corporation industry jobs are used to track what members are building and to tell them when their jobs are ready.",
+		},
+	})
+}
+
+func (m *Messages) FeatureCoreCorporationReadCorporationMiningScopeExplanation() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "featureCoreCorporationReadCorporationMiningScopeExplanation",
+			Description: "Description of why dharma is requesting the Eve ESI scope for reading a corporation's moon mining ledgers",
+			Other:       "Corporation mining ledgers are used to report what members mined at the corporation's structures and the tax they owe.",
+		},
+	})
+}
+
+func (m *Messages) FeatureCoreCorporationReadCorporationContractsScopeExplanation() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "featureCoreCorporationReadCorporationContractsScopeExplanation",
+			Description: "Description of why dharma is requesting the Eve ESI scope for reading a corporation's contracts",
+			Other:       "Corporation contracts are used to find the courier contracts made for haul requests, and to complete the requests once they are delivered.",
+		},
+	})
+}
+
+func (m *Messages) FeatureCoreCorporationReadCorporationContactsScopeExplanation() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "featureCoreCorporationReadCorporationContactsScopeExplanation",
+			Description: "Description of why dharma is requesting the Eve ESI scope for reading a corporation's contacts",
+			Other:       "Corporation contacts are the corporation's standings, which are flagged when checking names pasted from local chat.",
+		},
+	})
+}
+
+func (m *Messages) FeatureCoreCorporationReadAllianceContactsScopeExplanation() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "featureCoreCorporationReadAllianceContactsScopeExplanation",
+			Description: "Description of why dharma is requesting the Eve ESI scope for reading an alliance's contacts",
+			Other:       "Alliance contacts are the standings of the corporation's alliance, which are flagged when checking names pasted from local chat.",
+		},
+	})
+}
+
 func (m *Messages) FeatureCoreCalendarName() (string, error) {
 	return m.l.Localize(&i18n.LocalizeConfig{
 		DefaultMessage: &i18n.Message{
@@ -889,3 +959,2210 @@ func (m *Messages) Selected() (string, error) {
 		},
 	})
 }
+
+func (m *Messages) ESIStatus() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "esiStatus",
+			Description: "Title of the administrative page showing the health of Dharma's connection to ESI",
+			Other:       "ESI Status",
+		},
+	})
+}
+
+func (m *Messages) ESIErrorLimitUnknown() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "esiErrorLimitUnknown",
+			Description: "Statement that ESI has not yet told Dharma how many errors it may make before being blocked",
+			Other:       "ESI has not yet reported an error limit.",
+		},
+	})
+}
+
+func (m *Messages) ESIErrorLimitRemaining() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "esiErrorLimitRemaining",
+			Description: "Label for the number of errors Dharma may still make before ESI blocks it",
+			Other:       "Errors remaining",
+		},
+	})
+}
+
+func (m *Messages) ESIErrorLimitReset() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "esiErrorLimitReset",
+			Description: "Label for the time at which ESI's error limit resets",
+			Other:       "Error limit resets at",
+		},
+	})
+}
+
+func (m *Messages) ESIErrorLimitThreshold() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "esiErrorLimitThreshold",
+			Description: "Label for the number of remaining errors at which Dharma stops making ESI requests",
+			Other:       "Requests pause at",
+		},
+	})
+}
+
+func (m *Messages) ESIErrorLimitPaused() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "esiErrorLimitPaused",
+			Description: "Statement that Dharma has temporarily stopped making ESI requests to avoid being blocked",
+			Other:       "ESI requests are paused until the error limit resets.",
+		},
+	})
+}
+
+func (m *Messages) ESIErrorLimitTimesLimited() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "esiErrorLimitTimesLimited",
+			Description: "Label for the number of times ESI has refused requests because Dharma made too many errors",
+			Other:       "Times error limited",
+		},
+	})
+}
+
+func (m *Messages) CorporationMembers() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "corporationMembers",
+			Description: "Title of the page listing the members of the corporation",
+			Other:       "Members",
+		},
+	})
+}
+
+func (m *Messages) MemberCharacter() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "memberCharacter",
+			Description: "Column heading for the name of a character in the corporation",
+			Other:       "Character",
+		},
+	})
+}
+
+func (m *Messages) MemberJoined() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "memberJoined",
+			Description: "Column heading for the date a character was first seen in the corporation",
+			Other:       "Joined",
+		},
+	})
+}
+
+func (m *Messages) MemberLeft() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "memberLeft",
+			Description: "Column heading for the date a character was seen leaving the corporation",
+			Other:       "Left",
+		},
+	})
+}
+
+func (m *Messages) MemberAccount() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "memberAccount",
+			Description: "Column heading for the Dharma account that has added a character",
+			Other:       "Account",
+		},
+	})
+}
+
+func (m *Messages) MemberNoAccount() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "memberNoAccount",
+			Description: "Shown when no Dharma account has added a character in the corporation",
+			Other:       "None",
+		},
+	})
+}
+
+func (m *Messages) NoCorporationMembers() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "noCorporationMembers",
+			Description: "Shown when the corporation's member list has not yet been fetched from ESI",
+			Other:       "The member list has not been fetched from ESI yet.",
+		},
+	})
+}
+
+func (m *Messages) Killboard() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboard",
+			Description: "Title of the corporation's killboard",
+			Other:       "Killboard",
+		},
+	})
+}
+
+func (m *Messages) KillboardKills() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardKills",
+			Description: "Label for the number of kills listed",
+			Other:       "Kills",
+		},
+	})
+}
+
+func (m *Messages) KillboardLosses() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardLosses",
+			Description: "Label for the number of losses listed",
+			Other:       "Losses",
+		},
+	})
+}
+
+func (m *Messages) KillboardLoss() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardLoss",
+			Description: "Marks a killmail where the victim is in the corporation",
+			Other:       "Loss",
+		},
+	})
+}
+
+func (m *Messages) KillboardTime() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardTime",
+			Description: "Label for the time a kill happened",
+			Other:       "Time",
+		},
+	})
+}
+
+func (m *Messages) KillboardShip() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardShip",
+			Description: "Label for the ship involved in a kill",
+			Other:       "Ship",
+		},
+	})
+}
+
+func (m *Messages) KillboardVictim() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardVictim",
+			Description: "Label for the victim of a kill",
+			Other:       "Victim",
+		},
+	})
+}
+
+func (m *Messages) KillboardFinalBlow() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardFinalBlow",
+			Description: "Label for the attacker that landed the final blow of a kill",
+			Other:       "Final blow",
+		},
+	})
+}
+
+func (m *Messages) KillboardSolarSystem() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardSolarSystem",
+			Description: "Label for the solar system a kill happened in",
+			Other:       "System",
+		},
+	})
+}
+
+func (m *Messages) KillboardNAttackers() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardNAttackers",
+			Description: "Label for the number of attackers involved in a kill",
+			Other:       "Attackers",
+		},
+	})
+}
+
+func (m *Messages) KillboardNoKillmails() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardNoKillmails",
+			Description: "Shown when there are no killmails to list",
+			Other:       "No killmails.",
+		},
+	})
+}
+
+func (m *Messages) KillboardPreviousMonth() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardPreviousMonth",
+			Description: "Link to the killboard of the month before",
+			Other:       "Previous month",
+		},
+	})
+}
+
+func (m *Messages) KillboardNextMonth() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardNextMonth",
+			Description: "Link to the killboard of the month after",
+			Other:       "Next month",
+		},
+	})
+}
+
+func (m *Messages) KillboardNewer() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardNewer",
+			Description: "Link to the page of more recent killmails",
+			Other:       "Newer",
+		},
+	})
+}
+
+func (m *Messages) KillboardOlder() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardOlder",
+			Description: "Link to the page of older killmails",
+			Other:       "Older",
+		},
+	})
+}
+
+func (m *Messages) KillboardDamageTaken() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardDamageTaken",
+			Description: "Label for the total damage the victim of a kill took",
+			Other:       "Damage taken",
+		},
+	})
+}
+
+func (m *Messages) KillboardAttackers() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardAttackers",
+			Description: "Heading for the list of attackers of a kill",
+			Other:       "Attackers",
+		},
+	})
+}
+
+func (m *Messages) KillboardAttacker() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardAttacker",
+			Description: "Column heading for an attacker of a kill",
+			Other:       "Attacker",
+		},
+	})
+}
+
+func (m *Messages) KillboardWeapon() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardWeapon",
+			Description: "Column heading for the weapon an attacker used",
+			Other:       "Weapon",
+		},
+	})
+}
+
+func (m *Messages) KillboardDamageDone() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardDamageDone",
+			Description: "Column heading for the damage an attacker did",
+			Other:       "Damage",
+		},
+	})
+}
+
+func (m *Messages) KillboardItems() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardItems",
+			Description: "Heading for the list of items the victim of a kill had",
+			Other:       "Items",
+		},
+	})
+}
+
+func (m *Messages) KillboardItem() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardItem",
+			Description: "Column heading for an item the victim of a kill had",
+			Other:       "Item",
+		},
+	})
+}
+
+func (m *Messages) KillboardDestroyed() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardDestroyed",
+			Description: "Column heading for the quantity of an item that was destroyed",
+			Other:       "Destroyed",
+		},
+	})
+}
+
+func (m *Messages) KillboardDropped() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "killboardDropped",
+			Description: "Column heading for the quantity of an item that dropped",
+			Other:       "Dropped",
+		},
+	})
+}
+
+func (m *Messages) Finance() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "finance",
+			Description: "Title of the corporation's finance dashboard",
+			Other:       "Finance",
+		},
+	})
+}
+
+func (m *Messages) FinanceBalances() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "financeBalances",
+			Description: "Heading for the current balances of the corporation's wallet divisions",
+			Other:       "Wallet balances",
+		},
+	})
+}
+
+func (m *Messages) FinanceDivision() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "financeDivision",
+			Description: "Column heading for a corporation wallet division",
+			Other:       "Division",
+		},
+	})
+}
+
+func (m *Messages) FinanceBalance() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "financeBalance",
+			Description: "Column heading for the ISK balance of a wallet division",
+			Other:       "Balance",
+		},
+	})
+}
+
+func (m *Messages) FinanceIncome() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "financeIncome",
+			Description: "Heading for the corporation's income over the last 30 days, by kind of journal entry",
+			Other:       "Income over the last 30 days",
+		},
+	})
+}
+
+func (m *Messages) FinanceRefType() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "financeRefType",
+			Description: "Column heading for the kind of a wallet journal entry",
+			Other:       "Kind",
+		},
+	})
+}
+
+func (m *Messages) FinanceAmount() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "financeAmount",
+			Description: "Column heading for an amount of ISK",
+			Other:       "Amount",
+		},
+	})
+}
+
+func (m *Messages) FinanceTax() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "financeTax",
+			Description: "Heading for the tax each member paid to the corporation per month",
+			Other:       "Tax paid by members",
+		},
+	})
+}
+
+func (m *Messages) FinanceNoJournal() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "financeNoJournal",
+			Description: "Shown when no wallet journal entries have been fetched from ESI",
+			Other:       "No wallet journal entries yet.",
+		},
+	})
+}
+
+func (m *Messages) FactionWarfare() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "factionWarfare",
+			Description: "Title of the corporation's faction warfare statistics page",
+			Other:       "Faction Warfare",
+		},
+	})
+}
+
+func (m *Messages) FactionWarfareMilitia() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "factionWarfareMilitia",
+			Description: "Label for the faction whose militia the corporation is enlisted in",
+			Other:       "Militia",
+		},
+	})
+}
+
+func (m *Messages) FactionWarfareThisWeek() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "factionWarfareThisWeek",
+			Description: "Heading for the corporation's faction warfare results over the last week",
+			Other:       "This week",
+		},
+	})
+}
+
+func (m *Messages) FactionWarfareLastSevenDays() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "factionWarfareLastSevenDays",
+			Description: "Column heading for faction warfare totals over the last seven days",
+			Other:       "Last 7 days",
+		},
+	})
+}
+
+func (m *Messages) FactionWarfareWeekOverWeek() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "factionWarfareWeekOverWeek",
+			Description: "Column heading for the change in faction warfare totals compared to the week before",
+			Other:       "Change from the week before",
+		},
+	})
+}
+
+func (m *Messages) FactionWarfareKills() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "factionWarfareKills",
+			Description: "Label for faction warfare kills",
+			Other:       "Kills",
+		},
+	})
+}
+
+func (m *Messages) FactionWarfareVictoryPoints() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "factionWarfareVictoryPoints",
+			Description: "Label for faction warfare victory points",
+			Other:       "Victory points",
+		},
+	})
+}
+
+func (m *Messages) FactionWarfarePilots() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "factionWarfarePilots",
+			Description: "Label for the number of pilots enrolled in faction warfare",
+			Other:       "Pilots enrolled",
+		},
+	})
+}
+
+func (m *Messages) FactionWarfareNoTrend() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "factionWarfareNoTrend",
+			Description: "Shown when there are not yet statistics from a week ago to compare against",
+			Other:       "A week of statistics is needed before changes can be shown.",
+		},
+	})
+}
+
+func (m *Messages) FactionWarfareHistory() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "factionWarfareHistory",
+			Description: "Heading for the daily history of faction warfare statistics",
+			Other:       "Daily history",
+		},
+	})
+}
+
+func (m *Messages) FactionWarfareDate() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "factionWarfareDate",
+			Description: "Column heading for the day of a faction warfare statistics snapshot",
+			Other:       "Date",
+		},
+	})
+}
+
+func (m *Messages) FactionWarfareNoStats() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "factionWarfareNoStats",
+			Description: "Shown when no faction warfare statistics have been fetched from ESI",
+			Other:       "No faction warfare statistics yet.",
+		},
+	})
+}
+
+func (m *Messages) Calendar() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "calendar",
+			Description: "Title of the page listing the corporation's calendar events",
+			Other:       "Calendar",
+		},
+	})
+}
+
+func (m *Messages) NoCalendarEvents() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "noCalendarEvents",
+			Description: "Shown when the corporation has no upcoming calendar events",
+			Other:       "No upcoming events.",
+		},
+	})
+}
+
+func (m *Messages) CalendarEventDate() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "calendarEventDate",
+			Description: "Column header for the date and time an event starts",
+			Other:       "Date",
+		},
+	})
+}
+
+func (m *Messages) CalendarEventTitle() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "calendarEventTitle",
+			Description: "Column header for the title of an event",
+			Other:       "Event",
+		},
+	})
+}
+
+func (m *Messages) CalendarEventDuration() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "calendarEventDuration",
+			Description: "Column header for how long an event lasts",
+			Other:       "Duration",
+		},
+	})
+}
+
+func (m *Messages) CalendarAccepted() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "calendarAccepted",
+			Description: "A response of accepting an event invitation",
+			Other:       "Accepted",
+		},
+	})
+}
+
+func (m *Messages) CalendarTentative() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "calendarTentative",
+			Description: "A response of tentatively accepting an event invitation",
+			Other:       "Tentative",
+		},
+	})
+}
+
+func (m *Messages) CalendarDeclined() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "calendarDeclined",
+			Description: "A response of declining an event invitation",
+			Other:       "Declined",
+		},
+	})
+}
+
+func (m *Messages) CalendarYourResponse() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "calendarYourResponse",
+			Description: "Column header for the selected character's response to an event",
+			Other:       "Your Response",
+		},
+	})
+}
+
+func (m *Messages) CalendarRespond() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "calendarRespond",
+			Description: "Button to send the selected character's response to an event",
+			Other:       "Respond",
+		},
+	})
+}
+
+func (m *Messages) CalendarNotInvited() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "calendarNotInvited",
+			Description: "Shown when the selected character is not invited to an event",
+			Other:       "Not invited",
+		},
+	})
+}
+
+func (m *Messages) Mail() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mail",
+			Description: "Title of the page listing the selected character's EVE mail",
+			Other:       "Mail",
+		},
+	})
+}
+
+func (m *Messages) MailCompose() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mailCompose",
+			Description: "Link and title for writing a new EVE mail",
+			Other:       "Compose",
+		},
+	})
+}
+
+func (m *Messages) MailAllMail() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mailAllMail",
+			Description: "Link to show all of the selected character's EVE mail regardless of label",
+			Other:       "All Mail",
+		},
+	})
+}
+
+func (m *Messages) NoMail() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "noMail",
+			Description: "Shown when there is no EVE mail to list",
+			Other:       "No mail.",
+		},
+	})
+}
+
+func (m *Messages) MailFrom() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mailFrom",
+			Description: "Label for the sender of an EVE mail",
+			Other:       "From",
+		},
+	})
+}
+
+func (m *Messages) MailTo() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mailTo",
+			Description: "Label for the recipients of an EVE mail",
+			Other:       "To",
+		},
+	})
+}
+
+func (m *Messages) MailSubject() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mailSubject",
+			Description: "Label for the subject of an EVE mail",
+			Other:       "Subject",
+		},
+	})
+}
+
+func (m *Messages) MailBody() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mailBody",
+			Description: "Label for the body of an EVE mail being written",
+			Other:       "Message",
+		},
+	})
+}
+
+func (m *Messages) MailDate() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mailDate",
+			Description: "Label for when an EVE mail was sent",
+			Other:       "Date",
+		},
+	})
+}
+
+func (m *Messages) MailOlder() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mailOlder",
+			Description: "Link to the next page of older EVE mail",
+			Other:       "Older",
+		},
+	})
+}
+
+func (m *Messages) MailReply() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mailReply",
+			Description: "Link to reply to the sender of an EVE mail",
+			Other:       "Reply",
+		},
+	})
+}
+
+func (m *Messages) MailSend() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mailSend",
+			Description: "Button to send an EVE mail",
+			Other:       "Send",
+		},
+	})
+}
+
+func (m *Messages) MailRecipientsHelp() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mailRecipientsHelp",
+			Description: "Explains how to enter the recipients of an EVE mail",
+			Other:       "Names of characters, corporations, alliances, or mailing lists, separated by commas.",
+		},
+	})
+}
+
+func (m *Messages) MailUnknownRecipients() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mailUnknownRecipients",
+			Description: "Shown before the recipient names that could not be found when sending an EVE mail",
+			Other:       "Could not find these recipients",
+		},
+	})
+}
+
+func (m *Messages) CannotUnlinkAuthoritativeCharacterError() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "cannotUnlinkAuthoritativeCharacterError",
+			Description: "Error message shown when unlinking the character the corporation is synced through.",
+			Other:       "The corporation is synced through this character. An administrator must choose the corporation with another CEO or director character before it can be unlinked.",
+		},
+	})
+}
+
+func (m *Messages) UnlinkCharacter() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "unlinkCharacter",
+			Description: "Button to remove a character from an account and revoke the access it granted",
+			Other:       "Unlink and revoke access",
+		},
+	})
+}
+
+func (m *Messages) Market() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "market",
+			Description: "Title of the page of market prices at the trade hubs",
+			Other:       "Market",
+		},
+	})
+}
+
+func (m *Messages) MarketNoHubs() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "marketNoHubs",
+			Description: "Shown on the market page when no trade hubs are configured",
+			Other:       "No trade hubs are configured.",
+		},
+	})
+}
+
+func (m *Messages) MarketSearchPlaceholder() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "marketSearchPlaceholder",
+			Description: "Placeholder of the field for searching market prices by item name",
+			Other:       "Item name",
+		},
+	})
+}
+
+func (m *Messages) MarketSearch() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "marketSearch",
+			Description: "Button to search market prices",
+			Other:       "Search",
+		},
+	})
+}
+
+func (m *Messages) MarketNoTypes() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "marketNoTypes",
+			Description: "Shown when no items sold on the market match the search",
+			Other:       "No items on the market match the search.",
+		},
+	})
+}
+
+func (m *Messages) MarketType() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "marketType",
+			Description: "Column header for an item on the market",
+			Other:       "Item",
+		},
+	})
+}
+
+func (m *Messages) MarketBuy() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "marketBuy",
+			Description: "Column header for the price an item can be sold to buy orders for",
+			Other:       "Buy",
+		},
+	})
+}
+
+func (m *Messages) MarketSell() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "marketSell",
+			Description: "Column header for the price an item can be bought from sell orders for",
+			Other:       "Sell",
+		},
+	})
+}
+
+func (m *Messages) MarketSplit() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "marketSplit",
+			Description: "Column header for the price halfway between buy and sell",
+			Other:       "Split",
+		},
+	})
+}
+
+func (m *Messages) MarketAverage() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "marketAverage",
+			Description: "Column header for CCP Games' universe-wide average price of an item",
+			Other:       "Universe Average",
+		},
+	})
+}
+
+func (m *Messages) Assets() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assets",
+			Description: "Title of the page browsing the corporation's assets",
+			Other:       "Assets",
+		},
+	})
+}
+
+func (m *Messages) AssetsNoSnapshot() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsNoSnapshot",
+			Description: "Shown when the corporation's assets have not yet been fetched",
+			Other:       "The corporation's assets have not been fetched yet.",
+		},
+	})
+}
+
+func (m *Messages) AssetsSnapshot() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsSnapshot",
+			Description: "Label preceding the time the shown assets were fetched",
+			Other:       "As of",
+		},
+	})
+}
+
+func (m *Messages) AssetsAllLocations() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsAllLocations",
+			Description: "Option to show assets in every location",
+			Other:       "All locations",
+		},
+	})
+}
+
+func (m *Messages) AssetsAllDivisions() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsAllDivisions",
+			Description: "Option to show assets in every corporation hangar division",
+			Other:       "All divisions",
+		},
+	})
+}
+
+func (m *Messages) AssetsSearchPlaceholder() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsSearchPlaceholder",
+			Description: "Placeholder text when searching assets by item type or name",
+			Other:       "Item type or name",
+		},
+	})
+}
+
+func (m *Messages) AssetsSearch() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsSearch",
+			Description: "Button to filter the corporation's assets",
+			Other:       "Filter",
+		},
+	})
+}
+
+func (m *Messages) AssetsNoAssets() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsNoAssets",
+			Description: "Shown when no assets match the filter",
+			Other:       "No assets match.",
+		},
+	})
+}
+
+func (m *Messages) AssetsTruncated() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsTruncated",
+			Description: "Shown when more assets match the filter than are displayed",
+			Other:       "Only some matching assets are shown, narrow the filter to see the rest.",
+		},
+	})
+}
+
+func (m *Messages) AssetsType() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsType",
+			Description: "Column header for an asset's item type",
+			Other:       "Type",
+		},
+	})
+}
+
+func (m *Messages) AssetsName() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsName",
+			Description: "Column header for the name a player gave an asset",
+			Other:       "Name",
+		},
+	})
+}
+
+func (m *Messages) AssetsQuantity() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsQuantity",
+			Description: "Column header for the number of items in a stack of assets",
+			Other:       "Quantity",
+		},
+	})
+}
+
+func (m *Messages) AssetsLocation() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsLocation",
+			Description: "Column header for the station, structure, or solar system an asset is in",
+			Other:       "Location",
+		},
+	})
+}
+
+func (m *Messages) AssetsDivision() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsDivision",
+			Description: "Column header for the corporation hangar division holding an asset",
+			Other:       "Division",
+		},
+	})
+}
+
+func (m *Messages) AssetsContainer() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsContainer",
+			Description: "Column header for the container or ship an asset is inside of",
+			Other:       "Container",
+		},
+	})
+}
+
+func (m *Messages) AssetsStructure() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "assetsStructure",
+			Description: "Label for a structure whose name is not known, followed by its ID",
+			Other:       "Structure",
+		},
+	})
+}
+
+func (m *Messages) Industry() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryJobs",
+			Description: "Title of the page showing the corporation's industry jobs",
+			Other:       "Industry Jobs",
+		},
+	})
+}
+
+func (m *Messages) IndustryNoJobs() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryNoJobs",
+			Description: "Shown when the corporation has no current or recently delivered industry jobs",
+			Other:       "There are no industry jobs.",
+		},
+	})
+}
+
+func (m *Messages) IndustryActive() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryActive",
+			Description: "Label preceding the number of industry jobs in progress",
+			Other:       "In progress",
+		},
+	})
+}
+
+func (m *Messages) IndustryReady() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryReady",
+			Description: "Label preceding the number of industry jobs ready to be delivered",
+			Other:       "Ready",
+		},
+	})
+}
+
+func (m *Messages) IndustryDelivered() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryDelivered",
+			Description: "Label preceding the number of industry jobs recently delivered",
+			Other:       "Delivered",
+		},
+	})
+}
+
+func (m *Messages) IndustryActivityHeader() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryActivityHeader",
+			Description: "Column header for the kind of industry job, such as manufacturing or invention",
+			Other:       "Activity",
+		},
+	})
+}
+
+func (m *Messages) IndustryBlueprint() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryBlueprint",
+			Description: "Column header for the blueprint used by an industry job",
+			Other:       "Blueprint",
+		},
+	})
+}
+
+func (m *Messages) IndustryProduct() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryProduct",
+			Description: "Column header for the item an industry job produces",
+			Other:       "Product",
+		},
+	})
+}
+
+func (m *Messages) IndustryRuns() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryRuns",
+			Description: "Column header for the number of runs of an industry job",
+			Other:       "Runs",
+		},
+	})
+}
+
+func (m *Messages) IndustryStatus() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryStatus",
+			Description: "Column header for whether an industry job is in progress, ready, or delivered",
+			Other:       "Status",
+		},
+	})
+}
+
+func (m *Messages) IndustryEnds() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryEnds",
+			Description: "Column header for when an industry job finishes",
+			Other:       "Ends",
+		},
+	})
+}
+
+func (m *Messages) IndustryStructure() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryStructure",
+			Description: "Label for a structure whose name is not known, followed by its ID",
+			Other:       "Structure",
+		},
+	})
+}
+
+func (m *Messages) IndustryJobsReadyMailSubject() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryJobsReadyMailSubject",
+			Description: "Subject of the in-game mail telling a member their industry jobs are ready",
+			Other:       "Industry jobs ready",
+		},
+	})
+}
+
+func (m *Messages) IndustryJobsReadyMailBody() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "industryJobsReadyMailBody",
+			Description: "Beginning of the in-game mail telling a member their industry jobs are ready, followed by a list of the jobs",
+			Other:       "These industry jobs are ready to be delivered:",
+		},
+	})
+}
+
+// IndustryActivity names the activity of an industry job, using the activity
+// IDs of EVE Online.
+func (m *Messages) IndustryActivity(id int32) (string, error) {
+	switch id {
+	case 1:
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "industryManufacturing",
+				Description: "The industry activity of building items from a blueprint",
+				Other:       "Manufacturing",
+			},
+		})
+	case 3:
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "industryTimeEfficiency",
+				Description: "The industry activity of researching a blueprint to build faster",
+				Other:       "Time Efficiency Research",
+			},
+		})
+	case 4:
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "industryMaterialEfficiency",
+				Description: "The industry activity of researching a blueprint to use fewer materials",
+				Other:       "Material Efficiency Research",
+			},
+		})
+	case 5:
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "industryCopying",
+				Description: "The industry activity of copying a blueprint",
+				Other:       "Copying",
+			},
+		})
+	case 7:
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "industryReverseEngineering",
+				Description: "The industry activity of reverse engineering an artifact into a blueprint",
+				Other:       "Reverse Engineering",
+			},
+		})
+	case 8:
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "industryInvention",
+				Description: "The industry activity of inventing a more advanced blueprint",
+				Other:       "Invention",
+			},
+		})
+	case 9, 11:
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "industryReactions",
+				Description: "The industry activity of reacting materials",
+				Other:       "Reactions",
+			},
+		})
+	default:
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "industryUnknownActivity",
+				Description: "The name of an industry activity that is not known",
+				Other:       "Unknown Activity",
+			},
+		})
+	}
+}
+
+// IndustryState names whether an industry job is in progress, paused, ready,
+// or delivered.
+func (m *Messages) IndustryState(state string) (string, error) {
+	switch state {
+	case "paused":
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "industryStatePaused",
+				Description: "An industry job that was paused",
+				Other:       "Paused",
+			},
+		})
+	case "ready":
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "industryStateReady",
+				Description: "An industry job that finished and is ready to be delivered",
+				Other:       "Ready",
+			},
+		})
+	case "delivered":
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "industryStateDelivered",
+				Description: "An industry job whose products were delivered",
+				Other:       "Delivered",
+			},
+		})
+	default:
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "industryStateActive",
+				Description: "An industry job in progress",
+				Other:       "In Progress",
+			},
+		})
+	}
+}
+
+func (m *Messages) Mining() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "mining",
+			Description: "Title of the page reporting the ore members mined at the corporation's structures",
+			Other:       "Mining",
+		},
+	})
+}
+
+func (m *Messages) MiningPreviousMonth() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningPreviousMonth",
+			Description: "Link to the mining report of the month before",
+			Other:       "Previous Month",
+		},
+	})
+}
+
+func (m *Messages) MiningNextMonth() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningNextMonth",
+			Description: "Link to the mining report of the month after",
+			Other:       "Next Month",
+		},
+	})
+}
+
+func (m *Messages) MiningDefaultRate() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningDefaultRate",
+			Description: "Label preceding the percentage of the value of mined ore that members owe by default",
+			Other:       "Default tax rate",
+		},
+	})
+}
+
+func (m *Messages) MiningPricedAt() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningPricedAt",
+			Description: "Label preceding where mined ore is priced",
+			Other:       "Ore priced at",
+		},
+	})
+}
+
+func (m *Messages) MiningUniversePrices() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningUniversePrices",
+			Description: "Shown when mined ore is priced at CCP Games' universe-wide average prices",
+			Other:       "Universe average prices",
+		},
+	})
+}
+
+func (m *Messages) MiningNoLedger() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningNoLedger",
+			Description: "Shown when nothing was mined at the corporation's structures during the month",
+			Other:       "Nothing was mined this month.",
+		},
+	})
+}
+
+func (m *Messages) MiningTotalValue() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningTotalValue",
+			Description: "Label preceding the value of all ore mined during the month",
+			Other:       "Total value",
+		},
+	})
+}
+
+func (m *Messages) MiningTotalTax() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningTotalTax",
+			Description: "Label preceding the tax owed by all members for the month",
+			Other:       "Total tax",
+		},
+	})
+}
+
+func (m *Messages) MiningOre() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningOre",
+			Description: "Column header for the ore a member mined",
+			Other:       "Ore",
+		},
+	})
+}
+
+func (m *Messages) MiningValue() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningValue",
+			Description: "Column header for the value of the ore a member mined",
+			Other:       "Value",
+		},
+	})
+}
+
+func (m *Messages) MiningRate() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningRate",
+			Description: "Column header for the percentage of the value of their ore a member owes",
+			Other:       "Tax Rate (%)",
+		},
+	})
+}
+
+func (m *Messages) MiningTax() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningTax",
+			Description: "Column header for the tax a member owes",
+			Other:       "Tax",
+		},
+	})
+}
+
+func (m *Messages) MiningSetRate() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "miningSetRate",
+			Description: "Button to set a member's mining tax rate, or to return it to the default when left empty",
+			Other:       "Set",
+		},
+	})
+}
+
+func (m *Messages) Logistics() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logistics",
+			Description: "Title of the board where members request items be hauled",
+			Other:       "Logistics",
+		},
+	})
+}
+
+func (m *Messages) LogisticsNewRequest() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsNewRequest",
+			Description: "Heading of the form to request items be hauled",
+			Other:       "Request a Haul",
+		},
+	})
+}
+
+func (m *Messages) LogisticsPickup() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsPickup",
+			Description: "Label for where items are to be picked up",
+			Other:       "Pickup",
+		},
+	})
+}
+
+func (m *Messages) LogisticsDropoff() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsDropoff",
+			Description: "Label for where items are to be dropped off",
+			Other:       "Dropoff",
+		},
+	})
+}
+
+func (m *Messages) LogisticsVolume() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsVolume",
+			Description: "Label for the volume of items to haul, in cubic meters",
+			Other:       "Volume (m3)",
+		},
+	})
+}
+
+func (m *Messages) LogisticsCollateral() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsCollateral",
+			Description: "Label for the collateral of a haul, in ISK",
+			Other:       "Collateral (ISK)",
+		},
+	})
+}
+
+func (m *Messages) LogisticsReward() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsReward",
+			Description: "Label for the reward of a haul, in ISK",
+			Other:       "Reward (ISK)",
+		},
+	})
+}
+
+func (m *Messages) LogisticsNotes() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsNotes",
+			Description: "Label for notes to the hauler",
+			Other:       "Notes",
+		},
+	})
+}
+
+func (m *Messages) LogisticsPost() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsPost",
+			Description: "Button to post a haul request",
+			Other:       "Post Request",
+		},
+	})
+}
+
+func (m *Messages) LogisticsContractHelp() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsContractHelp",
+			Description: "Explains how courier contracts are tied to haul requests",
+			Other:       "Once a hauler claims your request, make them a courier contract and put the request's reference in its description. Contracts between stations with the same collateral are found without one. The request completes when the contract does.",
+		},
+	})
+}
+
+func (m *Messages) LogisticsNoRequests() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsNoRequests",
+			Description: "Shown when there are no haul requests",
+			Other:       "No one has requested a haul.",
+		},
+	})
+}
+
+func (m *Messages) LogisticsReference() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsReference",
+			Description: "Column header for the reference to put in a courier contract's description",
+			Other:       "Reference",
+		},
+	})
+}
+
+func (m *Messages) LogisticsRequester() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsRequester",
+			Description: "Column header for the member who requested a haul",
+			Other:       "Requester",
+		},
+	})
+}
+
+func (m *Messages) LogisticsHauler() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsHauler",
+			Description: "Column header for the member hauling a request",
+			Other:       "Hauler",
+		},
+	})
+}
+
+func (m *Messages) LogisticsStatusHeader() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsStatusHeader",
+			Description: "Column header for how far along a haul request is",
+			Other:       "Status",
+		},
+	})
+}
+
+func (m *Messages) LogisticsClaim() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsClaim",
+			Description: "Button to claim a haul request",
+			Other:       "Claim",
+		},
+	})
+}
+
+func (m *Messages) LogisticsUnclaim() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsUnclaim",
+			Description: "Button to give up a claim on a haul request",
+			Other:       "Unclaim",
+		},
+	})
+}
+
+func (m *Messages) LogisticsCancel() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "logisticsCancel",
+			Description: "Button to cancel a haul request",
+			Other:       "Cancel",
+		},
+	})
+}
+
+// LogisticsStatus names how far along a haul request is.
+func (m *Messages) LogisticsStatus(status string) (string, error) {
+	switch status {
+	case "claimed":
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "logisticsStatusClaimed",
+				Description: "A haul request a hauler claimed",
+				Other:       "Claimed",
+			},
+		})
+	case "contracted":
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "logisticsStatusContracted",
+				Description: "A haul request whose courier contract was found",
+				Other:       "Contracted",
+			},
+		})
+	case "completed":
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "logisticsStatusCompleted",
+				Description: "A haul request whose courier contract was delivered",
+				Other:       "Completed",
+			},
+		})
+	case "cancelled":
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "logisticsStatusCancelled",
+				Description: "A haul request its requester cancelled",
+				Other:       "Cancelled",
+			},
+		})
+	default:
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "logisticsStatusOpen",
+				Description: "A haul request waiting for a hauler",
+				Other:       "Open",
+			},
+		})
+	}
+}
+
+func (m *Messages) KOS() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kos",
+			Description: "Title of the list of characters, corporations, and alliances members are to kill on sight",
+			Other:       "Kill On Sight",
+		},
+	})
+}
+
+func (m *Messages) KOSSearch() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosSearch",
+			Description: "Button to search the kill on sight list",
+			Other:       "Search",
+		},
+	})
+}
+
+func (m *Messages) KOSAdd() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosAdd",
+			Description: "Heading of the form to add to the kill on sight list",
+			Other:       "Add to the List",
+		},
+	})
+}
+
+func (m *Messages) KOSAddButton() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosAddButton",
+			Description: "Button to add to the kill on sight list",
+			Other:       "Add",
+		},
+	})
+}
+
+func (m *Messages) KOSName() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosName",
+			Description: "Label for the name of a character, corporation, or alliance",
+			Other:       "Name",
+		},
+	})
+}
+
+func (m *Messages) KOSCategoryHeader() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosCategoryHeader",
+			Description: "Column header for whether an entry is a character, corporation, or alliance",
+			Other:       "Kind",
+		},
+	})
+}
+
+func (m *Messages) KOSReason() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosReason",
+			Description: "Label for why an entity is on the kill on sight list",
+			Other:       "Reason",
+		},
+	})
+}
+
+func (m *Messages) KOSEvidence() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosEvidence",
+			Description: "Label for links supporting why an entity is on the kill on sight list",
+			Other:       "Evidence",
+		},
+	})
+}
+
+func (m *Messages) KOSEvidenceHelp() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosEvidenceHelp",
+			Description: "Explains how to enter evidence links",
+			Other:       "Links to killmails, screenshots, or posts, one per line.",
+		},
+	})
+}
+
+func (m *Messages) KOSExpires() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosExpires",
+			Description: "Label for when an entry leaves the kill on sight list",
+			Other:       "Expires",
+		},
+	})
+}
+
+func (m *Messages) KOSExpiresHelp() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosExpiresHelp",
+			Description: "Explains that the expiry date is optional",
+			Other:       "Leave empty to keep the entry until it is removed.",
+		},
+	})
+}
+
+func (m *Messages) KOSNever() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosNever",
+			Description: "Shown when an entry never expires",
+			Other:       "Never",
+		},
+	})
+}
+
+func (m *Messages) KOSAddedBy() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosAddedBy",
+			Description: "Column header for who added or last changed an entry, and when",
+			Other:       "Added By",
+		},
+	})
+}
+
+func (m *Messages) KOSRemove() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosRemove",
+			Description: "Button to remove an entry from the kill on sight list",
+			Other:       "Remove",
+		},
+	})
+}
+
+func (m *Messages) KOSNoEntries() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosNoEntries",
+			Description: "Shown when no entries on the kill on sight list match",
+			Other:       "No one is on the list.",
+		},
+	})
+}
+
+func (m *Messages) KOSTruncated() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosTruncated",
+			Description: "Shown when more entries match than are displayed",
+			Other:       "Only some entries are shown. Search to narrow them down.",
+		},
+	})
+}
+
+func (m *Messages) KOSCheck() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosCheck",
+			Description: "Title of the page to check names against the kill on sight list",
+			Other:       "Check Names",
+		},
+	})
+}
+
+func (m *Messages) KOSCheckNames() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosCheckNames",
+			Description: "Label for the names to check",
+			Other:       "Names",
+		},
+	})
+}
+
+func (m *Messages) KOSCheckHelp() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosCheckHelp",
+			Description: "Explains how to paste names to check",
+			Other:       "Paste names one per line, such as those copied from a chat channel's member list. Characters are also checked by their corporation and alliance.",
+		},
+	})
+}
+
+func (m *Messages) KOSCheckButton() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosCheckButton",
+			Description: "Button to check names against the kill on sight list",
+			Other:       "Check",
+		},
+	})
+}
+
+func (m *Messages) KOSCheckTruncated() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosCheckTruncated",
+			Description: "Shown when too many names were pasted to check them all",
+			Other:       "Too many names were pasted, so only some were checked.",
+		},
+	})
+}
+
+func (m *Messages) KOSCheckOnList() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosCheckOnList",
+			Description: "Column header for the entry a checked name matched",
+			Other:       "On the List As",
+		},
+	})
+}
+
+func (m *Messages) KOSCheckNoMatches() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosCheckNoMatches",
+			Description: "Shown when none of the checked names are on the kill on sight list",
+			Other:       "None of the names are on the list.",
+		},
+	})
+}
+
+func (m *Messages) KOSCheckClear() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosCheckClear",
+			Description: "Label for how many checked names are not on the kill on sight list",
+			Other:       "Not on the list",
+		},
+	})
+}
+
+func (m *Messages) KOSCheckUnknown() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "kosCheckUnknown",
+			Description: "Label for the checked names that are not characters, corporations, or alliances",
+			Other:       "Unknown names",
+		},
+	})
+}
+
+func (m *Messages) MemberKOSEditor() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "memberKOSEditor",
+			Description: "Column header for whether an account may edit the kill on sight list",
+			Other:       "Kill On Sight Editor",
+		},
+	})
+}
+
+func (m *Messages) MemberGrantKOSEditor() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "memberGrantKOSEditor",
+			Description: "Button to let an account edit the kill on sight list",
+			Other:       "Grant",
+		},
+	})
+}
+
+func (m *Messages) MemberRevokeKOSEditor() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "memberRevokeKOSEditor",
+			Description: "Button to stop an account from editing the kill on sight list",
+			Other:       "Revoke",
+		},
+	})
+}
+
+// KOSCategory names whether an entry on the kill on sight list is a character,
+// corporation, or alliance.
+func (m *Messages) KOSCategory(category string) (string, error) {
+	switch category {
+	case "corporation":
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "kosCategoryCorporation",
+				Description: "A corporation on the kill on sight list",
+				Other:       "Corporation",
+			},
+		})
+	case "alliance":
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "kosCategoryAlliance",
+				Description: "An alliance on the kill on sight list",
+				Other:       "Alliance",
+			},
+		})
+	default:
+		return m.l.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{
+				ID:          "kosCategoryCharacter",
+				Description: "A character on the kill on sight list",
+				Other:       "Character",
+			},
+		})
+	}
+}
+
+func (m *Messages) LocalScan() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScan",
+			Description: "Title of the page that scans names pasted from local chat",
+			Other:       "Local Scan",
+		},
+	})
+}
+
+func (m *Messages) LocalScanNames() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanNames",
+			Description: "Label for the names pasted from local chat",
+			Other:       "Local Chat Members",
+		},
+	})
+}
+
+func (m *Messages) LocalScanHelp() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanHelp",
+			Description: "Explains how to paste names from local chat",
+			Other:       "Select the members of local chat, copy them, and paste them here, one per line. The result can be shared with a link until it expires.",
+		},
+	})
+}
+
+func (m *Messages) LocalScanButton() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanButton",
+			Description: "Button to scan names pasted from local chat",
+			Other:       "Scan",
+		},
+	})
+}
+
+func (m *Messages) LocalScanBy() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanBy",
+			Description: "Label for who made a local chat scan and when",
+			Other:       "Scanned by",
+		},
+	})
+}
+
+func (m *Messages) LocalScanShare() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanShare",
+			Description: "Link to share a local chat scan",
+			Other:       "Link to this scan",
+		},
+	})
+}
+
+func (m *Messages) LocalScanExpires() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanExpires",
+			Description: "Label for when a shared local chat scan expires",
+			Other:       "expires",
+		},
+	})
+}
+
+func (m *Messages) LocalScanAgain() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanAgain",
+			Description: "Link to make a new local chat scan",
+			Other:       "New scan",
+		},
+	})
+}
+
+func (m *Messages) LocalScanTruncated() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanTruncated",
+			Description: "Shown when too many names were pasted to scan them all",
+			Other:       "Too many names were pasted, so only some were scanned.",
+		},
+	})
+}
+
+func (m *Messages) LocalScanPilots() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanPilots",
+			Description: "Label for how many pilots were scanned",
+			Other:       "Pilots",
+		},
+	})
+}
+
+func (m *Messages) LocalScanHostile() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanHostile",
+			Description: "Label for how many scanned pilots are on the kill on sight list or have negative standings",
+			Other:       "Hostile",
+		},
+	})
+}
+
+func (m *Messages) LocalScanFriendly() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanFriendly",
+			Description: "Label for how many scanned pilots have positive standings",
+			Other:       "Friendly",
+		},
+	})
+}
+
+func (m *Messages) LocalScanNoAlliance() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanNoAlliance",
+			Description: "Heading for the corporations not in an alliance",
+			Other:       "No Alliance",
+		},
+	})
+}
+
+func (m *Messages) LocalScanKOS() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanKOS",
+			Description: "Marks a pilot, corporation, or alliance on the kill on sight list",
+			Other:       "KOS",
+		},
+	})
+}
+
+func (m *Messages) LocalScanMember() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanMember",
+			Description: "Marks a pilot, corporation, or alliance as part of the corporation or its alliance",
+			Other:       "Member",
+		},
+	})
+}
+
+func (m *Messages) LocalScanUnknown() (string, error) {
+	return m.l.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:          "localScanUnknown",
+			Description: "Label for the pasted names that are not characters",
+			Other:       "Unknown names",
+		},
+	})
+}
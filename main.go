@@ -18,6 +18,9 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
+	"os"
 
 	"github.com/cjslep/dharma/internal/activitypub"
 	"github.com/go-fed/apcore"
@@ -25,6 +28,9 @@ import (
 	"github.com/rs/zerolog/pkgerrors"
 )
 
+// importSDEAction imports the Static Data Export in dharma_sde_directory.
+const importSDEAction = "import-sde"
+
 func main() {
 	zerolog.ErrorStackMarshaler = pkgerrors.MarshalStack
 
@@ -32,5 +38,16 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	// apcore only runs its own actions, so importing the Static Data Export
+	// is handled before handing over to it.
+	flag.Parse()
+	if flag.Arg(0) == importSDEAction {
+		debug := flag.Lookup("dev").Value.String() == "true"
+		if err := a.ImportSDE(context.Background(), flag.Lookup("config").Value.String(), debug); err != nil {
+			fmt.Fprintf(os.Stderr, "error running %s: %s\n", importSDEAction, err)
+			os.Exit(1)
+		}
+		return
+	}
 	apcore.Run(a)
 }